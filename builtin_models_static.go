@@ -116,7 +116,7 @@ func inception_v3_0_yml() ([]byte, error) {
 	)
 }
 
-var _mlperf_bert_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x3c\x7f\x77\xdb\x38\x72\xff\xfb\x53\x4c\x99\x97\x17\x29\x91\x69\x2b\xde\xdd\xcb\xaa\xab\xed\x73\x9c\xa4\xe7\x6b\xce\xd9\x8b\x7d\x97\x77\x75\xfd\x78\x10\x39\x92\xb0\xa6\x00\x06\x00\x2d\x6b\xdb\x7e\xf7\x3e\xfc\x22\x01\x92\xb2\x9d\xed\xeb\x6b\xfe\xd8\x15\xc1\x99\xc1\x60\x66\x30\x33\x18\x0c\xcd\xc8\x06\x67\xf0\xe7\x8f\xbf\xa0\x58\x66\x6f\xdf\x7f\xbe\x82\x67\xa0\xc7\x80\x2f\x61\xc7\x6b\x01\x1b\x5e\x60\x79\xb0\x14\x64\x83\x5b\x2e\x6e\x67\x07\x00\x00\x16\xeb\x97\xdd\x15\x17\xf9\x1a\x9e\x41\xf3\x1a\x96\x5c\x80\x5a\xa3\x43\xd3\xb0\x77\x28\x24\xe5\x6c\x06\xd3\xf4\x4d\x3a\x8d\x80\xdd\x2b\xc8\x39\x93\x4a\x10\xca\xd4\x41\x00\x7d\x0c\xcf\x1a\x08\xca\x96\x5c\x6c\x88\xb2\xbf\x41\xe2\x86\x30\x45\xf3\xe6\xbd\x7d\x7b\x50\xa0\xcc\x05\xad\x94\xa1\xf0\xf3\x01\x84\x0b\x4b\x0f\x04\x2e\x51\x20\xcb\x51\xea\x55\x1c\xc2\x5a\xa9\x4a\xce\x8e\x8e\x56\x54\xad\xeb\x45\x9a\xf3\xcd\xd1\xa6\xcc\xf9\x66\xc3\x99\x3c\xa2\xcc\x01\x1f\x29\x81\x78\xb4\x21\x52\xa1\x38\x2a\x09\x5b\xd5\x64\x85\x47\x0b\x14\x2a\x22\xf2\x1b\x32\x5e\xf0\x94\x8b\xd5\x91\xc0\x9c\x8b\xe2\xe8\xe4\x0f\x27\x27\x6f\x7e\xfc\xe1\xe0\x19\x94\x34\x47\x26\x8d\x4c\x5b\xd9\xb8\xc1\x19\x9c\x56\x24\x5f\x23\x7c\xb4\xcf\x13\xf8\x9b\x5b\xd3\x6b\x23\x81\x41\xdc\x67\x40\x59\x55\x2b\x09\x8a\x07\xa3\x76\xcc\x6a\xe8\x10\xd4\xae\xc2\x19\xac\x90\xa1\x20\xe5\x01\xaf\x55\x55\x2b\xfb\xce\xbe\x51\x78\xaf\xcc\xe3\x33\x20\x10\x08\xce\xcf\x64\x31\xa0\x22\x5a\x5d\x0a\x85\x81\x8d\x04\xac\xa1\x24\x0a\x4a\x4a\xfa\x1b\x16\x0e\x41\x1e\x18\x66\x66\xf0\x0c\x64\x85\x39\x5d\x52\x94\x96\x3f\x58\x09\x52\xad\x81\xb0\x02\xb6\x48\x57\x6b\x25\x41\xa0\xe4\xb5\xc8\x51\x1a\xe2\xe6\x7d\x56\x11\xb5\x9e\x35\x62\x95\x27\x29\xd9\x90\xdf\x38\x23\x5b\x69\x34\x24\x15\x17\x98\xe6\x44\x6c\x4a\x23\x6c\x43\x5a\x1e\x55\x3b\xa5\x6d\xd1\xe8\x25\xad\xec\xc2\xa8\xcc\x88\xc8\xd7\xf4\x0e\xed\xba\xf5\xbf\x25\x29\x25\xc2\x33\xa0\x4b\x90\xa8\x26\x7a\x0d\xcc\x2c\x64\x41\x24\x66\xb5\x28\x81\x4a\x20\xa0\x7f\x28\x0e\x84\x81\xa3\xd0\x10\x78\x66\xa0\x5b\x56\xc3\xf5\xd8\x01\x43\xb2\x40\xc6\x15\xea\xdf\x01\xe6\x92\x96\x68\xf6\x8e\xf4\x42\xee\x8b\x64\x4b\xd5\x9a\x5a\x96\xc2\xa9\xed\x84\xf9\x1a\xf3\x5b\x59\x6f\x66\x90\x9f\x2c\x16\xdf\x93\xe3\xef\xff\xb0\xcc\xc9\xc9\x82\xfc\xe1\xf8\x87\x93\xd7\xdf\xe3\x49\x91\x2f\x09\x99\xe6\x79\x71\xb0\xc0\x25\x17\x98\x55\x02\x2b\xc1\x73\x94\x72\x06\xff\x75\xa0\x99\x38\xe3\xd5\x4e\xe8\xb9\x60\x94\x8f\xe1\xf5\xf1\xf4\x47\xb8\xf8\xdb\xf9\xbb\xf3\x53\x38\xfb\xf4\xf9\x97\x4f\x9f\x4f\xaf\xce\x3f\x5d\xa4\x70\x5a\x96\x20\x1a\x2d\xa1\xb8\xc3\x22\x35\xf8\xce\x4e\x0b\xa8\x59\x81\x76\xb3\x3f\x64\xc1\x23\x0d\x90\xb8\x57\xc9\xf8\x9f\x0d\x8d\x1d\xaf\x61\x43\x76\xc0\xb8\x82\x5a\x6a\x31\x51\x69\xa5\x83\xf7\x39\x56\x4a\xef\xf1\x9c\x6f\xaa\x92\x12\x96\xa3\x91\x89\x99\xc8\x91\xb1\x8c\xfc\xdd\x11\xe1\x0b\x45\x28\x03\x02\x39\xaf\x76\x5e\xb0\x0e\x12\x88\x36\x85\x67\x06\x5e\xff\xd3\x66\x35\x3b\x3a\xda\x6e\xb7\x29\x31\x4c\x1b\x1b\x72\x7b\x4c\x1e\x7d\x3c\x3f\x7b\x7f\x71\xf9\xfe\xf0\x75\x7a\xdc\xa0\xfd\x95\x95\x28\xb5\x10\xbe\xd6\x54\x60\x01\x8b\x1d\x90\xaa\x2a\x69\x4e\x16\x25\x42\x49\xb6\xc0\x05\x90\x95\x40\x2c\xb4\xc9\x50\x06\x5b\x41\x15\x65\xab\x09\x48\xbe\x54\x5b\x22\xd0\xd0\x29\xa8\x54\x82\x2e\x6a\x15\x89\xce\xf3\x49\x65\x04\xc0\x99\xb6\xbd\xe4\xf4\x12\xce\x2f\x13\x78\x7b\x7a\x79\x7e\x39\x31\x54\xbe\x9c\x5f\xfd\xf1\xd3\x5f\xaf\xe0\xcb\xe9\xe7\xcf\xa7\x17\x57\xe7\xef\x2f\xe1\xd3\x67\x38\xfb\x74\xf1\xee\x5c\xeb\xed\x12\x3e\x7d\x80\xd3\x8b\xbf\xc3\xbf\x9d\x5f\xbc\x9b\x00\x52\xb5\x46\x01\x78\x5f\x09\xbd\x06\x2e\x80\x6a\xa1\x7a\x55\x5e\x22\x46\x4c\x78\xe7\xed\x36\x6e\x0e\xde\xdd\xc1\x8a\xdf\xa1\x60\x94\xad\xa0\x42\xb1\xa1\x52\xab\x57\x6a\xbb\x35\x74\x4a\xba\xa1\xca\x38\x67\xd9\x5f\x5a\x7a\x60\x60\xae\x1a\x15\x53\x09\xb4\x40\xed\xbe\x89\xd9\x64\xef\x10\xab\x8f\x48\x0c\xf9\xf7\xf7\x64\x53\x95\x28\x8f\xae\x90\x49\x2e\x3e\x94\x7c\x7b\xf4\xd1\x31\xf1\x67\xbd\xd3\x29\x5b\x1d\x69\x6f\x7e\x54\x2b\x5a\xca\xa3\x5c\x20\x51\x98\xc9\xaf\x35\x29\xb2\x82\x28\x92\x56\x3b\x33\x9d\xb3\x23\xb5\x26\xca\x30\x53\x60\x85\xac\x40\x96\xef\xb4\x6c\xd7\x5c\xf0\x3b\x5e\x68\x56\x04\x6e\xb8\xb1\xed\x03\x80\xa5\xe0\x1b\xc8\xb2\x65\xad\x6a\x81\x59\xa6\x85\xc5\x85\x02\xb2\x90\xbc\xac\x15\x66\xf6\x79\x3f\x60\x41\xef\xa8\x96\xcc\x7e\x88\x4a\x50\xa6\xb2\x65\xcd\x72\x2d\x2d\x3d\xa7\x7b\xb1\xb5\xeb\xd7\x8e\xd0\xff\x4c\x97\xb4\x54\x28\xfc\xe3\x28\xa1\x2b\xc6\x05\x26\xe3\x00\x2d\xe7\x65\x89\x86\x96\x6c\x07\x7f\x95\x86\x05\xf7\xb4\x21\x6a\xdd\x3e\xf1\x00\x4e\x10\x56\xf0\x8d\x11\x97\x87\x75\x22\x0e\xc7\x78\xa5\xe8\x86\xfe\x66\xd4\xdb\xe2\x2a\x7e\x8b\xac\x37\x2a\xe9\x7d\x00\x62\x54\xb8\x2c\xf9\x16\x88\x04\xb5\x0c\x89\x3a\x05\xa4\x31\xcc\xfa\xae\x08\xd0\xe9\x06\x8d\x52\x4a\xb2\x92\x30\x07\xb5\xd4\xfe\xbf\x22\x2a\xbd\x9b\xa6\x66\xf0\x00\xe0\xc3\xc7\xd3\x7f\xbd\x84\x39\x5c\x70\x66\x80\x0b\x5c\x02\xde\x2b\x41\x72\x95\x19\x98\xd1\xd8\xfa\x7e\xf3\x90\xbe\x7b\xff\xe1\xfc\xe2\x7d\x46\x99\xc2\x15\x8a\x91\x73\xcd\xc9\x86\xdc\x67\x12\xbf\x66\x25\xb2\x95\x5a\x27\x13\x38\x79\xf3\xdd\xc4\xbf\xbc\xd2\xf1\x95\xdc\xd3\x4d\xbd\x01\xc5\x15\x29\x6d\xec\x05\x89\x5f\x6b\x9d\x1f\x80\xc5\x02\xb2\x54\x28\xe0\x0b\x17\xc5\x2f\x14\x73\x8c\x24\x94\x42\xe2\xc9\x5d\x3a\x34\x09\x25\x67\x2b\xb3\x55\x08\xb3\x0e\x70\x4b\xcb\x12\x16\x08\x4a\xd4\x2c\x27\x0a\x8b\x89\x89\x0c\xb2\xc1\x90\x6b\x2e\xf4\x24\x0d\xb1\x3e\x6e\x45\x8a\x02\x8b\xd4\x1a\xc9\x23\xcb\x2e\x78\x9e\x69\x97\x53\x60\x32\x81\xe9\xeb\x37\xcd\x92\xbf\xe8\x08\x26\xab\x92\x2a\xed\xc7\xa0\xae\x80\x18\x6e\xa1\xe0\x79\xbd\x41\xa6\xfd\xb3\xe2\x90\xaf\x6b\x76\x2b\x27\xb0\xe6\x5b\xd8\xd4\xf9\x1a\x2c\x31\xbd\xa7\x5b\x0e\xc9\x2d\xc2\x02\xd5\x16\x91\x39\x84\xa7\x31\xa7\x75\xf2\xb5\x46\xb1\x6b\xb5\xf2\xc3\xb0\x52\x58\xbd\x59\xa0\x30\x8e\x5f\x8b\x5c\x36\x6e\xec\x6b\x8d\xd2\x4a\xff\x2f\xee\x57\x2c\xf4\x40\x8c\x43\xd2\xb7\xc9\x15\x95\x4e\xc1\xc3\x6c\x2f\x38\x2f\x1b\x9e\x5d\x36\x9a\xbd\xce\x74\xc4\xca\x18\xae\x88\xa2\x77\x5a\xba\x1f\x74\xda\xd1\x70\x7f\xbe\xd4\xb3\xa0\xc9\x3e\xe0\xf2\x2f\xf5\xe9\x3b\x40\xe7\xf8\x74\x2e\x6c\xc2\x99\xe4\x1b\xb4\xee\xab\xe0\x26\x4e\xae\xc9\x1d\x9a\x84\x84\xc9\x2d\x8a\x61\x6e\xb4\x02\xd8\x6a\x94\x98\x64\x3a\xd3\xde\x36\x99\x98\xbd\x31\x69\xb2\x90\xee\xbf\xc4\xce\xaf\x7d\x86\x15\x9c\x46\xa5\x6c\x95\xc2\xfb\x74\x95\x4e\xec\xf3\xe1\xdd\x34\x9d\xa6\x1a\xe6\xa1\x69\xfd\xf2\x2a\x81\x05\xd5\x1b\xb0\x3f\x7f\x77\x36\x07\xaa\x55\xe3\x27\x2c\xf0\xae\x9d\x4e\x87\x2c\x85\x52\x7d\x1b\x03\x2e\x1c\x50\xd1\x9d\xfd\xaa\xcd\x6b\x0b\x2a\x30\x57\x5c\xec\x60\xbb\x46\x81\x6d\x1a\x0d\x26\xc9\xaa\x38\x65\xaa\x35\x0a\x1d\xd2\x15\xb2\x87\xa5\x7e\xc7\x73\xb2\x78\xa2\xd4\x35\x27\x06\xbe\x2e\x89\xd8\xd9\xc0\xd8\x04\x2b\x73\x0e\xb3\xcc\x6c\xb5\xeb\xd4\x2a\x30\x49\xc1\x13\x6c\xb0\xe0\x59\xc9\xb7\x28\xb2\x9c\x48\xcd\xc7\x95\x36\xb4\x60\x63\x9b\x84\x40\x71\x30\x40\xa0\x81\xcc\x94\xd6\xa7\xe9\x33\x41\x0a\x97\x6b\x5e\x97\x85\x5e\xb6\x46\x36\x7a\xd2\x5b\x42\xe7\x7c\xcd\x96\xb1\x69\xb7\xf1\x4f\xc6\xb6\x0d\x94\x85\xb1\xaf\x9e\xb6\x5d\x16\x5c\x62\x56\xf2\xd5\x8a\xb2\xd5\xfe\x6d\x42\xca\xd2\xe7\x75\x3e\x1a\x82\xc0\xd2\x6f\x52\x1d\xf7\xc1\x65\xb9\xda\x5f\x35\xbe\x50\x07\x5b\x2c\x02\xe7\x7b\x1a\x38\x8b\x86\x12\x11\x3a\xed\xac\x30\xd7\xe4\xf4\x32\x08\x30\x7d\x9c\x2c\xfd\xd6\xbc\x23\x65\x6d\xdd\x78\x32\x0e\x96\xb4\x21\xe2\xd6\x04\x99\x8c\xc8\xcc\xa7\x87\xd1\xde\x7b\x02\x74\xb4\x57\x9e\x00\xdf\x9a\xf6\x13\x80\x03\x83\xb4\xd0\x02\x55\x2d\x98\x43\x32\xb1\x53\xeb\x28\x2f\x89\x94\x70\xa9\x29\xbb\xfc\x6b\xc4\x17\xbf\x62\xae\x5c\xec\x4c\x92\xe4\x14\xb4\x64\xb5\x8d\x3a\xf7\x70\xa4\x77\xa5\xf7\x5a\x46\x6a\x92\x9a\x9f\x4d\x58\x34\x54\x75\x16\x69\x45\xe7\x54\xf0\x81\x8b\xd6\xd7\x69\x2f\xc9\x6b\xd5\x7a\x35\xeb\x11\xa5\x22\x3a\xeb\x62\x05\x20\x2b\xa0\xe2\x92\x9a\x73\xa9\xd6\xd3\xe1\x34\xf5\x2c\x1d\xb8\x03\xe9\x12\xb2\x8c\x32\xaa\xb2\x6c\x24\xb1\x5c\xf6\xf7\xdc\x57\x22\x33\x5a\x0c\x8c\xbb\xa0\x90\x69\xab\xef\xbf\xd6\xd1\xd1\x06\x94\xfe\x3b\x2e\xe8\x2a\xb3\x2c\x1b\xec\xf9\xf0\x6e\x37\x0b\xc9\xfc\x02\xf6\x00\x21\x2b\x1e\x03\xa1\xd2\xa4\x9e\x52\xd2\x45\x89\x73\xb3\x49\xc6\xfe\x48\xab\xd7\x9c\xda\x25\xc2\xdc\xad\x35\x7a\x15\xae\x52\x43\x84\xcf\x21\x60\xbb\x5e\x98\x07\x8b\x0f\x41\xba\xcb\x86\x79\x4f\x12\x21\x78\xbc\x7c\x98\x77\xe4\x11\x82\x86\x42\x80\x79\x24\x93\x10\x2c\x12\x04\xcc\x63\xc1\x84\x06\x21\x95\x70\xf6\xd0\x08\xca\xd9\xbe\xa1\x93\x65\xfa\x58\x9c\x65\xa3\x71\x88\xe4\xc6\x22\x2c\x2d\x8c\x24\x69\x1e\x5e\xcd\x21\xb1\x32\x9e\xc1\x73\x99\xc0\x73\x18\x45\x79\x9e\x71\x39\xfa\x60\x68\x84\x31\x0a\x94\x33\x1e\x47\x44\x26\xb1\x22\x1a\x6a\x81\xee\x1f\x27\x1c\x52\xe8\xd1\x6f\x55\x38\x83\xeb\xe7\xf2\xc6\x90\x4f\x20\x49\x7f\xe5\x94\x8d\x3a\x2a\x6f\xb0\x4d\x5d\xa4\xa7\xbb\xb6\x7e\xe2\xa9\x77\xde\xc3\xf3\xc2\xd0\x1f\xc0\xfd\x56\xca\xa1\xee\x63\xba\xe1\x9b\x6f\xa5\x1a\x59\xca\x0c\x9e\x8b\x96\x6c\xf4\x6a\xdc\xb1\x96\xd6\x3f\x9e\xeb\x08\xf9\x01\x89\x3e\xd0\xc9\xfd\x0e\x52\xa2\xd2\xd1\x65\xe9\x00\xf5\x6f\x73\x2e\xfd\x16\x8f\x55\x33\xfa\xb5\xc6\x41\xa7\xe5\x7c\x67\x46\x59\x81\xf7\xc3\x4e\x4b\x56\x84\xed\x7b\xbf\xcf\xa1\x99\xf1\x4c\xf1\xcc\xec\xe7\x0d\xa9\xf6\x81\x50\x99\xe9\xcc\x5c\x67\xa9\x83\x5e\xd3\xe4\x11\x19\x2d\x06\xe6\xb0\xaf\x36\x44\xde\x0e\x78\x4a\x5c\xe9\x73\xc5\x30\xe2\xff\x8d\x1b\xd5\x30\xb1\x17\x6d\xc4\x0e\xf3\x56\x05\x91\x97\x0a\x85\xaf\xdd\x54\xf8\xdc\xf5\xa5\xad\x1a\x9c\x3f\x6d\x07\x42\xd0\xc6\xe5\xf6\xdd\x6d\x4f\x29\x1e\x2a\x1c\xeb\x23\xc4\x2a\x6a\x70\xe2\xe1\xc8\xab\x7a\x9d\x69\x8f\xea\x7f\xf7\x01\xb4\xe6\x1a\x08\xfd\x10\xf9\xfa\x56\x81\xda\xd1\xb7\x4f\xff\xcf\x01\x41\xef\x36\x81\xa4\x70\x25\x22\x9f\x7a\x8c\xec\x2a\x74\x6a\x64\x7c\x83\x4f\x6b\x26\xb0\xe7\x0c\x17\x45\xdd\x24\x49\x3e\x23\x29\x80\x40\x78\x9c\x31\xb5\x2d\x7d\x2e\x26\x50\x52\x69\xbc\x40\x98\x53\x19\x0f\xa0\xb1\x6d\x35\x33\xac\x66\xac\x34\x6e\xfa\xa9\x42\x16\xf1\x95\x88\x64\x0c\x44\x1a\xfe\x51\x78\x53\xb5\x10\x26\xe7\x9d\x9b\x99\xd3\x92\x93\x62\x64\x81\xc6\xd7\x89\x7e\x93\xdc\xb4\xbe\x86\xca\x6c\xbb\xa6\x0a\x65\x45\x72\x1c\xe5\x8d\xc9\xd3\x25\xe4\x30\x9f\x43\x02\x89\x3e\x65\xd9\xdf\xff\xa1\xc2\x07\x11\x3e\x30\xf3\xc0\x45\x31\xca\xc7\x7a\xe4\xf8\xfe\xf5\xf1\xeb\x0f\xad\xa7\x75\x1e\x53\x1f\x19\x62\x1f\x6a\x24\x67\xf9\x69\x32\xbf\x39\x5c\xdf\xd8\xfc\x55\xe7\x83\x4c\x89\x1d\x50\x16\x2c\xcd\x93\x35\x67\x44\x22\x88\xad\x93\x53\x66\x61\xaf\x93\x66\x4c\x26\x37\x2d\x0b\xcd\xa8\xcf\x4e\x9a\x81\xeb\xc4\xd9\x7d\x72\xd3\x40\x47\x09\xcf\x75\x3b\x9e\xaf\x89\xd0\x3b\x6c\xcb\x45\x91\xf1\xe5\x52\x7b\xf4\x08\xa0\x12\x78\x97\x45\x62\x85\x79\xb8\x6e\xcb\x76\xae\xd9\x8d\x39\x9a\x05\x2e\x89\xee\xd7\xcc\x53\x67\x01\xc0\x52\x62\x8c\x45\x97\x03\x88\xb3\x8e\x2b\x6c\x57\x9e\x92\xaa\x42\xa6\x55\x1a\x81\xf4\xe9\x86\x48\xd7\x87\xd3\x1b\x1d\x56\xf3\xc7\xf9\xb5\xba\x6f\x81\x06\x64\xeb\x59\x28\x91\x8d\x82\x84\x04\x0e\x61\xea\xb2\x33\x2f\xd1\xaf\x24\x12\xe9\xb5\xce\xc4\x42\xf5\xfb\x54\xdf\xe4\xc1\xd7\x09\x2d\x02\x65\x77\xd2\x7d\x07\xe2\xc7\x22\xc0\x9e\x97\x32\xc5\xc5\x40\x38\xb1\x6b\xea\xbc\x1d\xc8\x91\x3b\x10\x5d\x9f\xd5\x95\x91\xb5\x0c\xef\x91\x66\x07\x5d\xfd\xee\xf1\x50\x5d\x7d\x75\xa7\x31\x22\x09\xc7\xa2\x45\x1b\xca\x46\x07\x1a\xce\xf2\x2f\x93\x9b\x31\xfc\xd3\x1c\xa6\x63\x73\x18\x1b\x31\xae\x62\xaa\xe3\xee\x9c\x82\x50\x89\xf0\x37\x52\xd6\xf8\x5e\x08\x2e\x46\x03\x05\x90\xe4\x43\x50\x65\x9a\x00\x92\x7c\xdd\xe8\x06\xa4\x2d\x3d\x98\x5a\x17\xde\x93\x5c\x95\x3b\x98\x06\x25\xaf\x0e\xc3\x3d\x8e\xba\x0c\x59\x4c\xb7\xfa\x66\x55\xd7\xc7\x37\x1d\xb8\x01\xad\xd9\xa7\xeb\xa4\xe3\x35\x42\xba\xad\x77\xf0\xc0\x6e\xdc\x98\xd0\x3e\x24\x57\x2f\x9e\x83\x96\x76\x77\xe2\x71\x07\xa7\x67\x8c\x03\x3b\xe8\x3a\xe2\xa6\x3b\x6b\xc7\x5c\x1f\xc5\x87\x57\x1d\x46\x0f\xf7\x56\xb1\xf6\xfd\x9b\x76\x79\x78\x06\x9f\x58\xb9\x03\x52\x14\x8e\xb6\x0c\xaa\x6e\x46\xde\x39\x61\xb0\x68\x75\x2e\x30\xe7\x77\x28\xb0\xb0\x17\x29\xe1\x35\xaa\xa7\xe8\x6b\xd1\x29\x9c\x2f\x6d\x9d\xf6\xec\xf4\xe2\xc5\x15\xac\x8d\x3f\x01\xaa\x5e\x48\x28\xe9\x2d\x96\x3b\x28\x6a\x53\x94\xde\x22\x15\x05\xfc\x95\xd1\x9c\x17\x7d\x7a\x52\xd5\xcb\x25\x48\x0d\x66\x2b\x48\xbf\xd6\x52\x81\xbc\xa5\x95\xe1\xd2\x85\xae\xb4\x8b\xd7\xa3\x73\x61\xef\x7d\x4d\x29\x8f\x4a\xd8\x20\xf1\x25\x69\x67\xf3\xa6\x40\x36\x01\xbc\x43\xb1\x6b\x2a\x28\x54\xc2\xc5\xa7\xab\x1e\xb1\x55\x4d\x04\x61\xca\xdd\x2d\x9a\x9a\x56\x70\x19\x1b\xfe\x23\xb9\xaa\x49\xe9\x8d\xb7\x39\xe7\x0d\xe8\x2e\x70\xe4\x9d\x53\xd3\x28\xb2\x95\x57\x30\x1d\xdf\x74\xed\x31\x2f\x91\x30\x2c\x3a\x7b\xe5\xa1\xe9\xa2\x63\x6c\x1b\x1c\x32\x37\x8e\xfd\x2d\xd0\x9d\x93\x2e\xc3\xd5\xa5\x4b\xaa\x03\x56\x9f\x0f\x93\x95\x1c\x4e\xbb\x2e\x00\x74\xae\xe5\x4a\x8c\xa9\x2b\xfa\x8d\x92\x33\xe3\x67\xb4\xff\xd0\xe4\x9c\x55\xce\xe0\xc5\x73\xf9\x02\xee\x64\x6a\x7e\x24\xfb\xeb\xb7\x83\x72\x9f\x0c\x49\xa7\xbb\x18\x30\x15\x7e\xca\x6a\x7c\x34\xe2\xf6\xf6\xfe\xe1\xf4\xe1\xbd\xdd\x7b\x3f\xe0\xd6\xfc\x31\xd4\x11\x70\xe6\x37\x8f\xcb\x7f\x1d\x32\x36\xac\xce\x87\x0b\x69\x51\x5c\x9d\x3f\x58\x54\x6b\x2d\x6f\xbe\xbf\xba\xd6\xab\xad\x75\x07\xba\x08\x9d\xe3\x61\xfc\xd8\x05\x8e\x8e\x89\xe1\x43\x17\x30\x3e\x2c\x0e\xd6\x08\x02\x01\x36\x89\x94\x7b\x76\x99\x8b\xcb\x80\x3d\x90\x3f\x90\xd8\x3e\x8e\xce\x89\x6c\xe4\xcf\x88\x72\x02\x79\x2d\xc2\x63\x7c\x53\x03\x6d\xcf\x1f\x67\x9a\x82\xde\x19\xc6\xc9\x50\x69\x7c\xd4\x8b\x0d\xb9\x07\x47\xee\x85\x96\x36\x68\x22\xcd\x7d\x98\x91\x77\x5b\x88\x78\x06\x6f\x31\x27\x75\xdb\x5b\x24\x4b\x5a\xd8\xfa\x39\x2b\xf8\x16\x48\x55\x09\xae\xa3\xb3\x22\xb7\xc8\xb4\xff\x91\x39\x17\x34\xb8\x06\x94\x13\x20\xae\xee\xe1\x28\x9a\x29\x8c\x33\xd7\xf2\x20\x42\x27\x6c\x9b\xba\x54\x54\x1b\x59\x83\x66\xaf\x7a\x1c\x0a\xbc\xe3\xb9\xed\x25\xda\x10\x06\x5b\x64\xca\x77\x35\x99\x56\x1f\x93\x7a\x2c\x78\xbd\x5a\x2b\x20\xb0\x22\x65\x69\x5b\x94\x36\xb4\xbc\xf5\x14\x2e\xf5\x2a\x4f\x07\x89\x44\x20\x6f\x67\xfb\x48\x47\x60\x67\xb3\x3d\x93\x7a\xa8\x34\x75\xcc\xbb\x81\x0b\xbe\xb5\x97\x13\x5c\x14\xf0\xc2\xe2\xbd\xb0\x41\xc4\x64\x32\x6a\x6b\x65\x87\xd2\x86\x33\xa3\x65\x78\x6b\x66\x39\x4b\xe1\x0b\x02\x67\xe5\xce\x11\xdb\x12\xcb\x7c\xce\x99\xa4\xbe\x97\xc2\x60\xdb\x93\x63\xe2\xef\x3d\xfd\x89\x66\x02\xdb\x35\xcd\xd7\x3a\x6e\x15\xb8\xa4\x0c\x81\x48\xaf\x8e\x35\xc2\xcb\x0d\x65\x1a\xfe\xa5\x96\x1a\x55\x12\x4a\x5c\xda\xea\xba\xed\x02\xf2\x75\x02\xd3\xa8\xf3\x52\x3a\x40\x0f\xe4\x08\xc5\xa0\x66\x65\xa4\xdc\x92\x9d\x34\x37\xa6\x9a\x41\xb2\xc1\x89\x46\xcc\x79\x2d\x24\x8e\x63\x01\x9d\xb3\x30\x86\x3a\x3d\x45\xab\x30\x46\xda\x8a\xce\x5f\x40\x19\xfb\x3d\xd3\x36\x96\x7b\x55\x52\x05\x6b\x22\x61\x6a\x59\xf4\xe8\x7a\x3d\x27\x31\x9b\x46\x2e\xa5\xa3\xf1\xd6\x20\x7d\x17\x21\x39\x82\x1a\xf5\x38\x46\xb5\xdc\x2f\x50\xaa\xcc\x4a\x3e\x48\xe2\xed\x68\x58\xdc\x69\x5e\xe9\x35\x8c\xc2\x7d\xeb\xb7\xf4\xd8\x1e\x5d\xeb\x0d\x0a\xa2\xb0\xdd\xe9\x4d\x02\x8d\xac\x08\xaa\x44\xb6\x50\x02\xaf\xda\x01\x9f\x8a\xc1\xb4\x3d\xbd\x37\x8e\xff\xa7\x0e\x62\x1b\x47\x3a\xa1\x26\x44\xfa\x59\xcf\xb9\x17\x92\xd5\x9b\x4c\x8b\x2a\xa8\x22\x35\x98\x87\x9d\xe9\x02\x14\x23\xc4\x00\x47\x2f\xeb\x10\xba\x85\x1b\x27\xd1\x0d\x65\xa3\xee\x3c\x93\x3e\x99\x31\xbc\x82\xe3\xf4\x78\x0a\x2f\xbb\xe2\x68\x57\x15\x68\x4a\x27\x52\x9c\x21\x70\xe1\x26\xfa\x39\x78\xdb\xae\x37\xd2\xad\xf9\x7f\xe7\x55\xa8\xe0\xa0\x72\x17\xba\xf5\xd8\x4b\xeb\xec\xa3\x83\xda\xf8\x7b\xba\xa9\x04\xbf\x43\x1f\xc5\x34\x44\x70\xd6\x9d\xb8\xc2\x87\x91\xa6\x7f\x40\x56\x4c\x7c\xee\x84\xe2\xa1\x4c\xa4\x97\x3f\x05\x45\x2a\xcd\xa7\x6c\xc8\xf8\x3c\xc7\x39\x20\x93\xa4\x2e\x50\x29\x14\xb0\x21\x2a\xb7\x3d\x76\x84\x31\xae\xcc\xd5\xaa\x3f\x76\xb5\x01\xe3\xaa\xe9\x57\x70\x50\xb6\x0d\x4c\xa0\x39\x56\x90\x5c\x53\x5a\x10\x89\x85\x71\x6a\x4b\x2a\xa4\x82\x4a\xf0\x5f\x31\x37\x17\xdb\x1b\x50\xdc\x7b\xb9\x26\x11\x3c\x6c\xb9\xd3\xfe\x53\xa6\xf0\xb6\x56\xb6\x89\xf2\xa1\x96\x9a\x89\xf6\x77\x39\x61\x8e\x1e\x5f\x2a\x64\x2e\x97\x83\x24\x5c\x54\x92\x86\x97\x8e\xb3\xc8\x2f\x41\xd3\x19\x32\x83\x2f\x5a\x1a\x3b\x1d\xb3\xb6\x44\xc2\x9f\xf8\x9a\xc1\xe5\x46\x3b\xdc\x05\x17\xec\x5f\x1a\x84\x33\x6b\x95\x33\x23\x8b\xd2\x14\xdd\xba\x08\xa3\xe9\x9b\x1f\xbf\x3f\x9c\xfe\xf8\xdd\xc9\xb8\x09\x72\x70\xea\x32\x4c\xfd\x2e\xe2\xc1\xf4\x24\x08\xba\xa2\x8c\x94\xc3\x62\x71\x4a\xf3\xd7\xda\x49\x48\x3f\x49\xe1\x8f\x7c\xab\xcf\x12\xde\x9b\x19\x99\xc5\x92\xe2\xb5\xf0\xbd\x31\x2d\x11\xc3\x89\xf6\x28\x3f\x7e\x77\x02\x63\xd0\x94\x2e\xb9\x13\xaa\x95\x5c\x10\x44\xcc\xb9\xac\xb9\xa4\xd5\x98\xb1\x83\x77\x3c\x4c\x9a\x74\x44\x67\xd6\x2e\x42\xf8\xb4\x29\xd5\xb2\x6b\x43\xda\x92\x97\x25\xdf\x9a\x0a\xc7\x83\x2a\xc9\x79\x6d\xeb\x83\xd2\x25\x31\x15\xe0\x7d\x65\x1b\xa1\xf8\x12\xb0\x34\x6d\x1c\x8c\xe6\x72\x8f\x92\xfe\x44\x2a\xc2\x50\xa2\x05\x15\x5c\x83\x02\x65\x45\x2d\x03\xb2\x25\x59\xa1\x34\x7d\xa7\x2e\x90\x97\x45\x5f\x77\x86\x52\x3f\xb0\x51\x69\x3a\x1f\x26\xe1\xf6\xe1\x02\xf2\x35\x97\x08\x89\x41\x4a\x80\x48\x20\xc1\x2e\x91\xf5\xe2\xd0\x04\xa6\x26\xab\x68\x12\x88\xc4\x33\xac\x35\xa2\x03\x9f\xd1\x5f\x6f\x17\xa0\x80\x82\xa3\x95\xb4\xe9\xd5\x72\x74\x5a\x74\xb3\x45\xcc\x29\xb6\x6e\x18\xd1\xe3\xa6\x1d\xaf\x65\xd5\x74\x48\x5d\x39\xbd\x2d\x09\x15\xfa\xfc\x4d\x44\x13\x6f\x99\xdd\xf2\x13\x58\xd4\xca\x4e\x69\x8f\xd8\x56\x3e\x8a\xdf\xee\x3b\x0c\x36\x8c\xa6\x0f\x9d\xf5\x9a\xc8\xc9\x70\x6b\x5d\xa0\x9e\x52\x10\xb6\xc2\xd1\xb0\x5f\x34\x27\xd3\xb0\x42\xac\x31\xf5\x78\x07\xcf\xb8\xd0\x96\xea\x21\x4c\x27\x70\x38\x0d\x8a\x56\x9a\x03\xe3\x8c\x43\xae\x83\xe3\x71\x83\x3b\x1b\xf9\x29\x3a\x87\x62\x9d\x80\xb7\x44\xe6\x5d\x69\x84\x27\x3a\x17\x34\x46\x0d\xd1\x89\xe7\x3b\x3e\x2c\xec\x59\xf4\xf8\xc0\x47\x93\x9c\xb3\x3b\x14\xaa\xb9\xcb\xc8\x14\xcf\xfc\x75\xa3\x3f\x7f\xc8\x30\x76\x40\xdc\xdf\xf8\x94\x53\x6d\xdb\x1a\x68\xb1\xc3\x4e\xbc\xf8\xc2\xe4\x09\xc4\x6c\xc7\x55\xb6\x64\xe6\x76\x25\x6c\xf9\xe9\xde\xaa\x7c\xe4\xa4\xd0\xfb\xc4\xdc\x6e\x0c\x5d\xa8\xfc\xc3\xdc\xc2\xbe\xd5\xde\xe9\x1f\xb2\x8d\x4c\xe1\xcd\xdd\xf4\xd8\xff\x6b\x4d\x6b\x14\x5f\x9b\xfa\x60\xd0\x49\xcb\xbc\xec\x1a\x0b\xb1\xab\x8e\x6f\xe8\x62\x7b\xf6\x55\xa1\xf8\x2a\xfe\xa0\xcd\x4c\x4c\x45\x35\x20\x33\x86\x9f\x7b\x02\x6d\xad\xa4\x33\x61\xf8\x78\x7d\x3c\xeb\xe2\xdd\xf8\x79\xb4\xd5\xf9\x2b\x41\x9f\xb3\x34\xf7\x15\x66\x54\x71\x4d\xa6\xff\x92\x94\x65\x36\x7c\x0f\x62\xc4\x46\x9d\x19\x0d\x0b\x2a\xec\x1f\x68\xd7\xd0\x9b\x2f\x2c\xf0\xc7\xf3\x05\x95\x1e\x59\x2f\x1e\x14\xb4\x65\x23\xba\x0e\x68\x50\xcc\x17\x41\x0d\x7e\xb8\xe7\x7a\x82\xf1\xbc\xd0\xf0\x0c\x1f\x33\xe5\x41\x1a\x8a\xe3\x50\xcc\x0f\x5d\x0e\xe8\xf7\xfb\x2f\x07\xe2\xb2\xbe\x6d\x6f\x72\x72\xdc\x53\xc2\x1e\x9c\x2f\xa8\xf1\x0c\xcc\x77\x38\xdd\x3f\x9b\x0e\x14\xbf\x67\xc6\x9e\x42\xaf\x3d\x95\x18\xf4\x26\xf4\x8a\x1e\x24\xe2\xef\x27\xb3\x1d\x06\xac\x47\xfb\xe6\xae\xde\x3a\x2b\xdb\xcf\x45\xb7\x68\x79\x13\x1c\x93\xfa\x85\xb5\x01\xd2\x03\x86\x19\x91\x18\xf5\xc5\x32\xe9\xd1\x19\xc3\x7c\x38\xcf\x8f\x1c\x64\x3c\xcd\x64\x40\xe0\x7d\xca\x7b\x8f\x01\x5e\x00\xfd\x3b\x04\x07\x66\x93\xcb\xc3\x13\x20\xb9\xc9\xa2\x6c\x1d\xfa\xfa\xec\xe3\xe5\xcd\x04\xae\x2f\xdf\xff\x72\x63\x0c\xc3\xfc\x72\x28\xda\xc7\x58\xe6\xb2\x25\x17\x9a\x57\x7d\x52\x8b\xe2\x07\x1c\x0e\x78\xb5\x43\x38\x69\x67\xfd\x62\x93\x48\x53\xfb\x68\x8a\x3e\xf6\xb8\xa1\xcf\x0a\x71\xe3\x7b\x5b\x0a\xe8\x74\xd4\xa7\xed\x2a\x38\x14\xa8\xb3\x63\xfb\x1d\x10\x95\xa6\xd6\xa1\xe3\xc2\x9e\x92\xd5\xc4\x5d\x2f\x6c\xd1\x14\xaf\x5c\xe3\x79\x43\xcf\xd5\xbb\xea\x0a\x14\x37\xc9\xd5\x86\xdc\xfb\x36\x7e\x33\x07\xf1\xad\xec\x3a\xe4\xb4\xb1\xf0\x1f\x9e\xa5\xec\x1d\xcf\x2f\x6d\xde\x10\x7c\x81\x91\x32\xb2\xc1\x42\xd5\x55\x89\x23\x3d\x4d\xb5\x2b\x29\x53\x33\x28\xa8\x24\xa6\x88\xc8\xee\x48\x49\x8b\x43\x0d\x16\x28\x32\x71\xc4\x92\x09\x5c\x27\xf6\xe6\x68\x02\x89\xeb\x7a\x6f\x72\x8d\xa6\x66\x10\x3a\x69\x6b\x3a\xcd\x2d\xd4\xb1\x1b\x76\x75\x8f\xf0\xe5\x4f\x43\x56\xde\xee\x8b\xe8\x4e\xaa\xbf\x17\x42\x52\xe1\x2e\x77\x68\x3f\x0f\x98\x4d\xb8\xe7\x1a\xea\x7d\xb0\x06\xaa\x59\x9f\xf7\xbf\x5e\xc6\x23\x33\xf9\x3c\x64\x61\xe2\x28\xce\xed\xff\xc6\x51\x3e\x16\x2d\xfb\x55\x33\xf7\xe0\xd2\x42\x26\x17\x02\xc9\x6d\x1b\x8f\x22\x2a\xb6\x58\xe1\xd3\x9f\xd6\x20\x9a\x9d\x66\xa2\x65\xa7\xf9\xea\xe9\xd5\x1f\xdf\x97\x15\xb7\x19\x0c\xb5\xff\xfc\xe7\x7f\x77\x5e\xf7\x9a\x7d\x02\x88\xb8\x27\xa7\x4b\xb9\x11\x74\x62\x1c\x42\x70\xb9\x1a\xe0\x79\x98\xe3\x38\xf4\x36\x61\x37\x74\x02\x1d\x2f\x1b\xcc\xd0\x09\xde\x8f\xcd\xd0\x65\x4f\xbb\xa7\x47\xd9\x8b\xf8\xa3\xed\x51\xa0\x53\x33\x8a\x54\x6e\x8e\x4d\x99\x13\x64\xa7\x53\xab\xa9\xc1\xd1\xee\xb2\x42\x95\x5c\x6b\xa3\x72\xc6\x74\x63\x33\x97\x38\xe1\xb8\xee\xcd\x71\x13\xde\xbf\xf4\xd4\xf7\xe8\xd5\xc0\x63\x1d\x7e\x8f\xfd\xeb\xf1\x33\xee\xad\x2f\x9e\xbc\xbb\xc4\xc1\x3e\xb2\xbe\xda\xe2\x9d\x36\x20\x86\x47\xec\x61\xfa\xbf\xb1\x87\xb0\x73\x24\xec\x6e\x6b\x13\x4b\x7f\x88\x72\xde\x48\x71\x0d\xe1\x97\xd9\x22\xdb\x08\x6a\x3a\xdf\x6c\xd1\x59\x5b\x97\x40\xf3\xe1\xa2\xd9\xb1\xb6\x78\x6c\xdb\x94\x0a\x13\x8a\x1c\xb7\xf6\xc6\x5b\x83\x06\xc4\x3c\x92\x40\x20\x4a\x21\x2b\xcc\xbd\x6e\xda\xe1\xd5\x35\xda\x5d\x4f\x6f\xe0\xa5\xf1\x5a\xcd\x12\x22\xce\xfe\x1d\x05\x3f\xac\x48\xe1\xe2\x98\xfd\x1a\x7b\x30\x82\xfa\x88\x10\xd3\x82\x9f\x3a\x81\x3d\x6a\x4f\xf2\x60\x03\xfb\x33\xe4\x73\xf0\xf5\x23\x3b\x94\x48\x89\x42\x75\xb9\x99\x77\xf3\x8c\xfd\xf0\x7a\xe2\x27\x22\x04\xac\x0c\x61\x74\xfc\xfd\xbe\xb6\x9e\x87\x5a\x7e\x7e\x5f\xa6\xad\x15\x18\x37\xc2\xd0\xa5\x49\x45\x9a\x4f\xec\x4c\xce\xd2\x96\x78\xfc\x77\x61\xe6\x5b\x09\x5f\xb7\x89\xc8\xe9\x64\x67\x2d\xf8\x16\xa8\xd2\x47\xee\x89\xbd\x35\xd1\x76\x61\xcb\xe2\x8c\xab\xb5\x35\x50\xff\xd9\x55\xd8\x43\x60\x83\x9a\x76\x79\x5d\x1f\xd8\x01\xfa\xe6\xab\x0a\xb0\x25\x80\x8c\x2f\x7d\xad\x65\xa0\xe3\x49\x2f\x71\x20\xcf\x86\x9f\xe7\x01\x67\xfe\x3e\x2a\xfe\xd7\xcb\xe8\x7f\x9a\x7b\x46\x3b\x0d\x4a\x31\x17\x9d\x5e\x3a\x23\xff\xe6\x7d\x8c\xd8\xb3\x8e\xe3\x38\x11\x8f\xcd\x23\x7c\xd9\xbf\xd7\xd7\xac\x35\x19\x5b\x3f\x91\x7e\x05\xaf\x1f\x9e\x7a\x40\x48\x87\x81\x8c\x5e\x05\x13\x3c\xc4\x64\x4f\x6a\x7b\x89\x3c\x64\xe9\x8f\x5a\xf9\x83\x92\xeb\xc9\x2d\x9c\xaa\x53\x2a\x0a\xa7\x73\xc1\xfa\x27\x78\x7d\x1c\x25\x1d\x61\x4f\xad\xef\xf8\xa0\x6c\xc9\x47\xc9\xcb\x97\x2f\xc1\x35\x37\xc0\xcb\x97\x2f\xa3\x4e\xb2\x07\xd0\x9a\xba\x52\xf3\x7d\x46\x33\x32\x7e\x22\x89\x88\xe5\x86\x4c\x34\xfa\x54\x52\x71\xe8\x6f\x68\xc5\xc3\x4f\x25\xe6\x3f\x0d\xb1\x44\xf6\x35\xf0\x5c\x3f\xf4\x11\xca\xfd\xd8\x04\xbe\x7b\x53\x35\x37\xe4\x6e\xbe\x69\xf6\x30\x91\x7a\x94\x91\xe4\x79\x31\x73\x1f\x83\xdc\x4f\x60\x67\xa7\x76\x3f\x29\x03\x49\xef\x53\xaa\x50\x50\x85\x1b\x17\xc7\x43\xf2\xe3\x6f\xe4\x2c\x4e\x72\x7a\xcc\x5d\x77\xb8\x33\xcc\xc9\x6f\x62\x2e\x9e\x21\x64\xee\xc9\xac\x36\xc1\xb3\xcf\x9f\x54\x22\x52\x4f\x03\xfa\x54\xe2\xdd\x05\xb6\x81\xf7\xa9\x93\x69\xd8\xdf\x3b\x5b\x10\xb5\x1f\x9f\x2e\x00\x8e\xe7\xfb\x3d\xfe\xea\x61\x81\xb7\xfd\xb4\x8e\x50\xf2\xc8\x7c\x4f\xca\x04\x9a\xce\xd0\xc1\x0b\x94\x6f\xef\xd2\x7b\x60\x05\xfb\x3e\xdb\x8a\xbf\xd8\x7a\x32\xb9\xc1\x6f\xb5\xa2\xaa\xd8\x53\x49\xf5\xe2\x7a\xe2\x5b\xf2\x9e\xf0\x99\x5d\x74\xa1\x14\x1e\x05\xed\xad\x08\xcc\x3b\x1f\x6f\x45\x93\x35\x1e\x7d\xbe\xe7\xb3\xab\xc8\x57\xcf\x1f\xf8\x00\x2b\x76\xc4\xf3\x87\x4e\x6a\xae\x0b\x6e\xa8\x03\xae\xe7\xbb\xe6\x8f\x7c\x9c\x35\xe4\x4f\xe6\x8f\x7f\xad\xd5\xb8\x84\xf9\x9e\x6f\xb6\xda\x6d\x3c\xdf\xf7\xe9\x56\xb0\xf5\xe6\x7b\x3f\xe1\xfa\x86\xfe\xbc\x27\x76\xe7\xc5\xbd\x79\x83\xfb\x2b\x3a\x29\x7d\xae\x19\xe4\xa4\x2c\x17\x24\x6f\x2b\x3c\xcd\xad\xd4\xc8\x99\x49\x80\xd2\xde\x27\xbd\x9a\xc3\x54\x8f\xe3\x1d\x29\x9b\x5b\x36\x5b\x4d\x71\xd7\x71\xbd\x3f\xb4\xd4\xfc\xc9\x11\xd3\xb9\x2c\x08\x93\x4b\x2e\x36\x28\xa4\xff\xcb\x26\x6f\x51\xa8\x2b\x7f\x12\xb5\x93\x76\x2a\xac\x73\x38\x79\xf3\x5d\xf3\x22\xbc\xf3\x81\x39\xfc\x60\xdf\xb4\x55\x28\x98\xc3\xf4\xf5\x1b\x3b\x48\x14\xc9\x0a\x2a\x60\x0e\x5c\xa6\x15\x51\xeb\x54\x1f\x41\xf5\x8f\x11\x97\xe9\x0a\x15\xb2\xbb\xd1\x8b\x77\xa7\x57\xa7\xd9\xbb\xf3\xcf\x2f\xfc\x6e\x31\x1b\x6a\x94\x9c\x09\x24\xaa\x39\xc4\x9a\x83\x72\xea\xdb\xee\xdb\x6b\xe7\x79\xbc\x82\x91\x9f\xc9\x5e\x9f\x3a\x0e\x26\x60\x3f\x3f\x4f\xd5\xbd\x4a\x9c\x1b\x70\xb3\x7c\x46\x62\x4e\xca\x4d\xef\x64\x33\x87\x91\x72\xf0\x91\xd0\xc3\x5f\x6e\xcd\xf7\x4d\x1c\xfd\x09\x89\x64\xec\x8d\x27\x70\xce\xf6\xe2\xf1\xb1\xaf\xbd\x62\xd9\xd8\x8a\x41\xc8\xb8\x3e\x4a\x79\x9b\x68\x17\xa1\x8d\xc2\x9e\x7a\xbd\xc1\x34\x16\xd6\xb4\x7b\x85\xd6\xe4\x8f\xc8\xb1\x19\x3e\x78\xcb\x7b\x10\x79\x26\x39\x8f\xe4\xe6\x97\xdb\x28\x6c\xde\xbb\x3f\x88\xcd\x6d\x3e\x7c\x3f\xdc\x1a\xd8\x3c\xb8\x0e\x0e\x28\x84\x76\x39\xef\xdd\x12\xef\x15\xfa\x41\x67\xff\xcd\x63\x61\xf9\xf7\xc3\x57\xc5\xd1\x55\xb9\xb9\x4d\x0a\x45\x39\x3e\xe8\xfe\xbd\x33\xad\x8c\x60\x6b\xe6\xea\x7e\x62\x36\x89\x53\x85\xdb\x91\xac\xde\x54\x3b\x20\x12\x98\xfd\x9e\x32\xaf\xb5\x95\x47\xa4\xaf\xb5\x11\x18\xcc\x9b\x90\x05\x56\xa5\x44\x08\xb2\x1b\xe5\xb5\x68\x3f\xa4\x9c\x40\xa1\x76\x95\xde\x98\xac\x4a\x29\x53\x3f\x7c\x37\x9e\x0c\x81\x1a\x8f\xfa\x28\x6c\xe8\x59\xfb\xc0\x07\x15\x97\xaa\xbf\xe6\x76\xf0\xa9\x8b\x8e\xff\x7a\x94\x5e\xa1\xb4\xf3\x48\x45\xf2\xdb\xd1\xb5\xa6\x70\x7d\x7c\x63\x49\x5d\x4f\x6f\x6e\x26\x40\xee\xa9\x34\xb7\x8e\xe3\x54\x7e\xad\x11\x7f\xc3\xd1\xf1\x38\x55\xbc\xa4\x52\x8d\xa2\x3f\x39\x71\x6d\xbe\x54\x2c\xea\x4d\x25\x47\x5a\x51\x37\x07\x07\x44\xb9\x3f\x77\x26\x67\xe6\x0f\x76\x29\x41\x80\xa1\x32\x7f\x19\xb2\x7d\x67\x88\xdc\x52\x56\xcc\xe0\xaa\xf5\xa5\xae\x5f\x46\x8f\x03\x5f\x02\xc3\x5a\x90\xb2\xc1\x1e\x9d\x5d\x5c\x4c\xe0\xb3\xfe\x4f\x9a\xa6\x63\xe7\x48\x19\x5d\xa2\x54\x19\xa9\xd5\x9a\x8b\x19\xfc\x1d\xd9\xe1\x1f\x25\x25\x6c\x05\x67\x6b\xc2\x56\x07\xff\x13\x00\x00\xff\xff\xbe\x9b\x6d\x3d\x0b\x53\x00\x00"
+var _mlperf_bert_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x54\x51\x6b\xe4\x46\x0c\x7e\xf7\xaf\x10\xf8\xa5\x85\x9c\xbd\x89\x9b\x64\x3b\x0f\x85\xf6\x28\xf4\xe1\x7a\x1c\x47\x28\xf4\xc9\xc8\x63\xd9\x1e\x62\xcf\x38\x92\x9c\xcd\xe6\xd7\x97\x19\x6f\x76\x9d\x6b\x8f\xc2\x62\x66\xa5\xef\xd3\x48\x9f\xa4\xf1\x38\x91\x81\x3f\x3f\x7d\x21\xee\xea\xdf\x7e\xff\xfa\x00\x39\x44\x1b\x84\x0e\x8e\x61\x61\x98\x42\x4b\x63\xd6\x31\x4e\x74\x08\xfc\x68\x32\x00\x48\x08\x03\x5f\x8e\x0f\x81\xed\x00\x39\x9c\xdd\xd0\x05\x06\x1d\xe8\x44\x8b\xd8\x67\x62\x71\xc1\x1b\xb8\x2e\xf6\xc5\xf5\x3b\xf0\xc9\x05\x36\x78\x51\x46\xe7\x35\xdb\xa0\x77\x90\xbf\x91\xc1\xf9\x2e\xf0\x84\x1a\xd1\xce\x83\xd0\x84\x5e\x9d\x3d\xfb\x57\x6f\xd6\x92\x58\x76\x73\x84\x19\xf8\x25\x83\x6d\x61\x45\xc6\xd4\x11\x93\xb7\x24\xb1\x8a\x0f\x30\xa8\xce\x62\xca\xb2\x77\x3a\x2c\x4d\x61\xc3\x54\x4e\xa3\x0d\xd3\x14\xbc\x94\xce\x9f\xc0\xa5\x32\x51\x39\xa1\x28\x71\x39\xa2\xef\x17\xec\xa9\x6c\x88\xf5\x5d\x90\x57\xf2\xa1\x0d\x45\xe0\xbe\x64\xb2\x81\xdb\xb2\xba\xaf\xaa\xfd\xcf\x77\x59\x0e\xa3\xb3\xe4\x25\x69\x7a\xd1\xe6\x64\x34\xf0\xeb\x8c\x76\x20\xf8\xb4\xfe\xbf\x82\xbf\x4e\x35\xdd\x14\x3b\xf8\x0e\x37\x07\xe7\xe7\x45\x05\x34\x6c\xac\xab\x2d\xd6\x16\x13\xd3\xe3\x4c\x06\x7a\xf2\xc4\x38\x26\x1b\xc0\x3b\x7d\x22\xf1\x69\x21\x89\x6a\x01\xfa\x36\x45\xb2\xc1\x2b\xbd\x28\xcc\x28\x82\x3d\x9d\x78\x33\xc6\x9e\x29\xb1\x18\xc8\x53\xe4\x8d\xe9\x84\x89\xbf\xe7\x60\xb1\xa9\x17\x1e\xcd\xff\xe9\x72\xb7\x2f\x3b\x37\x92\x94\x89\x52\xe8\x8b\x6e\xc2\x4c\xf8\x52\x0b\x3d\xd5\x23\xf9\x5e\x07\x03\xd5\xfe\xa7\x8d\xb7\x0d\xb6\x16\x65\xd7\x92\x81\xeb\x9b\xfd\x37\xbc\xa7\x85\xf8\x78\x66\xde\xbd\x27\xd6\x63\x38\x10\xd7\x16\x85\x0c\x28\x2f\x94\x85\x45\xe7\x45\x57\xcd\x62\x5d\xe6\x2c\x49\x8d\x5e\x0e\xc4\xce\xf7\xc9\x99\x03\x6e\xe5\x7b\xeb\xc7\xca\xbf\xa8\x91\xfd\xa7\xcc\x6b\x28\x90\x19\xbd\x00\xbd\x28\xa3\x55\x6a\xa1\xe3\x30\x6d\x55\xcf\xbe\xd1\xfa\x9c\xfb\xf7\x35\xf7\x75\x43\xa2\xb5\xb8\x57\x32\x70\xb3\x3b\xdb\xa3\x84\xeb\xad\x17\x15\x77\x59\x9a\xbc\xd8\x42\x99\xc9\xba\xce\x91\xac\xa3\x03\x3d\xe3\x3c\xa4\x21\x38\x90\xeb\x07\x15\x60\x92\xb0\xb0\xa5\xf5\xaa\xe4\xaf\x67\xd4\xe1\xd2\x59\xa9\x0a\x9c\xf0\x35\x78\x3c\x48\x5a\x1e\xd1\xc0\x54\x58\xe4\x69\x4c\x7b\x90\x42\x4b\x39\x1f\x35\x3e\x13\x69\x65\x8a\x79\xed\xb3\x93\x1a\xd9\x0e\xee\x99\x2e\x45\x76\x38\x0a\x41\x0e\xae\x03\x21\xbd\x8a\xc2\xf8\xf8\x81\x06\x85\xe2\x50\x81\x13\x40\x88\x07\x0d\x80\x1e\x4e\x11\xce\x01\xf2\x84\xbe\xa4\xba\xad\x27\xe5\x1e\xfd\x1e\x5a\xf2\x41\x29\x9e\x37\xcc\x38\x8c\xe9\x59\x93\xb7\xce\xfe\x5b\x92\x83\xd3\xc1\xad\x29\x6d\xaf\x4e\xc0\xda\x0e\x64\x1f\x65\x99\x0c\xd8\xaa\x69\x6e\x71\x77\x7b\xdf\x59\xac\x1a\xbc\xdf\xdd\x55\x37\xb7\x54\xb5\xb6\x43\xbc\xb6\xb6\xcd\x50\x95\x5d\xb3\x28\xa5\x6d\x4a\xe3\x00\x9e\x34\x3d\x89\x17\x5f\x4a\xee\xd1\xf9\xd6\xc0\x03\xa3\x97\xf8\xc4\x11\xc7\xf5\x1b\x28\xd9\x63\xa2\x9e\x16\xc6\xf1\xcc\xfe\xe1\xe3\xe7\xcf\x57\xf0\x35\x7e\x8a\xa2\xf8\x31\x85\x98\xd0\xbb\x2e\xce\x08\x2e\x3a\x04\x36\xf0\x37\xf9\x0f\x7f\x88\x43\xdf\xc3\xc7\x01\x7d\x9f\xfd\x33\x00\xd2\xca\xa6\x73\x04\x06\x00\x00"
 
 func mlperf_bert_yml() ([]byte, error) {
 	return bindata_read(
@@ -125,7 +125,7 @@ func mlperf_bert_yml() ([]byte, error) {
 	)
 }
 
-var _mlperf_bert_gpu_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x7c\x7f\x73\xdb\x38\x92\xe8\xff\xfe\x14\xfd\x98\x4a\x45\x4a\x64\xda\x4a\x66\x32\x89\xde\x68\x5e\x39\x4e\xf2\xd6\x7b\x59\x67\x36\xf6\x6e\x6a\xcf\xe7\xe2\x42\x24\x24\x61\x4c\x01\x0c\x00\x5a\xd6\xdc\xdd\x77\xbf\x6a\xfc\x20\x01\x92\xb2\x9d\xb9\xba\xba\xfc\x31\x23\x82\xdd\x8d\x46\x77\xa3\xbb\xd1\x68\x9a\x93\x0d\x9d\xc1\x5f\x3e\xfd\x4a\xe5\x32\x7b\xf7\xe1\xcb\x65\xb6\xaa\x6a\x78\x02\x38\x0e\x62\x09\x3b\x51\x4b\xd8\x88\x82\x96\x07\x4b\x49\x36\x74\x2b\xe4\xcd\xec\x00\x00\xc0\x62\xfe\xba\xbb\x14\x32\x5f\xc3\x13\x68\x5e\xc3\x52\x48\xd0\x6b\xea\xd0\x10\xf6\x96\x4a\xc5\x04\x9f\xc1\x34\x7d\x93\x4e\x23\x60\xf7\x0a\x72\xc1\x95\x96\x84\x71\x7d\x10\x40\x1f\xc3\x93\x06\x82\xf1\xa5\x90\x1b\xa2\xed\x6f\x50\x74\x43\xb8\x66\x79\xf3\xde\xbe\x3d\x28\xa8\xca\x25\xab\xb4\xa1\xf0\xcb\x01\x84\x8b\x4b\x0f\x24\x5d\x52\x49\x79\x4e\x15\xae\xe2\x10\xd6\x5a\x57\x6a\x76\x74\xb4\x62\x7a\x5d\x2f\xd2\x5c\x6c\x8e\x36\x65\x2e\x36\x1b\xc1\xd5\x11\xe3\x0e\xf8\x48\x4b\x4a\x8f\x36\x44\x69\x2a\x8f\x4a\xc2\x57\x35\x59\xd1\xa3\x05\x95\x3a\x22\xf2\x3b\xe5\xa2\x10\xa9\x90\xab\x23\x49\x73\x21\x8b\xa3\x57\x3f\xbd\x7a\xf5\xe6\xed\xeb\x83\x27\x50\xb2\x9c\x72\x65\x64\xda\xca\xc6\x0d\xce\xe0\xa4\x22\xf9\x9a\xc2\x27\xfb\x3c\x81\xbf\xbb\x35\xbd\x34\x12\x18\xc4\x7d\x02\x8c\x57\xb5\x56\xa0\x45\x30\x6a\xc7\xac\x86\x0e\x41\xef\x2a\x3a\x83\x15\xe5\x54\x92\xf2\x40\xd4\xba\xaa\xb5\x7d\x67\xdf\x68\x7a\xa7\xcd\xe3\x13\x20\x10\x08\xce\xcf\x64\x31\xa0\x22\xa8\x2e\x4d\xa5\x81\x8d\x04\x8c\x50\x8a\x4a\x46\x4a\xf6\x3b\x2d\x1c\x82\x3a\x30\xcc\xcc\xe0\x09\xa8\x8a\xe6\x6c\xc9\xa8\xb2\xfc\xc1\x4a\x92\x6a\x0d\x84\x17\xb0\xa5\x6c\xb5\xd6\x0a\x24\x55\xa2\x96\x39\x55\x86\xb8\x79\x9f\x55\x44\xaf\x67\x8d\x58\xd5\xab\x94\x6c\xc8\xef\x82\x93\xad\x32\x1a\x52\x5a\x48\x9a\xe6\x44\x6e\x4a\x23\x6c\x43\x5a\x1d\x55\x3b\x8d\xb6\x68\xf4\x82\x66\x9c\x56\x76\x71\x4c\x65\x44\xe6\x6b\x76\x4b\xed\xda\xf1\xdf\x92\x94\x8a\xc2\x13\x60\x4b\x50\x54\x4f\x70\x1d\xdc\x2c\x66\x41\x14\xcd\x6a\x59\x02\x53\x40\x00\x7f\x68\x01\x84\x83\xa3\xd0\x10\x78\x62\xa0\x5b\x76\xc3\x35\xd9\x01\x43\xb2\xa0\x5c\x68\x8a\xbf\x03\xcc\x25\x2b\xa9\xd9\x3f\xca\x0b\xba\x2f\x96\x2d\xd3\x6b\x66\x59\x0a\xa7\xb6\x13\xe6\x6b\x9a\xdf\xa8\x7a\x33\x03\xfa\xd3\x8f\x79\x91\xff\xf8\x53\xf1\xd3\xab\xd7\x0b\x52\xe4\x2f\x7f\x7c\xb9\x7c\xfd\xea\xed\xeb\xe3\x97\x6f\x7e\x7a\x43\xdf\xe6\x07\x0b\xba\x14\x92\x66\x95\xa4\x95\x14\x39\x55\x6a\x06\xff\x71\x80\x4c\x9c\x8a\x6a\x27\x71\x2e\x18\xe5\x63\x78\x79\x3c\x7d\x0b\xe7\x7f\x3f\x7b\x7f\x76\x02\xa7\x9f\xbf\xfc\xfa\xf9\xcb\xc9\xe5\xd9\xe7\xf3\x14\x4e\xca\x12\x64\xa3\x29\x2a\x6f\x69\x91\x1a\x7c\x67\xab\x05\xd4\xbc\xa0\x76\xc3\xdf\x67\xc5\x23\x04\x48\xdc\xab\x64\xfc\x7f\x0d\x8d\x9d\xa8\x61\x43\x76\xc0\x85\x86\x5a\xa1\x98\x98\xb2\xd2\xa1\x77\x39\xad\x34\xee\xf3\x5c\x6c\xaa\x92\x11\x9e\x53\x23\x13\x33\x91\x23\x63\x19\xf9\x87\x23\x22\x16\x9a\x30\x0e\x04\x72\x51\xed\xbc\x60\x1d\x24\x10\x34\x85\x27\x06\x1e\xff\xa1\x69\xcd\x8e\x8e\xb6\xdb\x6d\x4a\x0c\xd3\xc6\x8e\xdc\x3e\x53\x47\x9f\xce\x4e\x3f\x9c\x5f\x7c\x38\x7c\x99\x1e\x37\x68\x7f\xe3\x25\x55\x28\x84\x6f\x35\x93\xb4\x80\xc5\x0e\x48\x55\x95\x2c\x27\x8b\x92\x42\x49\xb6\x20\x24\x90\x95\xa4\xb4\x40\x93\x61\x1c\xb6\x92\x69\xc6\x57\x13\x50\x62\xa9\xb7\x44\x52\x43\xa7\x60\x4a\x4b\xb6\xa8\x75\x24\x3a\xcf\x27\x53\x11\x80\xe0\x68\x7b\xc9\xc9\x05\x9c\x5d\x24\xf0\xee\xe4\xe2\xec\x62\x62\xa8\x7c\x3d\xbb\xfc\xd3\xe7\xbf\x5d\xc2\xd7\x93\x2f\x5f\x4e\xce\x2f\xcf\x3e\x5c\xc0\xe7\x2f\x70\xfa\xf9\xfc\xfd\x19\xea\xed\x02\x3e\x7f\x84\x93\xf3\x7f\xc0\xbf\x9c\x9d\xbf\x9f\x00\x65\x7a\x4d\x25\xd0\xbb\x4a\xe2\x1a\x84\x04\x86\x42\xf5\xaa\xbc\xa0\x34\x62\xc2\x3b\x70\xb7\x79\x73\xf0\x2e\x0f\x56\xe2\x96\x4a\xce\xf8\x0a\x2a\x2a\x37\x4c\xa1\x7a\x15\xda\xad\xa1\x53\xb2\x0d\xd3\xc6\x41\xab\xfe\xd2\xd2\x03\x03\x73\xd9\xa8\x98\x29\x60\x05\x45\x17\x4e\xcc\x26\x7b\x4f\x69\xf5\x89\x12\x43\xfe\xc3\x1d\xd9\x54\x25\x55\x47\x97\x94\x2b\x21\x3f\x96\x62\x7b\xf4\xc9\x31\xf1\x17\xdc\xed\x8c\xaf\x8e\xd0\xa3\x1f\xd5\x9a\x95\xea\x28\x97\x94\x68\x9a\xa9\x6f\x35\x29\xb2\x82\x68\x92\x56\x3b\x33\x9d\xb3\x23\xbd\x26\xda\x30\x53\xd0\x8a\xf2\x82\xf2\x7c\x87\xb2\x5d\x0b\x29\x6e\x45\x81\xac\x48\xba\x11\xc6\xb6\x0f\x00\x96\x52\x6c\x20\xcb\x96\xb5\xae\x25\xcd\x32\x14\x96\x90\x1a\xc8\x42\x89\xb2\xd6\x34\xb3\xcf\xfb\x01\x0b\x76\xcb\x50\x32\xfb\x21\x2a\xc9\xb8\xce\x96\x35\xcf\x51\x5a\x38\xa7\x7b\xb1\xb5\xeb\x47\x67\xe8\x7f\xa6\x4b\x56\x6a\x2a\xfd\xe3\x28\x61\x2b\x2e\x24\x4d\xc6\x01\x5a\x2e\xca\x92\x1a\x5a\xaa\x1d\xfc\x4d\x19\x16\xdc\xd3\x86\xe8\x75\xfb\x24\x02\x38\x49\x78\x21\x36\x46\x5c\x1e\xd6\x89\x38\x1c\x13\x95\x66\x1b\xf6\xbb\x51\x6f\x8b\xab\xc5\x0d\xe5\xbd\x51\xc5\xee\x02\x10\xa3\xc2\x65\x29\xb6\x40\x14\xe8\x65\x48\xd4\x29\x20\x8d\x61\xd6\xb7\x45\x80\xce\x36\xd4\x28\xa5\x24\x2b\x05\x73\xd0\x4b\x8c\x01\x15\xd1\xe9\xed\x34\x35\x83\x07\x00\x1f\x3f\x9d\xfc\xff\x0b\x98\xc3\xb9\xe0\x06\xb8\xa0\x4b\xa0\x77\x5a\x92\x5c\x67\x06\x66\x34\xb6\xbe\xdf\x3c\xa4\xef\x3f\x7c\x3c\x3b\xff\x90\x31\xae\xe9\x8a\xca\x91\x73\xcd\xc9\x86\xdc\x65\x8a\x7e\xcb\x4a\xca\x57\x7a\x9d\x4c\xe0\xd5\x9b\x1f\x26\xfe\xe5\x25\xc6\x58\x72\xc7\x36\xf5\x06\xb4\xd0\xa4\xb4\xf1\x17\x14\xfd\x56\x63\x8e\x00\x16\x0b\xc8\x52\x53\x09\x5f\x85\x2c\x7e\x65\x34\xa7\x91\x84\x52\x48\x3c\xb9\x0b\x87\xa6\xa0\x14\x7c\x65\xb6\x0a\xe1\xd6\x01\x6e\x59\x59\xc2\x82\x82\x96\x35\xcf\x89\xa6\xc5\xc4\x44\x06\xd5\x60\xa8\xb5\x90\x38\x49\x43\xac\x8f\x5b\x91\xa2\xa0\x45\x6a\x8d\xe4\x81\x65\x17\x22\xcf\xd0\xe5\x14\x34\x99\xc0\xf4\xe5\x9b\x66\xc9\x5f\x31\x82\xa9\xaa\x64\x1a\xfd\x18\xd4\x15\x10\xc3\x2d\x14\x22\xaf\x37\x94\xa3\x7f\xd6\x02\xf2\x75\xcd\x6f\xd4\x04\xd6\x62\x0b\x9b\x3a\x5f\x83\x25\x86\x7b\xba\xe5\x90\xdc\x50\x58\x50\xbd\xa5\x94\x3b\x84\xc7\x31\x87\x3a\xf9\x56\x53\xb9\x6b\xb5\xf2\x7a\x58\x29\xbc\xde\x2c\xa8\x34\x8e\x1f\x45\xae\x1a\x37\xf6\xad\xa6\xca\x4a\xff\xaf\xee\x57\x2c\xf4\x40\x8c\x43\xd2\xb7\x09\x16\x53\x4e\xc1\xc3\x6c\x2f\x84\x28\x1b\x9e\x5d\x46\x9a\xbd\xcc\x30\x62\x65\x9c\xae\x88\x66\xb7\x28\xdd\x8f\x98\x76\x34\xdc\x9f\x2d\x71\x16\x6a\xb2\x0f\xb8\xf8\x6b\x7d\xf2\x1e\xa8\x73\x7c\x98\x0f\x9b\x70\xa6\xc4\x86\x5a\xf7\x55\x08\x13\x27\xd7\xe4\x96\x9a\x84\x84\xab\x2d\x95\xc3\xdc\xa0\x02\xf8\x6a\x94\x98\x84\x3a\x43\x6f\x9b\x4c\xcc\xde\x98\x34\x59\x48\xf7\x5f\x62\xe7\x47\x9f\x61\x05\x87\xa8\x8c\xaf\x52\xf8\x90\xae\xd2\x89\x7d\x3e\xbc\x9d\xa6\xd3\x14\x61\xee\x9b\xd6\x2f\xaf\x92\xb4\x60\xb8\x01\xfb\xf3\x77\x67\x73\xa0\xa8\x1a\x3f\x61\x41\x6f\xdb\xe9\x30\x64\x69\xaa\xf4\xf7\x31\xe0\xc2\x01\x93\xdd\xd9\x2f\xdb\xdc\xb6\x60\x92\xe6\x5a\xc8\x1d\x6c\xd7\x54\xd2\x36\x95\x06\x93\x64\x55\x82\x71\xdd\x1a\x05\x86\x74\x4d\xf9\xfd\x52\xbf\x15\x39\x59\x3c\x52\xea\xc8\x89\x81\xaf\x4b\x22\x77\x36\x30\x36\xc1\x0a\x03\x9c\x63\x66\x8b\xae\x13\x55\x60\x92\x82\x47\xd8\x60\x21\xb2\x52\x6c\xa9\xcc\x72\xa2\x90\x8f\x4b\x34\xb4\x60\x63\x9b\x84\x40\x0b\x30\x40\x80\x40\x66\x4a\xeb\xd3\xf0\x5c\x90\xc2\xc5\x5a\xd4\x65\x81\xcb\x46\x64\xa3\x27\xdc\x12\x98\xf3\x35\x5b\xc6\xa6\xde\xc6\x3f\x19\xdb\x36\x50\x16\xc6\xbe\x7a\xdc\x76\x59\x08\x45\xb3\x52\xac\x56\x8c\xaf\xf6\x6f\x13\x52\x96\x3e\xaf\xf3\xd1\x10\x24\x2d\xfd\x26\xc5\xb8\x0f\x2e\xcb\x45\x7f\xd5\xf8\x42\x0c\xb6\xb4\x08\x9c\xef\x49\xe0\x2c\x1a\x4a\x44\x62\xda\x59\xd1\x1c\xc9\xe1\x32\x08\x70\x3c\x52\x96\x7e\x6b\xde\x92\xb2\xb6\x6e\x3c\x19\x07\x4b\xda\x10\x79\x63\x82\x4c\x46\x54\xe6\xd3\xc3\x68\xef\x3d\x02\x3a\xda\x2b\x8f\x80\x6f\x4d\xfb\x11\xc0\x81\x41\x5a\x68\x49\x75\x2d\xb9\x43\x32\xb1\x13\x75\x94\x97\x44\x29\xb8\x40\xca\x2e\xff\x1a\x89\xc5\x6f\x34\xd7\x2e\x76\x26\x49\x72\x02\x28\x59\xb4\x51\xe7\x1e\x8e\x70\x57\x7a\xaf\x65\xa4\xa6\x98\xf9\xd9\x84\x45\x43\x15\xb3\x48\x2b\x3a\xa7\x82\x8f\x42\xb6\xbe\x0e\xbd\xa4\xa8\x75\xeb\xd5\xac\x47\x54\x9a\x60\xd6\xc5\x0b\xa0\xbc\x80\x4a\x28\x66\xce\xa6\xa8\xa7\xc3\x69\xea\x59\x3a\x70\x87\xd2\x25\x64\x19\xe3\x4c\x67\xd9\x48\xd1\x72\xd9\xdf\x73\xdf\x88\xca\x58\x31\x30\xee\x82\x42\x86\x56\xdf\x7f\x8d\xd1\xd1\x06\x94\xfe\x3b\x21\xd9\x2a\xb3\x2c\x1b\xec\xf9\xf0\x6e\x37\x0b\xc9\xfc\x02\xf6\x00\x51\x5e\x3c\x04\xc2\x94\x49\x3d\x95\x62\x8b\x92\xce\xcd\x26\x19\xfb\x23\x2d\xae\x39\xb5\x4b\x84\xb9\x5b\x6b\xf4\x2a\x5c\x25\x42\x84\xcf\x21\x60\xbb\x5e\x98\x07\x8b\x0f\x41\xba\xcb\x86\x79\x4f\x12\x21\x78\xbc\x7c\x98\x77\xe4\x11\x82\x86\x42\x80\x79\x24\x93\x10\x2c\x12\x04\xcc\x63\xc1\x84\x06\xa1\xb4\x74\xf6\xd0\x08\xca\xd9\xbe\xa1\x93\x65\x78\x2c\xce\xb2\xd1\x38\x44\x72\x63\x11\x16\x0a\x23\x49\x9a\x87\x17\x73\x48\xac\x8c\x67\xf0\x54\x25\xf0\x14\x46\x51\x9e\x67\x5c\x0e\x1e\x0c\x8d\x30\x46\x81\x72\xc6\xe3\x88\xc8\x24\x56\x44\x43\x2d\xd0\xfd\xc3\x84\x43\x0a\x3d\xfa\xad\x0a\x67\x70\xf5\x54\x5d\x1b\xf2\x09\x24\xe9\x6f\x82\xf1\x51\x47\xe5\x0d\xb6\xa9\x8b\xf4\x74\xd7\xd6\x4f\x3c\xf5\xce\x7b\x78\x5a\x18\xfa\x03\xb8\xdf\x4b\x39\xd4\x7d\x4c\x37\x7c\xf3\xbd\x54\x23\x4b\x99\xc1\x53\xd9\x92\x8d\x5e\x8d\x3b\xd6\xd2\xfa\xc7\x33\x8c\x90\x1f\x29\xc1\x03\x9d\xda\xef\x20\x15\xd5\x18\x5d\x96\x0e\x10\x7f\x9b\x73\xe9\xf7\x78\xac\x9a\xb3\x6f\x35\x1d\x74\x5a\xce\x77\x66\x8c\x17\xf4\x6e\xd8\x69\xa9\x8a\xf0\x7d\xef\xf7\x39\x34\x33\x9e\x69\x91\x99\xfd\xbc\x21\xd5\x3e\x10\xa6\x32\xcc\xcc\x31\x4b\x1d\xf4\x9a\x26\x8f\xc8\x58\x31\x30\x87\x7d\xb5\x21\xea\x66\xc0\x53\xd2\x15\x9e\x2b\x86\x11\xff\x67\xdc\x28\xc2\xc4\x5e\xb4\x11\x3b\xcc\x5b\x15\x44\x5e\x2a\x14\x3e\xba\xa9\xf0\xb9\xeb\x4b\x5b\x35\x38\x7f\xda\x0e\x84\xa0\x8d\xcb\xed\xbb\xdb\x9e\x52\x3c\x54\x38\xd6\x47\x88\x55\xd4\xe0\xc4\xc3\x91\x57\xf5\x3a\x43\x8f\xea\x7f\xf7\x01\x50\x73\x0d\x04\x3e\x44\xbe\xbe\x55\x20\x3a\xfa\xf6\xe9\x7f\x39\x20\xe0\x6e\x93\x94\x14\xae\x44\xe4\x53\x8f\x91\x5d\x05\xa6\x46\xc6\x37\xf8\xb4\x66\x02\x7b\xce\x70\x51\xd4\x4d\x92\xe4\x0b\x25\x05\x10\x08\x8f\x33\xa6\xb6\x85\xe7\x62\x02\x25\x53\xc6\x0b\x84\x39\x95\xf1\x00\x88\x6d\xab\x99\x61\x35\x63\x85\xb8\xe9\xe7\x8a\xf2\x88\xaf\x44\x26\x63\x20\xca\xf0\x4f\xa5\x37\x55\x0b\x61\x72\xde\xb9\x99\x39\x2d\x05\x29\x46\x16\x68\x7c\x95\xe0\x9b\xe4\xba\xf5\x35\x4c\x65\xdb\x35\xd3\x54\x55\x24\xa7\xa3\xbc\x31\x79\xb6\x84\x1c\xe6\x73\x48\x20\xc1\x53\x96\xfd\xfd\x6f\x3a\x7c\x90\xe1\x03\x37\x0f\x42\x16\xa3\x7c\x8c\x23\xc7\x77\x2f\x8f\x5f\x7e\x6c\x3d\xad\xf3\x98\x78\x64\x88\x7d\xa8\x91\x9c\xe5\xa7\xc9\xfc\xe6\x70\x75\x6d\xf3\x57\xcc\x07\xb9\x96\x3b\x60\x3c\x58\x9a\x27\x6b\xce\x88\x44\x12\x5b\x27\x67\xdc\xc2\x5e\x25\xcd\x98\x4a\xae\x5b\x16\x9a\x51\x9f\x9d\x34\x03\x57\x89\xb3\xfb\xe4\xba\x81\x8e\x12\x9e\xab\x76\x3c\x5f\x13\x89\x3b\x6c\x2b\x64\x91\x89\xe5\x12\x3d\x7a\x04\x50\x49\x7a\x9b\x45\x62\x85\x79\xb8\x6e\xcb\x76\x8e\xec\xc6\x1c\xcd\x02\x97\xc4\xf6\x6b\xe6\xb1\xb3\x00\xd0\x52\xd1\x18\x8b\x2d\x07\x10\x67\x1d\x57\xd8\xae\x3c\x25\x55\x45\x39\xaa\x34\x02\xe9\xd3\x0d\x91\xae\x0e\xa7\xd7\x18\x56\xf3\x87\xf9\xb5\xba\x6f\x81\x06\x64\xeb\x59\x28\x29\x1f\x05\x09\x09\x1c\xc2\xd4\x65\x67\x5e\xa2\xdf\x48\x24\xd2\x2b\xcc\xc4\x42\xf5\xfb\x54\xdf\xe4\xc1\x57\x09\x2b\x02\x65\x77\xd2\x7d\x07\xe2\xc7\x22\xc0\x9e\x97\x32\xc5\xc5\x40\x38\xb1\x6b\xea\xbc\x1d\xc8\x91\x3b\x10\x5d\x9f\xd5\x95\x91\xb5\x0c\xef\x91\x66\x07\x5d\xfd\xee\xf1\x50\x5d\x7d\x75\xa7\x31\x22\x09\xc7\xa2\x45\x1b\xca\x46\x07\x08\x67\xf9\x57\xc9\xf5\x18\xfe\xcf\x1c\xa6\x63\x73\x18\x1b\x71\xa1\x63\xaa\xe3\xee\x9c\x92\x30\x45\xe1\xef\xa4\xac\xe9\x07\x29\x85\x1c\x0d\x14\x40\x92\x8f\x41\x95\x69\x02\x94\xe4\xeb\x46\x37\xa0\x6c\xe9\xc1\xd4\xba\xe8\x1d\xc9\x75\xb9\x83\x69\x50\xf2\xea\x30\xdc\xe3\xa8\xcb\x90\xc5\x74\xab\x6f\x56\x75\x75\x7c\xdd\x81\x1b\xd0\x9a\x7d\xba\x4a\x3a\x5e\x23\xa4\xdb\x7a\x07\x0f\xec\xc6\x8d\x09\xed\x43\x72\xf5\xe2\x39\xa0\xb4\xbb\x13\x8f\x3b\x38\x3d\x63\x1c\xd8\x41\x57\x11\x37\xdd\x59\x3b\xe6\xfa\x20\x3e\xbc\xe8\x30\x7a\xb8\xb7\x8a\xb5\xef\xdf\xb4\xcb\xc3\x13\xf8\xcc\xcb\x1d\x90\xa2\x70\xb4\x55\x50\x75\x33\xf2\xce\x09\x87\x45\xab\x73\x49\x73\x71\x4b\x25\x2d\xec\x45\x4a\x78\x8d\xea\x29\xfa\x5a\x74\x0a\x67\x4b\x5b\xa7\x3d\x3d\x39\x7f\x76\x09\x6b\xe3\x4f\x80\xe9\x67\x0a\x4a\x76\x43\xcb\x1d\x14\xb5\x29\x4a\x6f\x29\x93\x05\xfc\x8d\xb3\x5c\x14\x7d\x7a\x4a\xd7\xcb\x25\x28\x04\xb3\x15\xa4\xdf\x6a\xa5\x41\xdd\xb0\xca\x70\xe9\x42\x57\xda\xc5\xeb\xd1\x39\xb7\xf7\xbe\xa6\x94\xc7\x14\x6c\x28\xf1\x25\x69\x67\xf3\xa6\x40\x36\x01\x7a\x4b\xe5\xae\xa9\xa0\x30\x05\xe7\x9f\x2f\x7b\xc4\x56\x35\x91\x84\x6b\x77\xb7\x68\x6a\x5a\xc1\x65\x6c\xf8\x8f\xe4\xba\x26\xa5\x37\xde\xe6\x9c\x37\xa0\xbb\xc0\x91\x77\x4e\x4d\xa3\xc8\x56\x5e\xc0\x74\x7c\xdd\xb5\xc7\xbc\xa4\x84\xd3\xa2\xb3\x57\xee\x9b\x2e\x3a\xc6\xb6\xc1\x21\x73\xe3\xb4\xbf\x05\xba\x73\xb2\x65\xb8\xba\x74\xc9\x30\x60\xf5\xf9\x30\x59\xc9\xe1\xb4\xeb\x02\x00\x73\x2d\x57\x62\x4c\x5d\xd1\x6f\x94\x9c\x1a\x3f\x83\xfe\x03\xc9\x39\xab\x9c\xc1\xb3\xa7\xea\x19\xdc\xaa\xd4\xfc\x48\xf6\xd7\x6f\x07\xe5\x3e\x19\x92\x4e\x77\x31\x60\x2a\xfc\x8c\xd7\xf4\xc1\x88\xdb\xdb\xfb\x87\xd3\xfb\xf7\x76\xef\xfd\x80\x5b\xf3\xc7\x50\x47\xc0\x99\xdf\x3c\x2e\xff\x75\xc8\xd8\xb0\x3a\x1f\x2e\xa4\x45\x71\x75\x7e\x6f\x51\xad\xb5\xbc\xf9\xfe\xea\x5a\xaf\xb6\xd6\x1d\xe8\x22\x74\x8e\x87\xf1\x63\x17\x38\x3a\x26\x86\x0f\x5d\xc0\xf8\xb0\x38\x58\x23\x08\x04\xd8\x24\x52\xee\xd9\x65\x2e\x2e\x03\xf6\x40\xfe\x40\x62\xfb\x38\x3a\x27\xb2\x91\x3f\x23\xaa\x09\xe4\xb5\x0c\x8f\xf1\x4d\x0d\xb4\x3d\x7f\x9c\x22\x05\xdc\x19\xc6\xc9\x30\x65\x7c\xd4\xb3\x0d\xb9\x03\x47\xee\x19\x4a\x1b\x90\x48\x73\x1f\x66\xe4\xdd\x16\x22\x9e\xc0\x3b\x9a\x93\xba\xed\x2f\x52\x25\x2b\x6c\xfd\x9c\x17\x62\x0b\xa4\xaa\xa4\xc0\xe8\xac\xc9\x0d\xe5\xe8\x7f\x54\x2e\x24\x0b\xae\x01\xd5\x04\x88\xab\x7b\x38\x8a\x66\x0a\xe3\xcc\x51\x1e\x44\x62\xc2\xb6\xa9\x4b\xcd\xd0\xc8\x1a\x34\x7b\xd5\xe3\x50\xe0\xbd\xc8\x6d\x3f\xd1\x86\x70\xd8\x52\xae\x7d\x67\x93\x69\xf7\x31\xa9\xc7\x42\xd4\xab\xb5\x06\x02\x2b\x52\x96\xb6\x4d\x69\xc3\xca\x1b\x4f\xe1\x02\x57\x79\x32\x48\x24\x02\x79\x37\xdb\x47\x3a\x02\x3b\x9d\xed\x99\xd4\x43\xa5\xa9\x63\xde\x0d\x9c\x8b\xad\xbd\x9c\x10\xb2\x80\x67\x16\xef\x99\x0d\x22\x26\x93\xd1\x5b\x2b\x3b\xaa\x6c\x38\x33\x5a\x86\x77\x66\x96\xd3\x14\xbe\x52\x10\xbc\xdc\x39\x62\x5b\x62\x99\xcf\x05\x57\xcc\xf7\x52\x18\x6c\x7b\x72\x4c\xfc\xbd\xa7\x3f\xd1\x4c\x60\xbb\x66\xf9\x1a\xe3\x56\x41\x97\x8c\x53\x20\xca\xab\x63\x4d\xe1\xf9\x86\x71\x84\x7f\x8e\x52\x63\x5a\x41\x49\x97\xb6\xba\x6e\xbb\x80\x7c\x9d\xc0\x34\xea\x3c\x57\x0e\xd0\x03\x39\x42\x31\xa8\x59\x19\x29\xb7\x64\xa7\xcc\x8d\x29\x32\x48\x36\x74\x82\x88\xb9\xa8\xa5\xa2\xe3\x58\x40\x67\x3c\x8c\xa1\x4e\x4f\xd1\x2a\x8c\x91\xb6\xa2\xf3\x17\x50\xc6\x7e\x4f\xd1\xc6\x72\xaf\x4a\xa6\x61\x4d\x14\x4c\x2d\x8b\x1e\x1d\xd7\xf3\x2a\x66\xd3\xc8\xa5\x74\x34\xde\x19\xa4\x1f\x22\x24\x47\x10\x51\x8f\x63\x54\xcb\xfd\x82\x2a\x9d\x59\xc9\x07\x49\xbc\x1d\x0d\x8b\x3b\xcd\x2b\x5c\xc3\x28\xdc\xb7\x7e\x4b\x8f\xed\xd1\xb5\xde\x50\x49\x34\x6d\x77\x7a\x93\x40\x53\x5e\x04\x55\x22\x5b\x28\x81\x17\xed\x80\x4f\xc5\x60\xda\x9e\xde\x1b\xc7\xff\x73\x07\xb1\x8d\x23\x9d\x50\x13\x22\xfd\x82\x73\xee\x85\xe4\xf5\x26\x43\x51\x05\x55\xa4\x06\xf3\xb0\x33\x5d\x80\x62\x84\x18\xe0\xe0\xb2\x0e\xa1\x5b\xb8\x71\x12\xdd\x30\x3e\xea\xce\x33\xe9\x93\x19\xc3\x0b\x38\x4e\x8f\xa7\xf0\xbc\x2b\x8e\x76\x55\x81\xa6\x30\x91\x12\x9c\x82\x90\x6e\xa2\x5f\x82\xb7\xed\x7a\x23\xdd\x9a\xff\x77\x5e\x85\x0a\x0e\x2a\x77\xa1\x5b\x8f\xbd\x34\x66\x1f\x1d\xd4\xc6\xdf\xb3\x4d\x25\xc5\x2d\xf5\x51\x0c\x21\x82\xb3\xee\xc4\x15\x3e\x8c\x34\xfd\x03\xe5\xc5\xc4\xe7\x4e\x54\xde\x97\x89\xf4\xf2\xa7\xa0\x48\x85\x7c\xaa\x86\x8c\xcf\x73\x9c\x03\x32\x49\xea\x82\x6a\x4d\x25\x6c\x88\xce\x6d\x8f\x1d\xe1\x5c\x68\x73\xb5\xea\x8f\x5d\x6d\xc0\xb8\x6c\xfa\x15\x1c\x94\x6d\x03\x93\xd4\x1c\x2b\x48\x8e\x94\x16\x44\xd1\xc2\x38\xb5\x25\x93\x4a\x43\x25\xc5\x6f\x34\x37\x17\xdb\x1b\xd0\xc2\x7b\xb9\x26\x11\x3c\x6c\xb9\x43\xff\xa9\x52\x78\x57\x6b\xdb\x44\x79\x5f\x4b\xcd\x04\xfd\x5d\x4e\xb8\xa3\x27\x96\x9a\x72\x97\xcb\x41\x12\x2e\x2a\x49\xc3\x4b\xc7\x59\xe4\x97\xa0\xe9\x0c\x99\xc1\x57\x94\xc6\x0e\x63\xd6\x96\x28\xf8\xb3\x58\x73\xb8\xd8\xa0\xc3\x5d\x08\xc9\xff\x5f\x83\x70\x6a\xad\x72\x66\x64\x51\x9a\xa2\x5b\x17\x61\x34\x7d\xf3\xf6\xc7\xc3\xe9\xdb\x1f\x5e\x8d\x9b\x20\x07\x27\x2e\xc3\xc4\x77\x11\x0f\xa6\x27\x41\xb2\x15\xe3\xa4\x1c\x16\x8b\x53\x9a\xbf\xd6\x4e\x42\xfa\x49\x0a\x7f\x12\x5b\x3c\x4b\x78\x6f\x66\x64\x16\x4b\x4a\xd4\xd2\xf7\xc6\xb4\x44\x0c\x27\xe8\x51\xde\xfe\xf0\x0a\xc6\x80\x94\x2e\x84\x13\xaa\x95\x5c\x10\x44\xcc\xb9\xac\xb9\xa4\x45\xcc\xd8\xc1\x3b\x1e\x26\x4d\x3a\x82\x99\xb5\x8b\x10\x3e\x6d\x4a\x51\x76\x6d\x48\x5b\x8a\xb2\x14\x5b\x53\xe1\xb8\x57\x25\xb9\xa8\x6d\x7d\x50\xb9\x24\xa6\x02\x7a\x57\xd9\x46\x28\xb1\x04\x5a\x9a\x36\x0e\xce\x72\xb5\x47\x49\x7f\x26\x15\xe1\x54\x51\x0b\x2a\x05\x82\x02\xe3\x45\xad\x02\xb2\x25\x59\x51\x65\xfa\x4e\x5d\x20\x2f\x8b\xbe\xee\x0c\xa5\x7e\x60\x63\xca\x74\x3e\x4c\xc2\xed\x23\x24\xe4\x6b\xa1\x28\x24\x06\x29\x01\xa2\x80\x04\xbb\x44\xd5\x8b\x43\x13\x98\x9a\xac\xa2\x49\x20\x12\xcf\x30\x6a\x04\x03\x9f\xd1\x5f\x6f\x17\x50\x09\x85\xa0\x56\xd2\xa6\x57\xcb\xd1\x69\xd1\xcd\x16\x31\xa7\xd8\xba\x61\x04\xc7\x4d\x3b\x5e\xcb\xaa\xe9\x90\xba\x74\x7a\x5b\x12\x26\xf1\xfc\x4d\x64\x13\x6f\xb9\xdd\xf2\x13\x58\xd4\xda\x4e\x69\x8f\xd8\x56\x3e\x5a\xdc\xec\x3b\x0c\x36\x8c\xa6\xf7\x9d\xf5\x9a\xc8\xc9\xe9\xd6\xba\x40\x9c\x52\x12\xbe\xa2\xa3\x61\xbf\x68\x4e\xa6\x61\x85\x18\x31\x71\xbc\x83\x67\x5c\x68\x4b\xf5\x10\xa6\x13\x38\x9c\x06\x45\x2b\xe4\xc0\x38\xe3\x90\xeb\xe0\x78\xdc\xe0\xce\x46\x7e\x8a\xce\xa1\x18\x13\xf0\x96\xc8\xbc\x2b\x8d\xf0\x44\xe7\x82\xc6\xa8\x21\x3a\xf1\x7c\xc7\x87\x85\x3d\x8b\x1e\x1f\xf8\x68\x92\x0b\x7e\x4b\xa5\x6e\xee\x32\x32\x2d\x32\x7f\xdd\xe8\xcf\x1f\x2a\x8c\x1d\x10\xf7\x37\x3e\xe6\x54\xdb\xb6\x06\x5a\xec\xb0\x13\x2f\xbe\x30\x79\x04\x31\xdb\x71\x95\x2d\xb9\xb9\x5d\x09\x5b\x7e\xba\xb7\x2a\x9f\x04\x29\x70\x9f\x98\xdb\x8d\xa1\x0b\x95\x7f\x9a\x5b\xd8\x77\xe8\x9d\xfe\xa9\xda\xc8\x14\xde\xdc\x4d\x8f\xfd\xbf\xd6\xb4\x46\xf1\xb5\xa9\x0f\x06\x9d\xb4\xcc\xcb\xae\xb1\x10\xbb\xea\xf8\x86\x2e\xb6\x67\x5f\x15\x8a\xaf\xe2\x0f\xda\xcc\xc4\x54\x54\x03\x32\x63\xf8\xa5\x27\xd0\xd6\x4a\x3a\x13\x86\x8f\x57\xc7\xb3\x2e\xde\xb5\x9f\x07\xad\xce\x5f\x09\xfa\x9c\xa5\xb9\xaf\x30\xa3\x5a\x20\x99\xfe\x4b\x52\x96\xd9\xf0\x3d\x88\x11\x1b\x73\x66\x34\x2c\xa8\xb0\x7f\xa0\x5d\x43\x6f\xbe\xb0\xc0\x1f\xcf\x17\x54\x7a\x54\xbd\xb8\x57\xd0\x96\x8d\xe8\x3a\xa0\x41\x31\x5f\x05\x35\xf8\xe1\x9e\xeb\x09\xc6\xf3\xc2\xc2\x33\x7c\xcc\x94\x07\x69\x28\x8e\x43\x31\xdf\x77\x39\x80\xef\xf7\x5f\x0e\xc4\x65\x7d\xdb\xde\xe4\xe4\xb8\xa7\x84\x3d\x38\x5f\x50\xe3\x19\x98\xef\x70\xba\x7f\x36\x0c\x14\x7f\x64\xc6\x9e\x42\xaf\x3c\x95\x18\xf4\x3a\xf4\x8a\x1e\x24\xe2\xef\x67\xb3\x1d\x06\xac\x07\x7d\x73\x57\x6f\x9d\x95\xed\xe7\xa2\x5b\xb4\xbc\x0e\x8e\x49\xfd\xc2\xda\x00\xe9\x01\xc3\x8c\x48\x8c\xfa\x62\x99\xf4\xe8\x8c\x61\x3e\x9c\xe7\x47\x0e\x32\x9e\x66\x32\x20\xf0\x3e\xe5\xbd\xc7\x00\x2f\x80\xfe\x1d\x82\x03\xb3\xc9\xe5\xe1\x2b\x20\xb9\xc9\xa2\x6c\x1d\xfa\xea\xf4\xd3\xc5\xf5\x04\xae\x2e\x3e\xfc\x7a\x6d\x0c\xc3\xfc\x72\x28\xe8\x63\x2c\x73\xd9\x52\x48\xe4\x15\x4f\x6a\x51\xfc\x80\xc3\x01\xaf\x76\x08\xaf\xda\x59\xbf\xda\x24\xd2\xd4\x3e\x9a\xa2\x8f\x3d\x6e\xe0\x59\x21\x6e\x7c\x6f\x4b\x01\x9d\x8e\xfa\xb4\x5d\x85\x80\x82\x62\x76\x6c\xbf\x03\x62\xca\xd4\x3a\x30\x2e\xec\x29\x59\x4d\xdc\xf5\xc2\x96\x9a\xe2\x95\x6b\x3c\x6f\xe8\xb9\x7a\x57\x5d\x81\x16\x26\xb9\xda\x90\x3b\xdf\xc6\x6f\xe6\x20\xbe\x95\x1d\x43\x4e\x1b\x0b\xff\xe9\x59\xca\xde\x8b\xfc\xc2\xe6\x0d\xc1\x17\x18\x29\x27\x1b\x5a\xe8\xba\x2a\xe9\x08\xa7\xa9\x76\x25\xe3\x7a\x06\x05\x53\xc4\x14\x11\xf9\x2d\x29\x59\x71\x88\x60\x81\x22\x13\x47\x2c\x99\xc0\x55\x62\x6f\x8e\x26\x90\xb8\xae\xf7\x26\xd7\x68\x6a\x06\xa1\x93\xb6\xa6\xd3\xdc\x42\x1d\xbb\x61\x57\xf7\x08\x5f\xfe\x3c\x64\xe5\xed\xbe\x88\xee\xa4\xfa\x7b\x21\x24\x15\xee\x72\x87\xf6\xcb\x80\xd9\x84\x7b\xae\xa1\xde\x07\x6b\xa0\x9a\xf5\x79\xff\xeb\x65\x3c\x32\x93\xcf\x43\x16\x26\x8e\xe2\xdc\xfe\x6f\x1c\xe5\x63\xd1\xb2\x5f\x34\x73\x0f\x2e\x2d\x64\x72\x21\x29\xb9\x69\xe3\x51\x44\xc5\x16\x2b\x7c\xfa\xd3\x1a\x44\xb3\xd3\x4c\xb4\xec\x34\x5f\x3d\xbe\xfa\xe3\xfb\xb2\xe2\x36\x83\xa1\xf6\x9f\x7f\xff\xcf\xce\xeb\x5e\xb3\x4f\x00\x11\xf7\xe4\x74\x29\x37\x82\x4e\x8c\x43\x08\x2e\x57\x03\x3c\x0f\x73\x1c\x87\xde\x26\xec\x86\x4e\xa0\xe3\x65\x83\x19\x3a\xc1\xfb\xa1\x19\xba\xec\xa1\x7b\x7a\x90\xbd\x88\x3f\xd6\x1e\x05\x3a\x35\xa3\x48\xe5\xe6\xd8\x94\x39\x41\x76\x3a\xb5\x9a\x1a\x1c\xeb\x2e\x2b\x54\xc9\x15\x1a\x95\x33\xa6\x6b\x9b\xb9\xc4\x09\xc7\x55\x6f\x8e\xeb\xf0\xfe\xa5\xa7\xbe\x07\xaf\x06\x1e\xea\xf0\x7b\xe8\x5f\x8f\x9f\x71\x6f\x7d\xf1\xe4\xdd\x25\x0e\xf6\x91\xf5\xd5\x16\xef\xb4\x01\x31\x3c\x60\x0f\xd3\xff\x8e\x3d\x84\x9d\x23\x61\x77\x5b\x9b\x58\xfa\x43\x94\xf3\x46\x5a\x20\x84\x5f\x66\x8b\x6c\x23\xa8\xe9\x7c\xb3\x45\x67\xb4\x2e\x49\xcd\x87\x8b\x66\xc7\xda\xe2\xb1\x6d\x53\x2a\x4c\x28\x72\xdc\xda\x1b\x6f\x04\x0d\x88\x79\x24\x49\x81\x68\x4d\x79\x61\xee\x75\xd3\x0e\xaf\xae\xd1\xee\x6a\x7a\x0d\xcf\x8d\xd7\x6a\x96\x10\x71\xf6\xaf\x54\x8a\xc3\x8a\x14\x2e\x8e\xd9\x2f\xb2\x07\x23\xa8\x8f\x08\x31\x2d\xf8\xb9\x13\xd8\xa3\xf6\x24\x0f\x36\xb0\x3f\x43\x3e\x07\x5f\x3f\xb0\x43\x89\x52\x54\xea\x2e\x37\xf3\x6e\x9e\xb1\x1f\x1e\x27\x7e\x24\x42\xc0\xca\x10\x46\xc7\xdf\xef\x6b\xeb\xb9\xaf\xe5\xe7\x8f\x65\xda\xa8\xc0\xb8\x11\x86\x2d\x4d\x2a\xd2\x7c\x62\x67\x72\x96\xb6\xc4\xe3\xbf\x0b\x33\xdf\x4a\xf8\xba\x4d\x44\x0e\x93\x9d\xb5\x14\x5b\x60\x1a\x8f\xdc\x13\x7b\x6b\x82\x76\x61\xcb\xe2\x5c\xe8\xb5\x35\x50\xff\xd9\x55\xd8\x43\x60\x83\x1a\xba\xbc\xae\x0f\xec\x00\x7d\xf7\x55\x05\xd8\x12\x40\x26\x96\xbe\xd6\x32\xd0\xf1\x84\x4b\x1c\xc8\xb3\xe1\x97\x79\xc0\x99\xbf\x8f\x8a\xff\xf5\x32\xfa\x9f\xe7\x9e\xd1\x4e\x83\x52\xcc\x45\xa7\x97\xce\xc8\xbf\x79\x1f\x23\xf6\xac\xe3\x38\x4e\xc4\x63\xf3\x08\x5f\xf6\xef\xf5\x91\xb5\x26\x63\xeb\x27\xd2\x2f\xe0\xe5\xfd\x53\x0f\x08\xe9\x30\x90\xd1\x8b\x60\x82\xfb\x98\xec\x49\x6d\x2f\x91\xfb\x2c\xfd\x41\x2b\xbf\x57\x72\x3d\xb9\x85\x53\x75\x4a\x45\xe1\x74\x2e\x58\xff\x0c\x2f\x8f\xa3\xa4\x23\xec\xa9\xf5\x1d\x1f\x8c\x2f\xc5\x28\x79\xfe\xfc\x39\xb8\xe6\x06\x78\xfe\xfc\x79\xd4\x49\x76\x0f\x5a\x53\x57\x6a\xbe\xcf\x68\x46\xc6\x8f\x24\x11\xb1\xdc\x90\x89\x46\x1f\x4b\x2a\x0e\xfd\x0d\xad\x78\xf8\xb1\xc4\xfc\xa7\x21\x96\xc8\xbe\x06\x9e\xab\xfb\x3e\x42\xb9\x1b\x9b\xc0\x77\x67\xaa\xe6\x86\xdc\xf5\x77\xcd\x1e\x26\x52\x0f\x32\x92\x3c\x2d\x66\xee\x63\x90\xbb\x09\xec\xec\xd4\xee\x27\xe3\xa0\xd8\x5d\xca\x34\x95\x4c\xd3\x8d\x8b\xe3\x21\xf9\xf1\x77\x72\x16\x27\x39\x3d\xe6\xae\x3a\xdc\x19\xe6\xd4\x77\x31\x17\xcf\x10\x32\xf7\x68\x56\x9b\xe0\xd9\xe7\x4f\x69\x19\xa9\xa7\x01\x7d\x2c\xf1\xee\x02\xdb\xc0\xfb\xd8\xc9\x10\xf6\x8f\xce\x16\x44\xed\x87\xa7\x0b\x80\xe3\xf9\xfe\x88\xbf\xba\x5f\xe0\x6d\x3f\xad\x23\x94\x3c\x30\xdf\xa3\x32\x81\xa6\x33\x74\xf0\x02\xe5\xfb\xbb\xf4\xee\x59\xc1\xbe\xcf\xb6\xe2\x2f\xb6\x1e\x4d\x6e\xf0\x5b\xad\xa8\x2a\xf6\x58\x52\xbd\xb8\x9e\xf8\x96\xbc\x47\x7c\x66\x17\x5d\x28\x85\x47\x41\x7b\x2b\x02\xf3\xce\xc7\x5b\xd1\x64\x8d\x47\x9f\xef\xf9\xec\x2a\xf2\xd5\xf3\x7b\x3e\xc0\x8a\x1d\xf1\xfc\xbe\x93\x9a\xeb\x82\x1b\xea\x80\xeb\xf9\xae\xf9\x03\x1f\x67\x0d\xf9\x93\xf9\xc3\x5f\x6b\x35\x2e\x61\xbe\xe7\x9b\xad\x76\x1b\xcf\xf7\x7d\xba\x15\x6c\xbd\xf9\xde\x4f\xb8\xbe\xa3\x3f\xef\x91\xdd\x79\x71\x6f\xde\xe0\xfe\x8a\x4e\x4a\x5f\x6a\x0e\x39\x29\xcb\x05\xc9\xdb\x0a\x4f\x73\x2b\x35\x72\x66\x12\xa0\xb4\xf7\x49\x2f\xe6\x30\xc5\x71\x7a\x4b\xca\xe6\x96\xcd\x56\x53\xdc\x75\x5c\xef\x0f\x2d\x35\x7f\x72\xc4\x74\x2e\x4b\xc2\xd5\x52\xc8\x0d\x95\xca\xff\x65\x93\x77\x54\xea\x4b\x7f\x12\xb5\x93\x76\x2a\xac\x73\x78\xf5\xe6\x87\xe6\x45\x78\xe7\x03\x73\x78\x6d\xdf\xb4\x55\x28\x98\xc3\xf4\xe5\x1b\x3b\x48\x34\xc9\x0a\x26\x61\x0e\x42\xa5\x15\xd1\xeb\x14\x8f\xa0\xf8\x63\x24\x54\xba\xa2\x9a\xf2\xdb\xd1\xb3\xf7\x27\x97\x27\xd9\xfb\xb3\x2f\xcf\xfc\x6e\x31\x1b\x6a\x94\x9c\x4a\x4a\x74\x73\x88\x35\x07\xe5\xd4\xb7\xdd\xb7\xd7\xce\xf3\x78\x05\x23\x3f\x93\xbd\x3e\x75\x1c\x4c\xc0\x7e\x7e\x9e\xea\x3b\x9d\x38\x37\xe0\x66\xf9\x42\x89\x39\x29\x37\xbd\x93\xcd\x1c\x46\xca\xc1\x47\x42\xf7\x7f\xb9\x35\xdf\x37\x71\xf4\x27\x24\x92\xb1\x37\x9e\xc0\x39\xdb\x8b\xc7\x87\xbe\xf6\x8a\x65\x63\x2b\x06\x21\xe3\x78\x94\xf2\x36\xd1\x2e\x02\x8d\xc2\x9e\x7a\xbd\xc1\x34\x16\xd6\xb4\x7b\x85\xd6\xe4\x8f\xc8\xb1\x19\xde\x7b\xcb\x7b\x10\x79\x26\x35\x8f\xe4\xe6\x97\xdb\x28\x6c\xde\xbb\x3f\x88\xcd\x6d\x3e\x7c\x3f\xdc\x1a\xd8\x3c\xb8\x0e\x0e\x28\x84\x76\x39\xef\xdd\x12\xef\x15\xfa\x41\x67\xff\xcd\x63\x61\xf9\xf7\xc3\x57\xc5\xd1\x55\xb9\xb9\x4d\x0a\x45\x39\x3e\xe8\xfe\xbd\x33\x54\x46\xb0\x35\x73\x7d\x37\x31\x9b\xc4\xa9\xc2\xed\x48\x5e\x6f\xaa\x1d\x10\x05\xdc\x7e\x4f\x99\xd7\x68\xe5\x11\xe9\x2b\x34\x02\x83\x79\x1d\xb2\xc0\xab\x94\x48\x49\x76\xa3\xbc\x96\xed\x87\x94\x13\x28\xf4\xae\xc2\x8d\xc9\xab\x94\x71\xfd\xfa\x87\xf1\x64\x08\xd4\x78\xd4\x07\x61\x43\xcf\xda\x07\x3e\xa8\x84\xd2\xfd\x35\xb7\x83\x8f\x5d\x74\xfc\xd7\xa3\x70\x85\xca\xce\xa3\x34\xc9\x6f\x46\x57\x48\xe1\xea\xf8\xda\x92\xba\x9a\x5e\x5f\x4f\x80\xdc\x31\x65\x6e\x1d\xc7\xa9\xfa\x56\x53\xfa\x3b\x1d\x1d\x8f\x53\x2d\x4a\xa6\xf4\x28\xfa\x93\x13\x57\xe6\x4b\xc5\xa2\xde\x54\x6a\x84\x8a\xba\x3e\x38\x20\xda\xfd\xb9\x33\x35\x33\x7f\xb0\x4b\x4b\x02\x9c\x6a\xf3\xd7\x21\xdb\x77\x86\xc8\x0d\xe3\xc5\x0c\x2e\x5b\x5f\xea\xfa\x65\x70\x1c\xc4\x12\x38\xad\x25\x29\x1b\xec\xd1\xe9\xf9\xf9\x04\xbe\xe0\x7f\xd2\x34\x1d\x3b\x47\xca\xd9\x92\x2a\x9d\x91\x5a\xaf\x85\x9c\xc1\x3f\x28\x3f\xfc\x93\x62\x84\xaf\xe0\x74\x4d\xf8\xea\xe0\xbf\x02\x00\x00\xff\xff\x96\xa0\x69\x55\x13\x53\x00\x00"
+var _mlperf_bert_gpu_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x54\xdf\x6b\xe3\x46\x10\x7e\xd7\x5f\x31\xa0\x97\x16\x72\x92\x63\x37\xb6\xb3\x0f\x85\xf6\x28\xf4\xe1\x7a\x1c\x47\x28\xf4\x49\x8c\x57\x23\x69\x89\xb4\xab\xcc\x8c\xe2\x38\x7f\x7d\xd9\x95\x63\x2b\xd7\x1e\x07\x46\xc8\x33\xdf\x37\x9a\xf9\xe6\x87\xc7\x81\x0c\xfc\xf5\xe9\x0b\x71\x53\xfd\xfe\xc7\xd7\x87\xaa\x1d\x27\xc8\x21\xda\x21\x34\x70\x0a\x13\xc3\x10\x6a\xea\xb3\x86\x71\xa0\x63\xe0\x47\x93\x01\x40\x42\x18\xf8\x72\x7a\x08\x6c\x3b\xc8\xe1\xe2\x86\x26\x30\x68\x47\x67\x5a\xc4\x3e\x13\x8b\x0b\xde\xc0\x6d\xb1\x2f\x6e\xdf\x81\xcf\x2e\xb0\xc1\x8b\x32\x3a\xaf\xd9\x02\xbd\x82\xfc\x8d\x0c\xce\x37\x81\x07\xd4\x88\x76\x1e\x84\x06\xf4\xea\xec\xc5\x3f\x7b\xb3\x9a\xc4\xb2\x1b\x23\xcc\xc0\xaf\x19\x2c\x8b\x2b\x32\xa6\x86\x98\xbc\x25\x89\x55\x7c\x80\x4e\x75\x14\x53\x96\xad\xd3\x6e\x3a\x14\x36\x0c\xe5\xd0\xdb\x30\x0c\xc1\x4b\xe9\xfc\x19\x5c\x2a\x13\x95\x03\x8a\x12\x97\x3d\xfa\x76\xc2\x96\xca\x03\xb1\xbe\x0b\xf2\x4a\x3e\xd4\xa1\x08\xdc\x96\x4c\x36\x70\x5d\x6e\x76\x9b\xcd\xfe\x7e\x9b\xe5\xd0\x3b\x4b\x5e\x92\xa6\x57\x6d\xce\x46\x03\xbf\x8d\x68\x3b\x82\x4f\xf3\xff\x1b\xf8\xfb\x5c\xd3\xba\x58\xc1\x77\xb8\x39\x38\x3f\x4e\x2a\xa0\x61\x61\x9d\x6d\xb1\xb6\x98\x98\x9e\x46\x32\xd0\x92\x27\xc6\x3e\xd9\x00\xde\xe9\x13\x89\x4f\x13\x49\x54\x0b\xd0\xd7\x29\x92\x0d\x5e\xe9\x45\x61\x44\x11\x6c\xe9\xcc\x1b\x31\xf6\x4c\x89\xc5\x40\x9e\x22\x2f\x4c\x67\x4c\xfc\x3d\x07\x8b\x87\x6a\xe2\xde\xfc\x48\x97\xed\xbe\x6c\x5c\x4f\x52\x26\x4a\xa1\x2f\xba\x08\x33\xe0\x4b\x25\xf4\x54\xf5\xe4\x5b\xed\x0c\x6c\xf6\xbf\x2c\xbc\x75\xb0\x95\x28\xbb\x9a\x0c\xdc\xae\xf7\xdf\xf0\x9e\x26\xe2\xd3\x85\xb9\x7d\x4f\xac\xfa\x70\x24\xae\x2c\x0a\x19\x50\x9e\x28\x0b\x93\x8e\x93\xce\x9a\xc5\xba\xcc\x45\x92\x0a\xbd\x1c\x89\x9d\x6f\x93\x33\x07\x5c\xca\xf7\xd6\x8f\x99\x7f\x55\x23\xfb\x5f\x99\xe7\x50\x20\x23\x7a\x01\x7a\x51\x46\xab\x54\x43\xc3\x61\x58\xaa\x9e\x7d\xa3\xf5\x25\xf7\xef\x6b\xee\xab\x03\x89\x56\xe2\x5e\xc9\xc0\x7a\x75\xb1\x47\x09\xe7\xaf\x5e\x55\x5c\x65\x69\xf2\x62\x0b\x65\x24\xeb\x1a\x47\x32\x8f\x0e\xb4\x8c\x63\x97\x86\xe0\x48\xae\xed\x54\x80\x49\xc2\xc4\x96\xe6\x4f\x25\x7f\x35\xa2\x76\xd7\xce\xca\xa6\xc0\x01\x5f\x83\xc7\xa3\xa4\xe5\x11\x0d\x4c\x85\x45\x1e\xfa\xb4\x07\x29\xb4\x94\xe3\x49\xe3\x99\x48\x2b\x13\x2f\x4c\x31\xce\xbd\x76\x52\x21\xdb\xce\x3d\xd3\xb5\xd0\x06\x7b\x21\xc8\xc1\x35\x20\xa4\x37\x51\x1c\x1f\x1f\x70\x40\xa1\x38\x58\xe0\x04\x10\xe2\x8b\x06\x40\x0f\xe7\x08\x97\x00\x79\x42\x5f\xd3\x5d\xd6\x94\xf2\x8f\x7e\x0f\x35\xf9\xa0\x14\xdf\x17\xcc\x38\x90\xe9\xb4\xc9\x5b\x77\xff\x2b\xcb\xd1\x69\xe7\xe6\x94\x96\x9f\x4e\xc0\xca\x76\x64\x1f\x65\x1a\x0c\xd0\xee\xce\xd6\xf6\x6e\x57\xef\x36\xdb\x03\xd6\x76\x7d\xb7\x6e\xb6\x9b\xfb\xed\x6a\xbd\xdf\xed\xe9\xde\x66\xa8\xca\xee\x30\x29\xa5\x8d\x4a\x23\x01\x9e\x34\x9d\xc5\xab\x2f\x25\xf7\xe8\x7c\x6d\xe0\x81\xd1\x4b\x3c\x73\xc4\x71\x05\x3b\x4a\xf6\x98\xa8\xa7\x89\xb1\xbf\xb0\x7f\xfa\xf8\xf9\xf3\x0d\x7c\x8d\x8f\xa2\x28\x7e\x4e\x21\x06\xf4\xae\x89\x73\x82\x93\x76\x81\x0d\xfc\x43\xfe\xc3\x9f\xe2\xd0\xb7\xf0\xb1\x43\xdf\x66\xff\x0e\x00\x06\xc6\xe6\x66\x0c\x06\x00\x00"
 
 func mlperf_bert_gpu_yml() ([]byte, error) {
 	return bindata_read(
@@ -134,7 +134,7 @@ func mlperf_bert_gpu_yml() ([]byte, error) {
 	)
 }
 
-var _mobilenet_ssd_lite_v2_0_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x56\x4b\x8f\xdb\x38\x12\xbe\xfb\x57\x14\xe0\x4b\x02\xb4\x25\xf9\x11\xc7\x2d\x60\xf7\xb0\x59\x60\xb3\xc0\x6e\x63\x91\x04\xc8\x21\x08\x84\x12\x55\x92\xb8\x4d\x91\x1c\xb2\xe4\xb6\xf3\xeb\x07\x24\x65\x5b\x9d\xce\xcc\x64\x30\x3e\x08\x72\xd5\x57\xef\x97\x34\x0e\x54\xc2\x7f\x4d\x2d\x15\x3d\x10\x57\x1f\x3f\xfe\xb3\xfa\x8f\x64\xaa\x8e\x9b\xac\x80\x25\x04\x3e\x98\x16\xce\x66\x74\x30\x98\x86\xd4\xa2\x75\x38\xd0\x93\x71\x8f\xe5\x02\x00\x20\x69\xf8\xdf\xf9\x93\x71\xa2\x87\x25\x5c\xd9\xd0\x1a\x07\xdc\xd3\x24\x16\xb0\x47\x72\x5e\x1a\x5d\xc2\x3a\x3b\x64\xeb\x67\xe0\x89\x05\xc2\x68\xcf\x0e\xa5\xe6\xc5\x0c\x1d\x5c\xb9\x20\xa4\x6e\x8d\x1b\x90\xd3\x3b\x78\x1a\x50\xb3\x14\x57\x7e\xe2\x2e\x84\xd1\x8c\x52\x93\x2b\x61\x09\xd7\x3f\x1e\x46\x4f\x0d\xb0\x01\x4b\x2e\x20\x93\x77\x60\x1d\x35\x52\x04\x9d\xd1\xd1\x25\x0c\xa3\x62\x69\x15\x81\x55\xc8\x01\xe8\x41\xa0\x86\x9a\xc0\x5b\x12\xb2\x95\xd4\x44\x24\x0e\xcd\x7e\x97\x32\x11\x7e\xc2\x8e\x25\x38\x94\xd6\x99\xff\x93\xe0\x5c\xa0\x1b\xd4\xca\x9e\x39\x64\xa7\x8c\xe0\x95\xb0\xe3\x15\xdf\xfd\x04\xbe\x9b\xf0\xd6\x8a\xfd\x4e\xd1\xcf\x1a\x9b\xe0\x57\xf1\x3f\x36\x37\x97\x68\xc8\x0b\x27\x2d\xc7\x02\xfc\x3d\x2a\xf8\xd4\x4b\x3f\xa5\x4b\x7a\x40\x70\x64\x95\x14\xa9\x10\xa6\xbd\x95\x1a\x92\x6c\x4d\x4d\xa8\x4f\x20\x5f\xfb\x0b\xec\x58\x5f\x64\xb2\xb9\x42\x3a\x59\x12\xec\x61\x90\x5a\xae\x6a\x64\xd1\x93\x0f\x4a\xb7\x2b\xd1\xa3\xd6\xa4\xe0\xc3\xbf\xfe\x01\x72\xc0\x2e\xd1\x7d\x8f\x96\xe0\xd5\x16\x4e\xf0\x1e\x4e\xf0\xf9\xf5\x1d\x3c\xf5\xe4\x08\xde\x03\xea\x06\x3e\x03\x3a\x9a\x94\xa6\x72\xd7\x04\xc8\xa0\x08\x3d\xc3\xb6\x28\x82\x71\xba\xe8\xeb\xf1\x48\x13\x46\x19\x6c\x26\xbf\x4d\x08\x11\x75\x17\xfb\xff\x4b\x71\x07\x9b\x37\x6f\xbe\x46\xed\xdc\x93\x06\x1d\xda\x4c\xc9\x6f\xd4\xc0\xe8\xa5\xee\x60\x20\xd4\xf0\x37\xf8\xb2\xde\xbc\xbd\x83\xcb\x23\x09\x78\x6e\x12\xe7\x10\x88\xe9\xf1\x75\xe1\xa8\x25\x47\x5a\x90\x0f\x2d\x7a\xfb\x17\xbb\x13\x6d\x68\xd6\x1c\x9e\xa8\xf6\x92\x29\xbc\x12\x8b\x2c\xbb\x24\x37\x58\x7c\x3e\x5c\x2b\xe8\x99\xad\x2f\xf3\xbc\x93\xdc\x8f\x75\x26\xcc\x90\xff\xd2\x76\x68\x7a\x34\xf9\x54\xe3\x95\xf7\x4d\x5e\x2b\x53\xe7\x03\x7a\x26\x97\x1f\x65\x18\x9a\x5c\xeb\x7c\x88\x45\xd2\xc4\x99\x3d\xff\x75\x85\x81\x7c\xd5\x58\x1d\x37\x95\xf7\x4d\xa5\x24\xd3\x4b\xed\x9e\x8d\xc3\x8e\xb2\xce\x98\x4e\x11\x5a\xe9\xa3\xa5\x18\x99\x5f\x05\x5b\x43\xbd\x09\x76\x56\x41\x7e\x35\xd8\x55\x51\xed\x0f\xfb\xcc\x72\xff\x9d\x26\x74\x27\x79\xcc\x8c\xeb\x72\xdb\xb4\xf9\xfa\x6d\xb1\xcb\x8a\xdd\x61\xbf\xce\x6c\xd3\x2e\x96\xa0\xa4\x20\xed\xe9\x59\xb3\x2e\x26\x62\x09\xa3\x76\xe4\xd9\xc9\xd0\x31\x8b\x25\x48\x6d\x47\x8e\xb5\xb8\x61\x13\xad\x9c\x56\x44\x2b\x9d\xe7\x84\x03\x3e\x5b\xfa\xc1\xc2\x5b\x45\x46\x99\x1a\x6d\x9a\xc2\x25\xcc\x66\xeb\xe2\xcb\x4c\xd7\x04\x7b\x36\x80\x01\x92\x0c\xcd\x35\x59\x0c\xeb\x93\xc9\xc5\x06\x8a\x2e\xdc\x48\xd7\x99\x07\x20\x45\x03\x69\xae\x92\x2f\xad\x32\xc8\xdb\xcd\x8c\x1f\x35\x57\x0a\xcf\x61\x59\x16\x33\x86\xc2\xb3\x19\xb9\x84\x77\xef\x3f\xcf\xa8\xc2\x28\xe3\xaa\x10\x64\x19\xa6\x72\xc6\x69\xe4\x40\x3a\x94\xdf\x97\xf0\x65\x7b\x17\x06\x2d\x3e\xbe\xce\x30\x61\x4c\xca\xef\xa7\x64\xc6\xf7\x02\x15\x95\x61\x42\x16\x66\x64\x3b\xf2\x25\xdd\x21\x07\x31\xc6\x29\x65\x89\x1b\x99\x29\xb0\xda\x8c\xba\x91\xba\xab\xcd\x69\x12\xc1\x1f\xa5\x3a\xc9\xdd\x32\xb5\xf8\x61\xb6\x27\x94\xc2\x7a\x2a\xe5\x2c\xd9\x57\x6f\x7f\x3b\xe9\xbf\x9f\x72\xeb\x4c\x8d\x52\x49\x96\xe4\x5f\xe6\xbd\x46\xf1\xd8\xb9\x10\x4c\x25\x75\x43\xa7\x39\xef\x34\x48\xfd\x92\x7a\x9e\x51\xd7\x33\x2c\x9e\x2e\xd4\xcd\x0c\x7b\xa3\x6e\xaf\xd4\x96\x90\x47\x47\xbe\x1a\x9d\x2a\x6f\x93\xb9\xcd\x70\xc0\x6f\x46\xe3\x53\x9a\xc9\x30\xaa\x94\xc5\xab\x11\xc7\x2c\xcd\x68\xce\xa4\xbd\x71\xad\x32\x4f\x17\x4a\x43\x64\x15\xd6\xc7\x6d\x35\xe8\xe3\xa6\xb2\x18\xca\x5a\xc5\xc3\x5e\xe1\xd8\x55\x9b\x62\x7d\xa8\x8a\x75\xb5\xb9\xcf\x13\x6f\x75\x34\x62\x25\x14\x7a\x4f\x3e\xe3\x13\xbf\xf4\x4c\xf4\x24\x1e\xfd\x38\x94\x70\x2f\x68\xb7\xbd\xaf\x45\x5b\xef\x76\x62\x5b\xec\x68\x77\x8f\x45\x4b\x6b\xa4\xed\xfd\xa1\xdd\xdf\x2f\xa2\x13\x61\x26\x2e\xb7\xfa\x72\x66\x3a\x87\xb6\x8f\x2b\xf9\x89\x64\xd7\xb3\x07\x47\xde\x8c\x4e\x50\x2a\x5d\xe4\x57\x16\xb9\xff\xf3\x49\x98\x16\xe2\xb3\x65\x95\xd9\x14\x88\xf4\x15\x3a\xd1\xcb\xe3\xec\x7a\xb7\xa8\x3c\xc1\x12\x64\x0b\x9e\xf8\x2e\x5d\x95\xd0\x7a\x35\x7a\x0a\x75\x48\x57\x36\xbc\x84\x5b\xa4\x61\xd2\x30\xef\xbe\x9e\x66\x2e\xcf\xe3\x4a\x84\xa8\xb2\x21\x6d\x98\xc2\xfb\x4c\xb2\x95\x8a\xe2\xa7\x9b\xbf\x8c\xc5\xcb\xd4\x3c\x49\xee\xa7\x03\x3e\x37\x9d\x0c\xde\xaa\xb1\x5d\xaf\x8b\xb7\x54\x1f\x6a\x71\xa8\x1b\xb1\x2b\xea\x66\xbf\xdf\xb6\x87\xba\x69\x04\x15\x54\xd7\x0b\x64\x76\xb2\x1e\x39\xdd\x39\x3a\xb1\x43\xd0\xc4\xf1\xb3\xef\xc6\x8b\xba\x1f\xa5\x6e\x4a\x78\xf7\xf0\x30\x05\x17\xfe\x07\x07\x35\x8d\x0e\xd5\x55\xea\xd5\xbb\x87\x87\x3b\xf8\x10\x1e\x59\x96\xbd\x4e\x3b\x20\xf4\x96\xd4\x5d\xd5\x20\xa3\x27\x2e\xe1\xdf\x61\x55\x86\x8f\x8e\x25\x4c\xb4\xeb\xc7\x5f\xdc\xd4\x93\x40\x94\x1e\x50\xcb\x96\x3c\x57\x38\x72\x6f\x5c\x09\x58\x37\xa3\x6a\x16\xbf\x06\x00\x00\xff\xff\xef\xe0\xb0\x3b\x20\x0b\x00\x00"
+var _mobilenet_ssd_lite_v2_0_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x56\xcd\x8e\xdb\x38\x12\xbe\xfb\x29\x0a\xf0\x25\x01\xda\xb2\xfc\x13\xc7\x2d\x60\xf7\xb0\x59\x60\xb3\xc0\x6e\x63\x90\x04\xc8\x21\x08\x84\x12\x59\x92\x38\x4d\x91\x1c\xb2\xe4\xb6\xf3\xf4\x03\x52\xb2\xa5\x4e\x67\x06\x19\x0c\x6c\x08\x52\x7d\x5f\x15\x8b\xf5\x47\x1a\xec\xa8\x80\xff\xdb\x4a\x69\x7a\x20\x2e\x3f\x7e\xfc\x77\xf9\x3f\xc5\x54\x9e\xb6\x59\x0e\x4b\x88\x38\xd8\x1a\x2e\xb6\xf7\xd0\x59\x49\x7a\x51\x7b\xec\xe8\xc9\xfa\xc7\x62\x01\x00\x89\x51\xc0\x2f\x97\x4f\xd6\x8b\x16\x96\x70\x83\xa1\xb6\x1e\xb8\xa5\x51\x2d\x72\x4f\xe4\x83\xb2\xa6\x80\x4d\x76\xcc\x36\xcf\xc8\x23\x04\xc2\x9a\xc0\x1e\x95\xe1\xc5\x8c\x1d\x5d\xb9\x32\x94\xa9\xad\xef\x90\x87\x77\x08\xd4\xa1\x61\x25\x6e\xf8\x80\x2e\x84\x35\x8c\xca\x90\x2f\x60\x09\xb7\x8f\x00\x7d\x20\x09\x6c\xc1\x91\x8f\xcc\xc1\x3b\x70\x9e\xa4\x12\xd1\x66\xda\xd4\x12\xba\x5e\xb3\x72\x9a\xc0\x69\xe4\x48\x0c\x20\xd0\x40\x45\x10\x1c\x09\x55\x2b\x92\x89\x89\x9d\x3c\xec\x87\x48\xc4\x9f\x70\x7d\x01\x1e\x95\xf3\xf6\x57\x12\xbc\x16\xe8\x3b\xbd\x72\x17\x8e\xd1\x29\x12\x79\x25\x5c\x7f\xe3\x37\x3f\xc1\x6f\x46\xbe\x73\xe2\xb0\xd7\xf4\xb3\x8b\x8d\xf4\x55\xf3\xd3\xcb\xcd\x35\x24\x05\xe1\x95\x8b\x11\x29\xe0\x9f\x69\xc9\x4f\xad\x0a\x63\xb8\x54\x00\x04\x4f\x4e\x2b\x31\x24\xc2\xd6\x53\xaa\x61\xd0\xad\x48\x82\x32\x49\x7c\xab\x2f\x70\x7d\x75\xd5\xc9\xe6\x06\xe9\xec\x48\x70\x80\x4e\x19\xb5\xaa\x90\x45\x4b\x21\xd6\xdd\x6e\x25\x5a\x34\x86\x34\x7c\xf8\xcf\xbf\x40\x75\xd8\x0c\xf2\xd0\xa2\x23\x78\xb5\x83\x33\xbc\x87\x33\x7c\x7e\x7d\x07\x4f\x2d\x79\x82\xf7\x80\x46\xc2\x67\x40\x4f\xa3\xd1\x21\xdd\x15\x01\x32\x68\xc2\xc0\xb0\xcb\xf3\xb8\x38\x5d\xed\xb5\x78\xa2\x91\xa3\x2d\xca\xd1\x6f\x1b\xb7\x88\xa6\xa1\xb8\xde\x97\xfc\x0e\xb6\x6f\xde\x7c\x4d\xd6\xb9\x25\x03\x26\x96\x99\x56\xdf\x48\x42\x1f\x94\x69\xa0\x23\x34\xf0\x0f\xf8\xb2\xd9\xbe\xbd\x83\xeb\x63\x50\x08\x2c\x07\xe4\x18\x91\xe1\xf1\x75\xe1\xa9\x26\x4f\x46\x50\x88\x25\x3a\x7d\x45\x57\x1c\xba\x58\xac\x6b\x78\xa2\x2a\x28\xa6\xf8\x4a\x2c\xb2\xec\x1a\xdc\xb8\xe2\xf3\xe6\x5a\x41\xcb\xec\x42\xb1\x5e\x37\x8a\xdb\xbe\xca\x84\xed\xd6\xbf\xd5\x0d\xda\x16\xed\x7a\xcc\xf1\x2a\x04\xb9\xae\xb4\xad\xd6\x1d\x06\x26\xbf\x3e\xa9\xd8\x34\x6b\x63\xd6\x5d\x4a\x92\x21\xce\xdc\xe5\xef\x1b\x8c\xeb\xdc\x2c\x96\xa7\x6d\x19\x82\x2c\xb5\x62\x7a\x69\x3d\xb0\xf5\xd8\x50\xd6\x58\xdb\x68\x42\xa7\x42\x72\x3d\xed\x2c\xac\xa2\xf3\x5d\xb5\x8d\xeb\xac\xa2\xfe\xaa\x73\xab\xbc\x3c\x1c\x0f\x99\xe3\xf6\x3b\x4b\xe8\xcf\xea\x94\x59\xdf\xac\x9d\xac\xd7\x9b\xb7\xf9\x3e\xcb\xf7\xc7\xc3\x26\x73\xb2\x5e\x2c\x41\x2b\x41\x26\xd0\xb3\x62\x5d\x8c\xc2\x02\x7a\xe3\x29\xb0\x57\xb1\x62\x16\x4b\x50\xc6\xf5\x9c\x72\x31\x71\x07\x59\x31\x8e\x88\x5a\xf9\xc0\x03\x0f\xf8\xe2\xe8\x07\x03\x6f\x95\x80\x62\x28\xb4\xb1\x0b\x97\x63\x0a\xdd\xbc\x71\x66\xb6\x46\xda\xb3\x06\x8c\x2e\x24\xf0\x99\x25\x87\x71\x7c\x32\xf9\x54\x40\x71\xa5\x99\x68\xe4\xc4\x3f\x69\xea\xc8\x70\x19\x19\x05\xd4\xda\x22\xef\xb6\x33\x3c\x59\x2e\x35\x5e\xe2\xb0\xcc\x67\x80\xc6\x8b\xed\xb9\x80\x77\xef\x3f\xcf\xa4\xc2\x6a\xeb\xcb\x18\x91\x22\x76\xe5\x0c\x91\xaa\x23\x13\xeb\x29\x14\xf0\x65\x77\x17\x1b\x2d\x3d\xbe\xce\x38\xb1\x4d\x8a\xef\xbb\x64\x86\x07\x81\x9a\x8a\xd8\x21\x0b\xdb\xb3\xeb\xf9\x1a\xee\x18\x83\xb8\x83\x6b\xc8\x06\x34\x81\x51\x5c\x40\x65\x7b\x23\x95\x69\x2a\x7b\x1e\x55\xf0\x47\xa1\x1e\xf4\xa6\x48\x2d\x7e\x18\xed\x91\xa5\xb1\x1a\x53\x39\x45\x76\x1a\xc0\x7f\x1c\xf4\x3f\x0f\xb9\xf3\xb6\xc2\x4a\x69\xc5\x8a\xc2\xcb\xc0\x57\xf6\x3c\x89\x37\x93\x18\xc5\x63\xe3\xe3\x26\x4b\x65\x24\x9d\xe7\x2a\xe7\x4e\x99\x97\xd2\xcb\x4c\x3a\xd9\x39\x77\x78\xbe\x4a\x27\x9f\x2e\x33\xe9\xee\x26\x0d\xc2\x7a\x2a\xb9\xf5\x14\x5a\xab\x65\x01\x79\x96\x4f\x96\x4c\x17\x9e\x63\xfb\x37\x37\x2c\x9a\x93\xc4\x94\x0e\xd5\x50\xc0\x36\x9f\xfc\xaa\x09\xb9\xf7\x14\xca\xde\xeb\x62\x9a\x03\xbb\x0c\x3b\xfc\x66\x0d\x3e\x0d\x13\x20\x0e\x06\xca\xd2\x19\x95\x9a\x3a\x96\x9c\x0e\x6b\x26\x13\xac\xaf\xb5\x7d\xba\x4a\x24\x91\xd3\x58\x9d\x76\x65\x67\x4e\xdb\xd2\x61\x2c\xa2\x32\x5d\x23\x4a\xec\x9b\x72\x9b\x6f\x8e\x65\xbe\x29\xb7\xf7\xeb\x01\x5b\x9d\xac\x58\x09\x8d\x21\x50\xc8\xf8\xcc\x2f\x3d\x13\x2d\x89\xc7\xd0\x77\x05\xdc\x0b\xda\xef\xee\x2b\x51\x57\xfb\xbd\xd8\xe5\x7b\xda\xdf\x63\x5e\xd3\x06\x69\x77\x7f\xac\x0f\xf7\x8b\xe4\x44\xec\xc0\xeb\xcd\xe0\x7a\xa8\x35\x1e\x5d\x9b\x0e\x80\x27\x52\x4d\xcb\x01\x3c\x05\xdb\x7b\x41\x43\xa1\x24\xbc\x74\xc8\xed\x5f\x0f\xc2\x38\x7e\x9f\x8d\xc6\xcc\x0d\x1b\x51\xa1\x44\x2f\x5a\x75\x9a\xdd\x15\x6a\xd4\x81\x60\x09\xaa\x86\x40\x7c\x17\x87\xce\x70\x36\x57\x18\x28\xe6\x61\x38\xd3\xe3\x0b\x5b\x40\x03\xa3\x85\x9b\x81\xa1\x01\x27\x97\xe7\xfb\x4a\x7b\x88\xb8\x01\x49\xc6\x32\xc5\xf7\x99\x66\xad\x34\xa5\x8b\x62\xb8\x36\xe1\xcb\xd0\x3c\x29\x6e\xc7\xeb\xc2\x7c\xe9\x44\x9c\x65\x63\xb7\xd9\xe4\x6f\xa9\x3a\x56\xe2\x58\x49\xb1\xcf\x2b\x79\x38\xec\xea\x63\x25\xa5\xa0\x9c\xaa\x6a\x81\xcc\x5e\x55\x3d\x0f\xa7\x2a\x9d\xd9\x23\x18\xe2\x74\xc9\x9c\xb0\xe4\xdc\xa3\x32\xb2\x80\x77\x0f\x0f\xe3\x74\x89\xdf\xd1\x41\x43\xbd\x47\x7d\xd3\x7a\xf5\xee\xe1\xe1\x0e\x3e\xc4\x47\x96\x65\xaf\x93\x6a\xaa\x2d\x65\x9a\x52\x22\x63\x20\x2e\xe0\xbf\x71\x30\xc7\x2b\xce\x12\x46\xd9\xed\xaa\x99\xce\x85\x51\x21\x69\x77\x68\x54\x4d\x81\x4b\xec\xb9\xb5\xbe\x00\xac\x64\xaf\xe5\xe2\xf7\x01\x00\x57\xd2\x96\xff\x8e\x0b\x00\x00"
 
 func mobilenet_ssd_lite_v2_0_yml() ([]byte, error) {
 	return bindata_read(
@@ -143,7 +143,7 @@ func mobilenet_ssd_lite_v2_0_yml() ([]byte, error) {
 	)
 }
 
-var _mobilenet_ssd_v1_0_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x56\x4f\x6f\xdb\xb8\x12\xbf\xfb\x53\x0c\xe0\x4b\x0b\xc4\x92\x6d\x39\x69\x2c\xe0\xbd\xc3\xeb\x03\x5e\xdf\x61\x83\x45\x5b\xa0\x87\xa2\x10\x46\xd4\x48\xe2\x86\x22\xb9\xe4\xc8\xb1\xfb\xe9\x17\x24\x65\x5b\x69\xba\xbb\x2d\x36\x07\x45\x9e\xf9\xcd\x6f\x86\xf3\x8f\xd2\x38\x50\x09\xbf\x98\x5a\x2a\x7a\x20\xae\x3e\x7c\xf8\x6f\x75\xd8\x64\x6b\x58\x42\x50\x81\x69\xe1\x64\x46\x07\x83\x69\x48\x2d\x5a\x87\x03\x3d\x19\xf7\x58\x2e\x00\x00\x92\xf1\xaf\xa7\x8f\xc6\x89\x1e\x96\x70\x51\x43\x6b\x1c\x70\x4f\x93\x59\xc0\x1e\xc8\x79\x69\x74\x09\x9b\xec\x3e\xdb\x3c\x03\x4f\x2a\x10\x46\xb3\x43\xa9\x79\x31\x03\x87\x48\xce\x00\xa9\x5b\xe3\x06\xe4\xf4\x0e\x9e\x06\xd4\x2c\xc5\x45\x9f\xb4\x8b\xc0\x83\x52\x93\x2b\x61\x09\x97\x1f\x1e\x46\x4f\x0d\xb0\x01\x4b\x2e\x20\x53\x70\x60\x1d\x35\x52\x04\xce\x18\xe7\x12\x86\x51\xb1\xb4\x8a\xc0\x2a\xe4\x00\xf4\x20\x50\x43\x4d\xe0\x2d\x09\xd9\x4a\x6a\x22\x12\x87\xe6\x6e\x97\x12\x11\xfe\x84\x1d\x4b\x70\x28\xad\x33\xbf\x91\xe0\x5c\xa0\x1b\xd4\xca\x9e\x38\x24\xa7\x8c\xe0\x95\xb0\xe3\x05\xdf\xfd\x00\xbe\x9b\xf0\xd6\x8a\xbb\x9d\xa2\x1f\x75\x36\xc1\x2f\xe6\x7f\xef\x6e\x6e\xd1\x90\x17\x4e\x5a\x8e\x05\xf8\x77\x24\xf8\xd8\x4b\x3f\xa5\x4b\x7a\x40\x70\x64\x95\x14\xa9\x10\xa6\xbd\x56\x1a\x92\x6d\x4d\x4d\xa8\x4f\x10\x5f\x3a\x0b\xec\x58\x9f\x6d\xb2\x39\x21\x1d\x2d\x09\xf6\x30\x48\x2d\x57\x35\xb2\xe8\xc9\x07\xd2\x62\x25\x7a\xd4\x9a\x14\xbc\xff\xdf\x7f\x40\x0e\xd8\x25\xb9\xef\xd1\x12\xbc\x2a\xe0\x08\xef\xe0\x08\x9f\x5e\xdf\xc0\x53\x4f\x8e\xe0\x1d\xa0\x6e\xe0\x13\xa0\xa3\x89\x34\x95\xbb\x26\x40\x06\x45\xe8\x19\x8a\xf5\x3a\x38\xa7\x33\x5f\x8f\x07\x9a\x30\xca\x60\x33\xc5\x6d\xc2\x11\x51\x77\xb1\xfd\x3f\xaf\x6f\x60\x7b\x7b\xfb\x25\xb2\x73\x4f\x1a\x74\x68\x33\x25\xbf\x52\x03\xa3\x97\xba\x83\x81\x50\xc3\xbf\xe0\xf3\x66\xfb\xe6\x06\xce\x8f\x64\xe0\xb9\x49\x9a\xfb\x20\x4c\x8f\x2f\x0b\x47\x2d\x39\xd2\x82\x7c\x68\xd1\xeb\xaf\xd8\x9d\x68\x43\xb3\xe6\xf0\x44\xb5\x97\x4c\xe1\x95\x58\x64\xd9\x39\xb9\xc1\xe3\xf3\xd9\x5a\x41\xcf\x6c\x7d\x99\xe7\x9d\xe4\x7e\xac\x33\x61\x86\xfc\xf7\xb6\x43\xd3\xa3\xc9\xa7\x1a\xaf\xbc\x6f\xf2\x5a\x99\x3a\x1f\xd0\x33\xb9\xfc\x20\xc3\xd0\xe4\x5a\xe7\x43\x2c\x92\x26\xce\xec\xe9\x9f\x13\x06\xf1\x85\xf1\xb0\xa9\xbc\x6f\x5e\xf2\x7a\x36\x0e\x3b\xca\x3a\x63\x3a\x45\x68\xa5\x8f\x3e\xe2\x99\xfc\x2a\x78\xb9\x50\xac\x0e\x9b\xe0\x6a\x35\xd8\xd5\xba\xba\x7b\x73\x9b\x59\xee\xbf\x61\x43\x77\x94\x87\xcc\xb8\x2e\xb7\x4d\x9b\x6f\xde\xac\x77\xd9\x7a\x77\x7f\xb7\xc9\x6c\xd3\x2e\x96\xa0\xa4\x20\xed\xe9\x59\xab\x2e\x26\x61\x09\xa3\x76\xe4\xd9\xc9\xd0\x2f\x8b\x25\x48\x6d\x47\x8e\x95\xb8\x62\x93\xac\x9c\x16\x44\x2b\x9d\xe7\x84\x03\x3e\x59\xfa\xce\xb6\x5b\x45\x45\x99\xda\x6c\x9a\xc1\x25\xcc\x26\xeb\x1c\xcb\x8c\x6b\x82\x3d\x1b\xbf\x00\x49\x8e\xe6\x4c\x16\xc3\xee\x64\x72\xb1\x7d\x62\x08\x57\xd1\x65\xe2\x01\x48\xd1\x40\x9a\xab\x14\x4b\xab\x0c\x72\xb1\x9d\xe9\x23\x73\xa5\xf0\x14\x56\xe5\x7a\xa6\x50\x78\x32\x23\x97\xf0\xf6\xdd\xa7\x99\x54\x18\x65\x5c\x15\x0e\x59\x86\x99\x9c\x69\x1a\x39\x90\x0e\xc5\xf7\x25\x7c\x2e\x6e\xc2\x98\xc5\xc7\x97\x19\x26\x0c\x49\xf9\xed\x8c\xcc\xf4\x5e\xa0\xa2\x32\xcc\xc7\xc2\x8c\x6c\x47\x3e\xa7\x3b\xe4\x20\x9e\x71\x4a\x59\xd2\x46\x65\x3a\x58\x6d\x46\xdd\x48\xdd\xd5\xe6\x38\x99\xe0\xf7\x52\x9d\xec\xae\x99\x5a\x7c\x37\xdb\x13\xea\xcc\x09\xb5\x39\xfe\x14\xe1\xac\x36\x97\xc3\xfd\x79\x8d\xfe\xba\x42\xd6\x99\x1a\x6b\xa9\x24\x4b\xf2\x2f\xeb\x54\xa3\x78\xec\x5c\x08\xb4\x92\xba\xa1\xe3\x5c\x77\x1c\xa4\x7e\x29\x3d\xcd\xa4\x9b\x19\x16\x8f\x67\xe9\x76\x86\xbd\x4a\x8b\x8b\xb4\x25\xe4\xd1\x91\xaf\x46\xa7\xca\xeb\x34\x17\x19\x0e\xf8\xd5\x68\x7c\x4a\x73\x1c\xc6\x9b\xb2\x78\xc7\xc4\xb1\x4c\x73\x9d\x33\x69\x6f\x5c\xab\xcc\xd3\x59\xd2\x10\x59\x85\xf5\xa1\xa8\x06\x7d\xd8\x56\x16\x43\x1b\x54\xf1\x33\xa0\xc2\xb1\xab\xb6\xeb\xcd\x7d\xb5\xde\x54\xdb\x7d\x9e\x74\xab\x83\x11\x2b\xa1\xd0\x7b\xf2\x19\x1f\xf9\x65\x64\xa2\x27\xf1\xe8\xc7\xa1\x84\xbd\xa0\x5d\xb1\xaf\x45\x5b\xef\x76\xa2\x58\xef\x68\xb7\xc7\x75\x4b\x1b\xa4\x62\x7f\xdf\xde\xed\x17\x31\x88\x30\x43\xe7\x9b\xfd\x7c\x29\x75\x0e\x6d\x1f\x17\xf8\x13\xc9\xae\x67\x0f\x8e\xbc\x19\x9d\xa0\x54\xbb\xa8\xaf\x2c\x72\xff\xf3\x49\x98\xd6\x67\x3e\xd4\x71\xaf\x65\x36\x9d\x41\xfa\x0a\x9d\xe8\xe5\x61\x76\xcd\xb7\xa8\x3c\xc1\x12\x64\x0b\x9e\xf8\x26\x5d\x3f\xa1\xf5\x6a\xf4\x14\x4a\x90\xae\xe3\xf0\x12\x2e\x2d\x0d\x13\xc3\xbc\xf3\x7a\x9a\x45\x3b\x3f\x52\x12\x44\xca\x86\xb4\x61\x0a\xef\x33\xcb\x56\x2a\x8a\x9f\x78\xfe\xdc\xf0\x2f\xb3\xf2\x24\xb9\x9f\x6e\xfa\xb9\xeb\xe4\xf0\x5a\x88\xfb\x42\xd0\xfd\x7e\xb3\xc6\x3b\xbc\x5d\x6f\x77\xb7\xe1\x5f\x51\x88\xe2\x76\xbd\x6f\xc5\x5d\xb3\x5d\x20\xb3\x93\xf5\xc8\xe9\x42\xa4\x23\x3b\x04\x4d\x1c\x3f\x0f\xaf\xba\xc8\xfd\x28\x75\x53\xc2\xdb\x87\x87\xe9\x70\xe1\x77\x08\x50\xd3\xe8\x50\x5d\xac\x5e\xbd\x7d\x78\xb8\x81\xf7\xe1\x91\x65\xd9\xeb\xb4\x2e\x42\x5b\x49\xdd\x55\x0d\x32\x7a\xe2\x12\xfe\x1f\xb6\x6a\xf8\x3a\x59\xc2\x24\xbb\x7c\x25\xc6\xa5\x3e\x19\x44\xeb\x01\xb5\x6c\xc9\x73\x85\x23\xf7\xc6\x95\x80\x75\x33\xaa\x66\xf1\x47\x00\x00\x00\xff\xff\x6d\xe6\x42\x79\x43\x0b\x00\x00"
+var _mobilenet_ssd_v1_0_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x56\xdd\x6e\xdb\xb8\x12\xbe\xf7\x53\x0c\xe0\x9b\x16\x88\x7e\x6c\x39\x69\x2c\xe0\x9c\x8b\xd3\x03\x6c\xf7\x62\x83\x45\x5b\xa0\x17\x45\x21\x8c\xa8\x91\xc4\x0d\x45\x72\x49\xca\xb1\xfb\xf4\x8b\xa1\x64\x5b\x69\xba\x8b\x16\x8b\x04\xb2\x34\xf3\xcd\xc7\xe1\xfc\x91\x1a\x07\x2a\xe1\x37\x53\x4b\x45\x0f\x14\xaa\x0f\x1f\xfe\x5f\x1d\x36\x69\x0e\x6b\x60\x15\x98\x16\x4e\x66\x74\x30\x98\x86\xd4\xaa\x75\x38\xd0\x93\x71\x8f\xe5\x0a\x00\x22\xa2\x84\xdf\x4f\x1f\x8d\x13\x3d\xac\xe1\xa2\x86\xd6\x38\x08\x3d\xcd\x66\x8c\x3d\x90\xf3\xd2\xe8\x12\x36\xe9\x7d\xba\x79\x06\x9e\x55\x20\x8c\x0e\x0e\xa5\x0e\xab\x05\x98\x3d\x39\x03\xa4\x6e\x8d\x1b\x30\x4c\xef\xe0\x69\x40\x1d\xa4\xb8\xe8\x27\xed\x8a\x79\x50\x6a\x72\x25\xac\xe1\xf2\xe1\x61\xf4\xd4\x40\x30\x60\xc9\x31\x72\x72\x0e\xac\xa3\x46\x0a\xe6\x8c\x7b\x5a\xc3\x30\xaa\x20\xad\x22\xb0\x0a\x03\x03\x3d\x08\xd4\x50\x13\x78\x4b\x42\xb6\x92\x9a\x88\xc4\xa1\xb9\xdb\x4d\x81\xe0\x3f\x61\xc7\x12\x1c\x4a\xeb\xcc\x1f\x24\x42\x26\xd0\x0d\x2a\xb1\xa7\xc0\xc1\x29\x23\x38\x11\x76\xbc\xe0\xbb\x1f\xc0\x77\x33\xde\x5a\x71\xb7\x53\xf4\xa3\x8b\xcd\xf0\xa4\xfb\xe1\xe5\x96\x16\x0d\x79\xe1\xa4\xe5\x88\x94\xf0\xdf\xb8\xe4\xc7\x5e\xfa\x39\x5c\xd2\x03\x82\x23\xab\xa4\x98\x12\x61\xda\x6b\xa6\x61\xb2\xad\xa9\x01\xa9\xa3\xf8\x52\x59\x60\xc7\xfa\x6c\x93\x2e\x09\xe9\x68\x49\x04\x0f\x83\xd4\x32\xa9\x31\x88\x9e\x3c\x97\x5d\x91\x88\x1e\xb5\x26\x05\xef\x7f\xf9\x1f\xc8\x01\xbb\x49\xee\x7b\xb4\x04\xaf\x0a\x38\xc2\x3b\x38\xc2\xa7\xd7\x37\xf0\xd4\x93\x23\x78\x07\xa8\x1b\xf8\x04\xe8\x68\x26\x9d\xd2\x5d\x13\x60\x00\x45\xe8\x03\x14\x79\xce\x8b\xd3\x99\xaf\xc7\x03\xcd\x18\x65\xb0\x99\xfd\x36\xbc\x45\xd4\x1d\xf1\x7a\x9f\xf3\x1b\xd8\xde\xde\x7e\x89\xec\xa1\x27\x0d\x9a\xcb\x4c\xc9\xaf\xd4\xc0\xe8\xa5\xee\x60\x20\xd4\xf0\x1f\xf8\xbc\xd9\xbe\xb9\x81\xf3\x63\x32\xf0\xa1\x99\x34\xf7\xac\x99\x1e\x5f\x56\x8e\x5a\x72\xa4\x05\x79\x2e\xd1\xeb\x17\xbb\x62\xd1\x72\xb1\x66\xf0\x44\xb5\x97\x81\xf8\x95\x82\x48\xd3\x73\x70\x79\xc5\xe7\xbd\x95\x40\x1f\x82\xf5\x65\x96\x75\x32\xf4\x63\x9d\x0a\x33\x64\x7f\xb6\x1d\x9a\x1e\x4d\x36\xe7\x38\xf1\xbe\xc9\x6a\x65\xea\x6c\x40\x1f\xc8\x65\x07\xc9\x4d\x93\x69\x9d\x0d\x31\x49\x9a\x42\x6a\x4f\xff\x9e\x90\xd7\xb9\x30\x1e\x36\x95\xf7\xcd\x4b\x5e\x1f\x8c\xc3\x8e\xd2\xce\x98\x4e\x11\x5a\xe9\xa3\xd3\x71\x4f\x3e\x61\xb7\x2f\x14\xc9\x61\xc3\x4b\x25\x83\x4d\xf2\xea\xee\xcd\x6d\x6a\x43\xff\x0d\x1b\xba\xa3\x3c\xa4\xc6\x75\x99\x6d\xda\x6c\xf3\x26\xdf\xa5\xf9\xee\xfe\x6e\x93\xda\xa6\x5d\xad\x41\x49\x41\xda\xd3\xb3\x52\x5d\xcd\xc2\x12\x46\xed\xc8\x07\x27\xb9\x5e\x56\x6b\x90\xda\x8e\x21\x66\xe2\x8a\x9d\x64\xe5\x3c\x20\x5a\xe9\x7c\x98\x70\x10\x4e\x96\xbe\x33\xed\x92\xa8\x28\xa7\x32\x9b\x7b\x70\x3d\x27\xd0\x2e\xdb\x66\xc1\x35\xc3\x9e\xb5\x1f\xbb\x10\x95\xcf\x98\x2c\xf2\xec\x0c\xe4\x62\xf9\xf0\x4a\x0b\xd1\x8c\xe1\x7f\x52\x34\x90\x0e\x15\x23\x4a\x68\x95\xc1\x50\x6c\x17\xfa\xc8\x5c\x29\x3c\xf1\xa8\xcc\x17\x0a\x85\x27\x33\x86\x12\xde\xbe\xfb\xb4\x90\x0a\xa3\x8c\xab\x38\x22\x25\xf7\xe4\x42\xd3\xc8\x81\x34\x57\x93\x2f\xe1\x73\x71\xc3\x6d\x16\x1f\x5f\x16\x18\x6e\x92\xf2\xdb\x1e\x59\xe8\xbd\x40\x45\x25\xf7\xc7\xca\x8c\xc1\x8e\xe1\x1c\x6e\x8e\x01\xef\xe0\x1c\xb2\x49\x1b\x95\x2c\x2e\xa1\x36\xa3\x6e\xa4\xee\x6a\x73\x9c\x4d\xf0\x7b\xa1\x9e\xec\xae\x91\x5a\x7d\x37\xda\x33\xea\xcc\x09\xb5\x39\xfe\x14\xe1\xe5\x6b\x2e\x97\x79\x0f\x7f\x93\xa3\x7f\xce\x90\x75\xa6\xc6\x5a\x2a\x19\x24\xf9\x97\x79\xaa\xcd\xf1\x2a\xde\x5c\xc5\x28\x1e\x3b\xc7\xfe\x57\x52\x37\x74\x5c\x9a\x1c\x07\xa9\x5f\x4a\x4f\x0b\xe9\x95\xe7\x38\xe0\xf1\x2c\xbd\xfa\x74\x5a\x48\x8b\x8b\xd4\x0b\xe3\xa8\x0a\xbd\x23\xdf\x1b\xd5\x94\x90\xa7\xf9\x95\x49\x0f\xfe\xb9\x6e\x77\x7b\xd1\x31\x5d\x43\x81\xe2\x09\xec\x4b\xd8\xe6\x57\xbf\x5a\xc2\x30\x3a\xf2\xd5\xe8\x54\x79\x1d\x1d\x45\x8a\x03\x7e\x35\x1a\x9f\xa6\xa1\xc1\xb3\x84\xd2\x78\xa0\xc5\x19\xc0\x15\xaa\x7c\x16\x48\x7b\xe3\x5a\x65\x9e\xce\x92\x86\xc8\x2a\xac\x0f\x45\x35\xe8\xc3\xb6\xb2\xc8\x35\x57\xc5\x3b\x47\x85\x63\x57\x6d\xf3\xcd\x7d\x95\x6f\xaa\xed\x3e\x9b\x74\xc9\xc1\x88\x44\x28\xf4\x9e\x7c\x1a\x8e\xe1\xa5\x67\xa2\x27\xf1\xe8\xc7\xa1\x84\xbd\xa0\x5d\xb1\xaf\x45\x5b\xef\x76\xa2\xc8\x77\xb4\xdb\x63\xde\xd2\x06\xa9\xd8\xdf\xb7\x77\xfb\x55\x74\x82\x1b\xf6\x7c\x8d\x38\x9f\x80\x9d\x43\xdb\xc7\xd3\xe2\x89\x64\xd7\x07\x0f\x8e\xbc\x19\x9d\x20\x1f\x17\x8c\xfa\xca\x62\xe8\x7f\x3e\x08\xf3\xac\xce\x86\x3a\x0e\xd1\xd4\x4e\x7b\x90\xbe\x42\x27\x7a\x79\x58\xdc\x29\x5a\x54\x9e\x60\x0d\xb2\x05\x4f\xe1\x86\xc7\xd3\x74\x86\xd7\xe8\x89\x53\x30\x9d\xfd\xfc\x12\x0c\xa0\x86\x99\xe1\x42\x30\xb5\xea\xd5\xdb\xe5\x96\xa2\xfb\xac\xd7\xd0\x90\x36\x81\xf8\x7d\x61\xd9\x4a\x45\xf1\x3e\xe9\xcf\xdd\xf5\x32\x2a\x4f\x32\xf4\xf3\xb5\x62\xb9\x74\x04\x2e\x12\x71\x5f\x08\xba\xdf\x6f\x72\xbc\xc3\xdb\x7c\xbb\xbb\xe5\x9f\xa2\x10\xc5\x6d\xbe\x6f\xc5\x5d\xb3\x5d\x61\x08\x4e\xd6\x63\x98\x4e\x5f\x3a\x06\x87\xa0\x29\xc4\xbb\xe8\x55\x17\x9d\x7b\x94\xba\x29\xe1\xed\xc3\xc3\x3c\x87\xf8\x9b\x1d\xd4\x34\x3a\x54\x17\xab\x57\x6f\x1f\x1e\x6e\xe0\x3d\x3f\xd2\x34\x7d\x1d\x4d\x63\x59\x49\xdd\x55\x0d\x06\xf4\x14\x4a\xf8\x95\x47\x38\x5f\x85\xd6\x30\xcb\x2e\x57\xd2\x78\x82\xcc\x06\xd1\x7a\x40\x2d\x5b\xf2\xa1\xc2\x31\xf4\xc6\x95\x80\x75\x33\xaa\x66\xf5\xd7\x00\x47\xeb\x47\xe6\xb0\x0b\x00\x00"
 
 func mobilenet_ssd_v1_0_yml() ([]byte, error) {
 	return bindata_read(
@@ -260,7 +260,7 @@ func se_resnext_50_32x4d_yml() ([]byte, error) {
 	)
 }
 
-var _srgan_v1_0_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x55\x41\x6f\xe3\x36\x13\xbd\xeb\x57\x0c\xe0\xcb\xf7\x01\x89\x24\x3b\x5e\x6f\xa2\x43\x81\x36\x87\xf4\xe4\x16\xe9\xa2\x3d\x14\x85\x30\xa6\x86\x12\x1b\x89\x24\xc8\x61\xbc\xee\xaf\x2f\x48\xca\xb6\x92\xa6\xe8\xfa\x60\x50\xf3\xde\x0c\x87\x6f\x66\x48\x8d\x13\x35\xf0\xcb\xf3\xd3\xf7\xfb\xf6\x75\x5d\xd6\xb0\x82\x68\x02\x23\xe1\x64\x82\x83\xc9\x74\x34\x16\xd2\xe1\x44\x47\xe3\x5e\x9a\x02\x00\x20\x3b\xfd\x7c\xfa\x62\x9c\x18\x60\x05\x17\x18\xa4\x71\xc0\x03\xcd\x6e\x91\xfb\x4a\xce\x2b\xa3\x1b\x58\x97\xf7\xe5\xfa\x0d\x79\x86\x40\x18\xed\xd9\xa1\xd2\x5c\x2c\xd8\x31\x95\x33\x43\x69\x69\xdc\x84\x9c\xd7\xe0\x69\x42\xcd\x4a\x5c\xf0\x8c\x16\xc2\x68\x46\xa5\xc9\x35\xb0\x82\xcb\x87\x87\xe0\xa9\x03\x36\x60\xc9\x45\x66\xce\x0e\xac\xa3\x4e\x89\x18\x33\x25\xba\x82\x29\x8c\xac\xec\x48\x60\x47\xe4\x48\xf4\x20\x50\xc3\x81\xc0\x5b\x12\x4a\x2a\xea\x12\x13\xa7\x6e\xb7\xcd\x4a\xc4\x9f\xb0\xa1\x01\x87\xca\x3a\xf3\x27\x09\xae\x04\xba\x69\xbc\xb5\x27\x8e\xea\x34\x89\x7c\x2b\x6c\xb8\xf0\xfb\x6f\xe0\xf7\x33\xdf\x5a\xb1\xdb\x8e\xf4\xad\x9b\xcd\xf4\x8b\xfb\x7f\x6f\xb7\xf4\xe8\xc8\x0b\xa7\x2c\xa7\x02\x7c\x97\x02\x7c\x19\x94\x9f\xe5\x52\x1e\x10\x1c\xd9\x51\x89\x5c\x08\x23\xaf\xa5\x86\xec\x7b\xa0\x2e\xd6\x27\x9a\x53\x4b\x81\x0d\x87\x33\xbf\x2c\x1c\x49\x72\xa4\x05\xf9\x58\x9f\xeb\x57\x2a\x0d\xda\x58\xa9\x0a\x8e\x74\xf0\x8a\x29\x2e\x89\x45\x59\x9e\x23\x2b\xdd\xbf\xeb\xac\x5b\x18\x98\xad\x6f\xaa\xaa\x57\x3c\x84\x43\x29\xcc\x54\x8d\x24\x99\x07\x33\xa1\xaf\x52\x06\xef\x98\xe8\xbe\xaa\xd7\xd2\xb8\xbe\xb2\x9d\xac\xd6\xbb\xfa\xa1\xac\xb7\xf7\xf5\xa6\xb4\x9d\x2c\x56\x30\x2a\x41\xda\xd3\x9b\xa3\x15\xb3\xb1\x81\xa0\x1d\x79\x76\x4a\x30\x75\xc5\x0a\x94\xb6\x81\x53\xf2\x57\x6e\xb6\x35\x73\x43\x49\xe5\x3c\x67\x1e\xf0\xc9\xd2\x07\xe3\x71\x9b\x80\x06\xd4\x84\x3d\xcd\x35\x5b\xc1\xa2\x12\xe7\x5c\x16\xb1\x66\xda\x9b\x72\x45\x4a\xde\x68\x19\xc9\x62\x1c\x36\x26\x97\x14\x4f\x29\x5c\x4d\x97\x0e\x01\xa0\x91\x26\xd2\xdc\xe6\x5c\xe4\x68\x90\xef\x36\x0b\x3c\x45\x6e\x47\x3c\xc5\xd1\xaa\x17\xc0\x88\x27\x13\xb8\x81\xc7\x1f\x7f\x5b\x58\x85\x19\x8d\x6b\xe3\x21\x1b\x78\x7e\xfa\x61\x81\x4c\x84\xba\x81\xdf\xeb\xb2\xbe\x81\xf3\xdf\x1f\x0b\xdc\x0b\x1c\xa9\x81\xcd\xa7\x4f\x85\x09\x6c\x03\x9f\xa5\x8c\xe7\x4b\xf9\xcf\x72\x64\x34\x81\xef\x05\x5c\x01\x7e\x24\x60\xf6\xb8\x9e\xbf\xf8\x50\xc3\x99\x75\x8d\xb6\x90\xf0\x92\xe7\xbf\x4b\xf9\x56\x48\xa5\x79\xb7\x2d\x52\xb1\xa3\xfe\xe7\x5b\xe4\x3c\x51\xbd\x43\x3b\x00\xea\x0e\x8e\xa4\xfa\x81\x3d\x38\xf2\x26\x38\x41\x39\x60\xc2\x5b\x8b\x3c\x34\x97\x06\xf6\x77\x25\x4e\xf8\x97\xd1\x78\xf4\xa9\xe1\x3d\x1b\x47\x65\x1a\xea\xd4\xd7\x29\xb4\xaf\xe6\xf9\xae\xbc\xeb\x51\xb7\x9a\xf8\xa9\x25\x6b\xc4\xd0\x6e\xdb\x75\x5d\x97\x36\x4b\xa7\x7c\x8b\x4e\x0c\xea\x75\x71\xc3\x48\x1c\x3d\xc1\x0a\x94\x04\x4f\x7c\x13\x55\xc9\x13\x7d\x40\x4f\x6d\x70\xf3\x4d\x10\x17\x6c\x00\x35\xcc\x11\x96\xea\x0c\xb4\x48\x7e\x79\xc2\x6c\x48\x21\x3b\xd2\x86\x29\xae\x17\x9e\x52\x8d\x94\x9e\x17\x7f\x2e\xdb\x3f\x45\x3a\x2a\x1e\xe6\x4b\x66\xb9\x75\xde\x50\x0c\x24\x5e\x7c\x98\x1a\xd8\x10\xde\xdf\xc9\x1d\x7d\xde\x6e\x37\xb5\xdc\xd5\x0f\xf5\x86\xea\xcf\x5b\x79\xa8\xe9\xfe\xe1\xae\x93\x05\x32\x3b\x75\x08\x9c\xaf\x23\xfa\xca\x0e\x41\x13\xa7\xa7\xe9\x8a\xa5\xd8\x2f\x4a\x77\x0d\x3c\xee\xf7\xf3\xe1\xe2\x77\x4c\x50\x53\x70\x38\x5e\xbc\xfe\xf7\xb8\xdf\xdf\xc0\x73\xfc\x2b\xcb\xf2\xff\xb9\x3b\xe3\xc3\xa6\x74\xdf\x76\xc8\xe8\x89\x1b\xf8\xf5\xa7\xc7\x84\x4c\xa8\x95\x24\xcf\x2d\x06\x1e\x8c\x6b\x00\x0f\x5d\x18\xbb\xe2\xef\x00\x00\x00\xff\xff\xc0\x36\x0a\x10\x93\x07\x00\x00"
+var _srgan_v1_0_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x55\x4d\x6f\xe3\x36\x10\xbd\xeb\x57\x0c\xe0\x4b\x0b\xc4\xb2\xec\x78\xb3\x59\x1e\x0a\xb4\x39\xa4\xa7\xb4\x48\x17\xed\x61\xb1\x10\xc6\xd4\x50\x62\x43\x91\x04\x39\x8a\xd7\xfb\xeb\x0b\x52\xb2\xa5\xa4\x69\x11\x24\x16\x28\xbe\xc7\xe1\xcc\x9b\x0f\x59\xec\x49\xc0\x1f\x8f\xf7\x3f\x3f\xd4\xcf\xdb\xb2\x82\x15\xa4\x2d\x70\x0a\x4e\x6e\x08\xd0\xbb\x86\x4c\xa1\x02\xf6\x74\x74\xe1\x49\x14\x00\x90\x19\x02\x7e\x3f\x7d\x76\x41\x76\xb0\x82\x0b\x0c\xca\x05\xe0\x8e\xa6\x63\x89\xfb\x4c\x21\x6a\x67\x05\x6c\xcb\xdb\x72\xfb\x82\x3c\x41\x20\x9d\x8d\x1c\x50\x5b\x2e\x16\xec\xe4\xca\x99\xa1\xad\x72\xa1\x47\x1e\xd7\x10\xa9\x47\xcb\x5a\x5e\xf0\x11\x2d\xa4\xb3\x8c\xda\x52\x10\xb0\x82\xcb\x4b\x84\x21\x52\x03\xec\xc0\x53\x48\xcc\xd1\x3b\xf0\x81\x1a\x2d\x93\xcd\x1c\xd4\x0a\xfa\xc1\xb0\xf6\x86\xc0\x1b\xe4\x44\x8c\x20\xd1\xc2\x81\x20\x7a\x92\x5a\x69\x6a\x32\x13\xfb\xe6\x66\x3f\x2a\x91\xfe\xa4\x1f\x04\x04\xd4\x3e\xb8\xbf\x49\xf2\x46\x62\xe8\xcd\xda\x9f\x38\xa9\x23\x32\x79\x2d\xfd\x70\xe1\xb7\xef\xe0\xb7\x13\xdf\x7b\x79\xb3\x37\xf4\xde\xcb\x26\xfa\xba\x7d\xf7\x75\xcb\x13\x0d\x45\x19\xb4\x4f\x8a\x08\xf8\x29\x5f\xf9\xb9\xd3\x71\x92\x4b\x47\x40\x08\xe4\x8d\x96\x63\x22\x9c\x9a\x53\x0d\xe3\xd9\x03\x35\xa0\x6d\xde\xce\x25\x05\x7e\x38\x9c\xf9\x65\x11\x48\x51\x20\x2b\x29\xa6\xfc\xcc\x6f\x39\x35\xe8\x53\xa6\x36\x70\xa4\x43\xd4\x4c\x69\x49\x2c\xcb\xf2\x6c\x59\xdb\xf6\x55\x65\xad\xa1\x63\xf6\x51\x6c\x36\xad\xe6\x6e\x38\x94\xd2\xf5\x1b\x43\x8a\xb9\x73\x3d\xc6\x4d\xf6\xe0\x15\x13\xc3\x37\xfd\x5c\xba\xd0\x6e\x7c\xa3\x36\xdb\x9b\xea\x53\x59\xed\x6f\xab\x5d\xe9\x1b\x55\xac\xc0\x68\x49\x36\xd2\x8b\xd0\x8a\x69\x53\xc0\x60\x03\x45\x0e\x5a\x32\x35\xc5\x0a\xb4\xf5\x03\x67\xe7\x67\xee\xb8\x27\xa6\x82\x52\x3a\x44\x1e\x79\xc0\x27\x4f\x6f\xb4\xc7\x3a\x03\x02\x74\x8f\x2d\x4d\x39\x5b\x4d\x31\xfb\xa5\xcc\x0b\x5b\x13\xed\x45\xba\x92\x0b\x19\x7c\x61\xc9\x63\x6a\x36\xa6\x90\x15\x4f\x37\x2d\xb6\x26\x4e\xfa\x27\x43\x3d\x59\xae\x13\x43\x80\x32\x0e\xf9\x7a\xb7\xc0\xb3\xe5\xda\xe0\x29\xb5\x56\xb5\x00\x0c\x9e\xdc\xc0\x02\xee\x7e\xfd\x6b\xb1\x2b\x9d\x71\xa1\x4e\x8a\x08\x78\xbc\xff\x65\x81\xf4\x84\x56\xc0\x97\xaa\xac\xae\xe0\xfc\xf8\xba\xc0\xa3\x44\x43\x02\x76\x1f\x3e\x14\x6e\x60\x3f\xf0\x59\xca\x14\x5f\xf2\xee\x2c\xc7\x88\x66\xf0\xb5\x80\x2b\xc0\xb7\x04\x1c\x4f\xcc\xf1\x17\x6f\x6a\x38\xb1\x66\x6b\xb3\x5e\x73\x13\xfe\xb7\x94\xff\x2f\x64\xa3\x7b\xb2\x69\x60\x45\x01\x5f\xae\xaf\x60\xbd\x4d\xbf\xaf\xb0\x5a\x5e\x3d\x93\xe0\xa8\xb9\x73\xa9\x76\x3a\x82\x03\xb2\xec\x12\x3d\x17\x11\xda\x13\x44\xfd\x9d\x8a\x24\xb2\x49\xc9\x3d\x8f\xa8\x73\xbb\xb6\x01\x7d\x07\x68\x1b\x38\x92\x6e\x3b\x8e\x10\x28\xba\x21\x48\x1a\xbd\xcd\x78\xed\x91\x3b\x71\xe9\x8e\x78\x5d\x62\x8f\xdf\x9d\xc5\x63\xcc\xdd\x14\xd9\x05\x2a\xf3\xc4\xc8\x4d\x93\x4d\xc7\xcd\x34\x3c\x36\x31\xb4\x68\x6b\x4b\x7c\x5f\x93\x77\xb2\xab\xf7\xf5\xb6\xaa\x4a\xcf\xf9\x06\x1d\x6b\x0c\xb2\xd3\xcf\x8b\xf1\xa5\xd0\x44\x82\x15\x68\x05\x91\xf8\x2a\x45\x66\xa7\xf0\x22\xd5\x43\x98\xc6\x4c\x5a\xb0\x03\xb4\x30\x59\xb8\x18\x18\xa5\x9a\x9d\x5f\x46\x98\xa3\x49\xb8\x85\x86\xac\x63\x4a\xeb\xc5\x49\xa5\x0d\xe5\x6f\x57\x3c\xd7\xc4\xbf\x45\x4a\x92\x4f\x13\x6c\x79\x75\x26\xd6\xb2\x23\xf9\x14\x87\x5e\xc0\x8e\xf0\xf6\x5a\xdd\xd0\xc7\xfd\x7e\x57\xa9\x9b\xea\x53\xb5\xa3\xea\xe3\x5e\x1d\x2a\xba\xfd\x74\xdd\xa8\x02\x99\x83\x3e\x0c\x3c\xce\x3a\xfa\xc6\x01\xc1\x12\xe7\xef\xde\x8c\x65\xe7\x9e\xb4\x6d\x04\xdc\x3d\x3c\x4c\x75\x90\xde\x93\x83\x96\x86\x80\xe6\x72\xea\x87\xbb\x87\x87\x2b\x78\x4c\x8f\xb2\x2c\x7f\xcc\x47\xf3\x57\x53\xdb\xb6\x6e\x90\x31\x12\x0b\xf8\xf3\xb7\xbb\x8c\xf4\x68\xb5\xa2\xc8\x35\x0e\xdc\xb9\x20\x00\x0f\xcd\x60\x9a\xe2\x9f\x01\x00\x46\xcf\xf3\xeb\xf0\x07\x00\x00"
 
 func srgan_v1_0_yml() ([]byte, error) {
 	return bindata_read(
@@ -278,7 +278,7 @@ func torchvision_alexnet_yml() ([]byte, error) {
 	)
 }
 
-var _torchvision_deeplabv3_resnet101_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x56\x5b\x6f\xe3\xc6\x0e\x7e\xf7\xaf\x20\xe0\x97\x73\x0e\x62\xdd\x6c\xf9\x22\xe0\xf4\xa1\x29\xd0\x7d\x28\xb2\x8b\xed\xa2\x45\x11\x04\x02\x35\xa2\xa4\x69\xa4\x19\x61\x86\x72\xd6\xfb\xeb\x8b\x19\xc9\xb2\xb2\x9b\xa2\xdb\x3c\xc4\x12\xf9\xf1\x32\xe4\x47\x8e\x14\x76\x94\xc1\x27\x6d\x44\xf3\x9b\xb4\x52\xab\xfc\x27\xa2\xfe\x17\x2c\xce\xdb\xfc\x23\x59\x45\x1c\x47\x31\xac\xc1\xe1\x40\x57\x70\xd1\x83\x81\x4e\x97\xd4\xae\x2a\x83\x1d\xbd\x68\xf3\x9c\xad\x00\x00\x46\x4f\x1f\x2e\xde\x17\xac\x61\x56\x43\xa5\x0d\x70\x43\x93\x99\xc3\x9e\xc9\xb8\x58\x19\xc4\xc1\x31\x88\x5f\x81\x27\x15\x08\xad\x2c\x1b\x94\x8a\x57\x0b\x74\x04\xeb\x19\x21\x55\xa5\x4d\x87\x3c\x3e\x83\xa5\x0e\x15\x4b\x31\xeb\x47\xed\x4a\x68\xc5\x28\x15\x99\x0c\xd6\x30\xbf\x58\x18\x2c\x95\xc0\x1a\x7a\x32\x0e\x39\x66\x07\xbd\xa1\x52\x0a\xe7\xd3\x27\xba\x86\x6e\x68\x59\xf6\x2d\x41\xdf\x22\x3b\xa0\x05\x81\x0a\x0a\x02\xdb\x93\x90\x95\xa4\xd2\x23\xb1\x2b\xf7\xbb\xb1\x12\xee\x4f\xf4\x43\x06\x06\x65\x6f\xf4\x9f\x24\x38\x14\x68\xba\x76\xd3\x5f\xd8\x55\x27\xf3\xe0\x8d\xe8\x87\x19\x5f\x7f\x07\xbe\x9e\xf0\x7d\x2f\xf6\xbb\x96\xbe\x37\xd8\x04\x9f\xcd\xff\x39\xdc\xd2\xa2\x24\x2b\x8c\xec\xd9\x37\xe0\x07\xef\xe0\x53\x43\x20\x3b\xac\xc9\x42\x83\x67\x72\x45\x2c\x08\x5a\x8d\x25\x95\xae\x13\xac\x01\xc1\xa0\xaa\x3d\x63\x1e\xa3\x3b\x88\x9f\x00\x55\xe9\x48\xa0\x40\xb9\xb6\xb4\xf2\x0b\x95\x30\x58\xa9\x6a\xe8\x08\x15\xfc\x1f\x1e\xa3\x60\x77\x4c\xef\x20\x0a\x76\xe9\xde\xff\x44\xfb\xd1\xcc\x72\x39\xea\x93\xe4\xe4\x14\x49\xb2\x1b\x7f\xd2\xa7\x60\x65\xa8\x22\x43\x4a\x90\x75\xfd\xbd\xbd\xf9\xd6\x62\xef\x3a\x1d\xc2\x0b\x15\x56\x32\xb9\x47\x62\x11\x04\x30\x9e\xaa\x70\xe1\x5f\x33\x73\x03\x0d\x73\x6f\xb3\x30\x44\xf3\x59\x9e\x03\x6d\xea\xb0\x2f\xab\x30\x3e\x44\xfb\x20\x4a\xd3\xe3\x21\xe8\xcb\xea\x2b\x68\x2d\xb9\x19\x8a\x40\xe8\x2e\x9c\x4a\x18\x9e\xfd\x30\x85\x45\xab\x8b\xb0\x43\xcb\x64\x42\xaf\x98\xe4\x3e\xa0\x0d\x2d\xd5\x1d\x29\xf6\x1c\x0e\x4b\xa2\xbe\x75\x83\x17\xf4\x97\xaf\x02\x4c\x5e\x7d\x36\xa5\x16\x36\xb4\x8c\x45\x4b\x6f\xb8\x0c\x1a\xee\xda\xd5\x1a\x5a\x29\x48\x59\xdf\x80\xdb\x01\x27\x61\x06\x83\x32\x64\xd9\x48\xc1\x54\xae\xd6\x20\x55\x3f\xb0\xaf\xd8\x0d\x3b\xca\xb2\x69\x0a\x2a\x69\x2c\x8f\x38\xe0\x4b\x4f\x6f\xcc\xf4\xc6\x2b\xb2\x91\x19\x13\xd1\xd6\xb0\xa0\xcf\x35\x97\x85\xaf\x09\xf6\x8a\x63\x0e\x32\x06\x5a\x7a\xea\xd1\x6d\x08\x26\xe3\xdb\xec\x53\xb8\x89\x66\x5a\x03\x50\x4b\xae\xa2\xf9\x98\x4b\xd5\x6a\xe4\x6d\xb2\xd0\x7b\xcf\x79\x8b\x17\xb7\x0f\xa2\x85\xa2\xc5\x8b\x1e\x38\x83\xfb\x77\xbf\x2f\xa4\x42\xb7\xda\xe4\xee\x90\x19\x7c\xfc\xf9\xc7\x85\xc6\xb1\x36\x83\xc7\x38\xd9\x06\xfb\x43\x7a\x07\x71\xbc\x0f\x92\xa3\xe3\x7a\xb4\x0d\xd2\x6d\xf4\x04\xeb\xbf\xa1\xf4\xff\x20\x49\xd3\x85\x27\x2b\xb0\xa5\x0c\x1e\xd3\x63\xb0\x3d\xa5\x77\x90\x1e\x82\x38\x89\xfc\xef\xf6\x90\x3e\xf9\x32\xbe\x22\x7f\x30\x91\x7f\xf2\xa4\x07\xee\x07\x76\x9d\x1a\x0f\x7d\x5d\x84\x13\xb9\x56\x6f\xd4\x77\x34\xb9\xad\xcc\x09\x0a\x6b\xc0\xb7\x3a\x36\xc1\xe7\x82\xaf\x5e\xf5\x63\xf5\x6d\xdd\xa5\xe2\xfd\xce\xcb\x3b\xb4\xcf\xf6\x5a\xef\x5f\xa7\x78\x1f\xe6\x25\x3b\xb6\xae\x22\xe4\xc1\x90\xcd\x07\xd3\x66\x33\xe9\xed\x36\xc0\x0e\xbf\x68\x85\x2f\xd6\xcf\x96\x65\x6d\x28\xf0\xab\xca\x4f\xc2\x34\x44\x4c\xca\x6a\x53\xb5\xfa\xe5\x2a\x99\x27\x29\xef\xd4\x39\xc9\x7b\x74\x25\xce\xfd\x6d\x92\xe3\x50\xe7\x49\x14\x1f\xf3\x28\xce\x93\x53\x38\xea\x36\x67\x2d\x36\xa2\x45\x6b\xc9\x06\xfc\x99\x5f\x67\x25\x1a\x12\xcf\x76\xe8\x32\x38\x09\xda\x6d\x4f\x85\xa8\x8a\xdd\x4e\x6c\xa3\x1d\xed\x4e\x18\x55\x14\x23\x6d\x4f\xc7\x6a\x7f\x5a\xf9\x04\x1c\x43\xaf\x97\x83\x9d\xee\x95\xda\x60\xdf\xf8\x6d\xf6\x42\xb2\x6e\xd8\x82\x21\xab\x07\x23\x68\x2c\x81\xd7\xe7\x3d\x72\xf3\xef\x0b\x70\xdd\x39\xb7\x63\x9b\xeb\xcd\x1d\xf4\xe3\x59\xa4\xcd\xd1\x88\x46\x9e\x17\xb7\x46\x85\xad\x25\x58\x83\xac\xc0\x12\xdf\x8d\xdb\xd9\xb5\xbb\x40\x4b\xae\x15\x20\x2d\x20\xb8\x07\xb7\xd1\x15\x4c\x1e\x66\x07\x6b\x8f\xbe\x65\xbe\x3c\xde\x28\xf0\x2e\x4b\x52\x9a\xc9\x3d\x2f\x2c\x2b\xd9\x92\xff\x64\xb0\x57\x92\x7d\x5b\xa1\x17\xc9\x8d\x1c\x53\x5a\x86\x1e\x03\xde\x9a\x72\xc4\x52\xec\x62\x4c\x0e\xd1\x3e\x2a\x2a\x2c\x2b\xda\x57\xa2\xa4\x28\x29\xab\x2a\x3d\x61\xb4\x42\x66\x23\x8b\x81\xc7\x2b\x82\x3e\xb3\x41\x50\xc4\xfe\x73\xe3\xa6\xf3\xbe\x9f\xa5\x2a\x33\xb8\x7f\x78\x98\x0e\xe7\xde\x5d\x82\x8a\x06\x83\xed\x6c\xf5\x9f\xfb\x87\x87\x3b\xf8\xe8\xfe\x05\x41\xf0\x5f\x6f\xea\xe9\x25\x55\x9d\x97\xc8\x68\xc9\xad\x92\xf7\xf7\xef\x21\x89\xe2\x83\xdb\x83\xa3\x70\xfe\xea\xf0\xfb\x73\xb2\x98\x06\x45\xc9\x8a\x2c\xe7\x38\x70\xa3\x4d\x06\x7f\x90\xda\xbc\xb3\x12\x55\x0d\xf7\x0d\xaa\x7a\xf5\x57\x00\x00\x00\xff\xff\xc9\x3e\x94\x03\xab\x09\x00\x00"
+var _torchvision_deeplabv3_resnet101_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x56\x5b\x8f\xe3\x36\x0f\x7d\xcf\xaf\x20\x90\x97\xdd\x0f\x89\x6f\xb9\x4c\x62\xe0\xeb\x43\xa7\x40\xf7\xa1\x98\x2d\x16\x8b\x16\xc5\x60\x60\x30\x32\x1d\xab\x63\x4b\x82\x24\x27\x93\xfd\xf5\x05\x65\x25\xe3\xd9\x6e\x8b\x2d\xe6\x92\x58\x3c\x22\x29\xf2\x1c\xca\x0a\x7b\x2a\xe1\xb3\xb6\xa2\xfd\x4d\x3a\xa9\x55\xf5\x13\x91\xf9\x05\x0f\xa7\x55\xf5\x89\x9c\x22\x9f\x67\x39\xcc\x81\x71\xa0\x1b\xb8\xe8\xc1\x42\xaf\x6b\xea\x66\x8d\xc5\x9e\xce\xda\x3e\x97\x33\x00\x08\x88\x12\x7e\xbd\x04\x5f\x30\x87\x9b\x19\x1a\x6d\xc1\xb7\x14\xb7\x31\xf6\x44\x96\x63\x95\x90\x27\xbb\x24\x7f\x03\x8e\x26\x10\x5a\x39\x6f\x51\x2a\x3f\x9b\xa0\x33\x98\xdf\x10\x52\x35\xda\xf6\xe8\x19\x2d\x15\x38\xea\x51\x79\x29\x6e\xf6\xd1\x3a\x13\x5a\x79\x94\x8a\x6c\x09\x73\xb8\x3d\x38\x18\x1c\xd5\xe0\x35\x18\xb2\x8c\x1c\xb3\x03\x63\xa9\x96\x82\x7d\x86\x43\xcd\xa1\x1f\x3a\x2f\x4d\x47\x60\x3a\xf4\x0c\x74\x20\x50\xc1\x81\xc0\x19\x12\xb2\x91\x54\x07\x24\xf6\xf5\x76\x3d\x56\x82\x7f\x84\x19\x4a\xb0\x28\x8d\xd5\x7f\x92\xf0\xa9\x40\xdb\x77\x4b\x73\xf1\x5c\x9d\x32\x80\x97\xc2\x0c\x37\xfc\xf1\x3b\xf0\xc7\x88\x37\x46\x6c\xd7\x1d\x7d\x6f\xb0\x08\x5f\x1e\xbf\x3b\xdc\x74\x47\x4d\x4e\x58\x69\xb8\x22\x25\xfc\x10\x42\x7e\x6e\x09\x64\x8f\x47\x72\xd0\xe2\x89\xb8\x88\x07\x82\x4e\x63\x4d\x35\x48\xc5\xcf\x08\x16\xd5\x31\x30\xe6\x31\x5b\x40\xfe\x04\xa8\x6a\x26\x81\x02\xc5\x6d\xe9\xe4\x17\xaa\x61\x70\x52\x1d\xa1\x27\x54\xf0\x7f\x78\xcc\x92\xf5\x6e\xb3\x80\x2c\x59\x6f\xb6\xe1\x23\xdb\x8e\xdb\x9c\xaf\x47\x7b\x51\xec\xd9\x50\x14\xeb\xf1\x63\xf3\x94\xdc\x12\x0a\x99\x9f\x02\x85\x63\x2b\x2d\xf9\xc1\x2a\x07\x08\xdc\xd1\x05\x9c\x5b\x29\x5a\xce\xe1\x46\xd2\x83\x54\x35\x67\x20\x50\x29\xed\xc1\x12\xd6\x65\x00\x1c\x2d\x9a\x16\x5a\x74\xf1\x6c\x63\x09\x62\xa7\x1b\xab\x7b\x40\x38\x5b\x34\x86\x6c\x0c\xc3\x6e\x78\xa7\x1f\x98\x2b\xef\xf4\xe0\x17\x80\xc3\xcb\x7b\xae\x80\xf4\x0e\x4e\xd8\x0d\xe4\x38\x09\xed\x08\x7a\x74\xcf\xae\xea\xf0\x42\x16\x1c\x75\x24\xbc\x0b\x71\xf5\xe0\xc1\x09\x6d\xc9\x25\x33\x4b\x0d\x59\x52\x82\x1c\x13\xf7\xf5\x89\x53\x32\x68\x98\xc2\x29\x9c\xe9\xe0\xa4\x27\xfe\x4a\x5e\x24\x09\x8c\xed\x3a\x5c\xd3\x79\x95\xdc\x12\x5a\xef\x8d\x2b\xd3\x14\xed\x8b\x3c\x25\xda\x1e\x53\x53\x37\x69\x7e\x97\x6d\x93\x6c\xb3\xd9\xdd\x25\xa6\x6e\xbe\x82\x1e\xa5\x6f\x87\x43\x22\x74\x9f\x46\x6e\xa4\x63\x89\xd3\x43\xa7\x0f\x69\x8f\xce\x93\x4d\x27\xa5\x4f\x43\x40\x97\x3a\x3a\xf6\xa4\x7c\x10\x67\x5a\x13\x99\x8e\x27\x4a\x62\x2e\x5f\x05\x88\x5e\x43\x36\xb5\x16\x2e\x75\x1e\x0f\x1d\x7d\xc3\x65\xd2\xfa\xbe\x9b\xcd\xa1\x93\x82\x94\x0b\xcc\x7a\x3d\x60\x5c\x2c\x61\x50\x96\x9c\xb7\x52\x78\xaa\x67\x73\x90\xca\x0c\x5c\x5a\x3d\x29\xc6\xb8\x56\x46\x79\x37\xd2\x3a\x3f\xe2\xc0\x5f\x0c\x7d\x63\x58\x2d\x83\xa1\x1c\x29\x1f\x15\x34\x8f\x85\x0e\xba\xb8\xe6\x32\xf1\x15\x61\x6f\xc4\xc3\x90\x60\x7c\xe3\xc9\x20\xcf\x49\x4f\x36\xb4\x99\x23\x4d\x96\x22\x86\x7f\xa9\x23\xae\x68\xc5\x88\x12\x9a\x4e\xa3\x5f\x15\x13\x7b\xf0\x3c\x52\xaa\x84\x6c\x62\xe8\xf0\xa2\x07\x5f\xc2\xfd\x87\xdf\x27\xab\x42\x77\xda\x56\x5c\xbd\x12\x3e\xfd\xfc\xe3\xc4\xc2\x72\x2c\xe1\x31\x2f\x56\xc9\xf6\x6e\xb3\x80\x3c\xdf\x26\xc5\x8e\x45\x9c\xad\x92\xcd\x2a\x7b\x82\xf9\x3f\x68\xf5\x7f\x50\x6c\x36\x13\x4f\x4e\x60\x47\x25\x3c\x6e\x76\xc9\x6a\xbf\x59\xc0\xe6\x2e\xc9\x8b\x2c\x7c\xae\xee\x36\x4f\xa1\x8c\x6f\x54\x9d\x44\x55\x47\x4f\x7a\xf0\x66\xf0\xdc\xa9\xf1\xd0\xd7\x09\x1f\xc9\x35\xfb\x46\x7d\xc7\x2d\xaf\x77\x41\x84\xc2\x1c\x70\x0a\xbe\x76\x2c\xc2\x6f\x05\x9f\xbd\xe9\xc7\xec\xdf\xeb\x5e\xcb\x9e\x14\x33\xd4\x95\xf0\x58\xe4\x0b\x58\x86\x3f\xae\xcf\xc4\xf7\x2b\x0a\xce\xd2\xb7\xac\x70\xb6\x1e\xd0\x8b\x96\xe1\x81\x70\xa8\x2e\xe0\xe4\x17\x0a\x7e\x27\xc3\xa1\x04\x1d\xb9\xd4\x10\xfa\xc1\x92\xab\x06\xdb\x95\x37\xf9\xb8\x55\x82\x3d\x7e\xd1\x0a\xcf\x2e\xa8\xd4\x79\x6d\x29\x09\xd3\x3c\x68\x2a\xca\xd1\x93\x72\xda\x36\x9d\x3e\x5f\x05\x7a\xd3\x64\xd5\xab\x53\x51\x19\xe4\x66\x55\xe1\xc2\xad\x70\x38\x56\x45\x96\xef\xaa\x2c\xaf\x8a\x7d\x3a\xda\x96\x27\x2d\x96\xa2\x43\xe7\xc8\x25\xfe\xe5\xab\xac\x44\x4b\xe2\xd9\x0d\x7d\x09\x7b\x41\xeb\xd5\xfe\x20\x9a\xc3\x7a\x2d\x56\xd9\x9a\xd6\x7b\xcc\x1a\xca\x91\x56\xfb\x5d\xb3\xdd\xcf\x42\x02\xcc\xf5\xeb\xfd\xe9\xe2\xbc\x1e\x87\x2e\x0f\xfc\x33\xc9\x63\xeb\x1d\x58\x72\x7a\xb0\x82\x5c\x08\x16\xec\x95\x41\xdf\xfe\xf7\x02\xc4\x39\x33\x39\xb6\xbd\xbe\xdc\x24\x66\x3c\x8b\x74\x15\x5a\xd1\xca\xd3\xe4\x62\x6d\xb0\x73\x04\x73\x90\x0d\x38\xf2\x0b\xa6\x98\x8a\xed\x73\xc4\xad\x00\xc9\xf7\x0b\x7f\xe1\x4b\x4f\x41\xf4\x70\x73\x30\x7f\xbd\x4e\x42\xe6\xd3\xe3\x8d\x0b\xc1\x65\x4d\x4a\x7b\x62\xec\x64\x67\x23\x3b\x0a\x6f\x55\xee\x4a\xd7\xbf\x57\x88\x29\xc5\x37\x6e\x4b\x6f\x42\x07\xe0\xa4\x29\x3b\xac\xc5\x3a\xc7\xe2\x2e\xdb\x66\x87\x06\xeb\x86\xb6\x8d\xa8\x29\x2b\xea\xa6\xd9\xec\x31\x9b\xa1\xf7\x56\x1e\x06\x3f\x5e\x36\xf4\xe2\x2d\x82\x22\xcf\x6f\x77\xf0\x6a\x0b\xbe\x9f\xa5\xaa\x4b\xb8\x7f\x78\x88\x3c\xe7\x67\x4e\x50\xd1\x60\xb1\xbb\xed\x7a\x77\xff\xf0\xb0\x80\x4f\xfc\x2f\x49\x92\xf7\x61\x6b\xa0\x97\x54\xc7\xaa\x46\x8f\x8e\x78\x28\x7d\xbc\xff\x08\x45\x96\xdf\xc1\x1c\xe2\xe2\xed\xc5\x8c\x85\x71\xdd\x11\x85\xa1\x64\x43\xce\x57\x38\xf8\x56\xdb\x12\xfe\x20\xb5\xfc\xe0\x24\xaa\x23\xdc\xb7\xa8\x8e\xb3\xbf\x06\x00\xd9\xf6\xf4\xee\xce\x0a\x00\x00"
 
 func torchvision_deeplabv3_resnet101_yml() ([]byte, error) {
 	return bindata_read(
@@ -323,7 +323,7 @@ func torchvision_densenet_201_yml() ([]byte, error) {
 	)
 }
 
-var _torchvision_fcn_resnet101_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x56\x4d\x6f\xe3\x36\x13\xbe\xfb\x57\x0c\xe0\xcb\xfb\x16\x09\xf5\xed\xd8\x02\xda\x43\x03\xb4\x7b\xca\x2e\xb6\x8b\x16\x45\x10\x08\x23\x6a\x24\xb1\x91\x48\x81\xa4\x9c\xf5\xfe\xfa\x82\xa4\x6c\x2b\xbb\x29\xba\xf5\xc1\xa2\x66\x9e\xf9\xe0\xcc\xc3\xa1\x24\x8e\x54\xc2\x27\xa5\x79\xff\xbb\x30\x42\xc9\xea\x17\x2e\xab\x8f\x64\x24\xd9\x24\x4e\x60\x0b\x0e\x01\xaa\x85\x93\x9a\x35\x8c\xaa\xa1\x61\xd3\x6a\x1c\xe9\x45\xe9\xe7\x72\x03\x00\x10\x7c\x7c\x38\x79\x2f\xb0\x85\x8b\x1a\x5a\xa5\xc1\xf6\xb4\x98\x39\xec\x91\xb4\x8b\x52\x42\xc2\xf6\x2c\x79\x05\x5e\x54\xc0\x95\x34\x56\xa3\x90\x76\xb3\x42\xc7\xb0\xbd\x20\x84\x6c\x95\x1e\xd1\x86\x35\x18\x1a\x51\x5a\xc1\x2f\xfa\xa0\xdd\x70\x25\x2d\x0a\x49\xba\x84\x2d\x5c\x5e\x0c\xcc\x86\x1a\xb0\x0a\x26\xd2\x0e\x19\xb2\x83\x49\x53\x23\xb8\xf3\xe9\x13\xdd\xc2\x38\x0f\x56\x4c\x03\xc1\x34\xa0\x75\x40\x03\x1c\x25\xd4\x04\x66\x22\x2e\x5a\x41\x8d\x47\xe2\xd8\xec\xf2\x50\x09\xf7\xe3\xd3\x5c\x82\x46\x31\x69\xf5\x17\x71\x1b\x71\xd4\xe3\x70\x3b\x9d\xac\xab\x4e\xe9\xc1\xb7\x7c\x9a\x2f\xf8\xee\x3b\xf0\xdd\x82\x9f\x26\xbe\xcb\x07\xfa\xde\x60\x0b\xfc\x62\xfe\xef\xe1\xd6\x16\x0d\x19\xae\xc5\x64\x7d\x03\x7e\xf2\x0e\x3e\xf5\x04\x62\xc4\x8e\x0c\xf4\x78\x24\x57\xc4\x9a\x60\x50\xd8\x50\xe3\x3a\x61\x15\x20\x68\x94\x9d\x67\xcc\x63\x7c\x03\xc9\x13\xa0\x6c\x1c\x09\x24\x48\xd7\x96\x41\x7c\xa1\x06\x66\x23\x64\x07\x23\xa1\x84\x1f\xe1\x31\x66\xf9\xbe\xb8\x81\x98\xe5\xc5\xce\x3f\xe2\x5d\x30\x33\xb6\x09\xfa\x34\x3d\x38\x45\x9a\xe6\xe1\x51\x3c\xb1\x8d\xa6\x96\x34\x49\x4e\xc6\xf5\xf7\xfa\xe6\x5b\x8b\x93\xeb\x74\x04\x2f\x54\x1b\x61\xc9\x2d\xc9\x72\xc6\x20\xec\xaa\x76\xe1\x5f\x33\xf3\x16\x7a\x6b\x27\x53\x46\x11\xea\xcf\xe2\xc8\x94\xee\xa2\xa9\x69\xa3\x24\x4f\x12\x96\xc7\xd9\x9e\x4d\x4d\xfb\x15\xb2\x13\xb6\x9f\x6b\xc6\xd5\x18\x2d\x15\x8c\x8e\xfe\x14\x45\xf5\xa0\xea\xe8\x18\xb3\x9c\xc5\x91\x57\x2c\x72\x1f\xcf\x44\x86\xba\x91\xa4\xf5\x14\x8e\x5a\x2e\xd9\x74\xfa\xca\xf5\xe2\xcf\xa7\xd1\x28\x6e\x22\x63\xb1\x1e\xe8\x0d\x67\xac\xb7\xe3\xb0\xd9\xc2\x20\x38\x49\xe3\x2b\x7f\xdd\xd9\x22\x2c\x61\x96\x9a\x8c\xd5\x82\x5b\x6a\x36\x5b\x10\x72\x9a\xad\x2f\xd5\x15\x1b\x64\xe5\x42\xff\x56\x68\x63\x03\x0e\xec\x69\xa2\x37\x0e\xf3\xad\x57\x94\x81\x12\x0b\xc3\xb6\xb0\xe2\xcd\x39\x97\x95\xaf\x05\xf6\x8a\x5c\x0e\x12\x02\xad\x3d\x4d\xe8\x46\x83\x25\xed\xfb\xeb\x53\xb8\x8a\x2e\x7c\x06\xa0\x81\x5c\x2d\xab\x90\x4b\x3b\x28\xb4\x59\xba\xd2\x7b\xcf\xd5\x80\x27\x37\x08\xe2\x95\x62\xc0\x93\x9a\x6d\x09\xf7\xef\xfe\x58\x49\xb9\x1a\x94\xae\xdc\x26\x4b\xf8\xf8\xeb\xcf\x2b\x8d\xa3\x6b\x09\x8f\x49\x9a\xb1\xdd\x5d\x71\x03\x49\xb2\x63\xe9\xde\x91\x3c\xce\x58\x91\xc5\x4f\xb0\xfd\x07\x2e\xff\x00\x69\x51\xac\x3c\x19\x8e\x03\x95\xf0\x58\xec\x59\x76\x28\x6e\xa0\xb8\x63\x49\x1a\xfb\x67\x76\x57\x3c\xf9\x32\xbe\x62\x3d\x5b\x58\xbf\x78\x52\xb3\x9d\x66\xeb\x3a\x15\x36\x7d\x9e\x80\x0b\xad\x36\x6f\xd4\x37\x98\x5c\x67\xe5\x02\x85\x2d\xe0\x5b\x1d\x5b\xe0\x97\x82\x6f\x5e\xf5\x63\xf3\x6d\xdd\x85\xb4\xbb\xdc\xcb\x47\x34\xcf\xe6\x5c\xef\xdf\x96\x78\x1f\x2e\xd3\x35\xb4\xae\x25\xb4\xb3\x26\x53\xcd\x7a\x28\x2f\xa4\x37\x19\xc3\x11\xbf\x28\x89\x2f\xc6\x9f\x2a\x63\x95\x26\xe6\x67\x94\x3f\x09\xcb\xf1\xb1\x24\x8d\xd2\xed\xa0\x5e\xce\x92\x86\x68\x1a\xb0\x3e\x66\xd5\x28\x8f\x69\x35\xa1\x2b\x71\xe5\xaf\x91\x0a\xe7\xae\x4a\xe3\x64\x5f\xc5\x49\x95\x1e\xa2\xa0\xbb\x3d\x2a\x7e\xcb\x07\x34\x86\x0c\xb3\x9f\xed\xeb\xac\x78\x4f\xfc\xd9\xcc\x63\x09\x07\x4e\x79\x76\xa8\x79\x5b\xe7\x39\xcf\xe2\x9c\xf2\x03\xc6\x2d\x25\x48\xd9\x61\xdf\xee\x0e\x1b\x9f\x80\x63\xe8\xf9\x56\x30\xcb\x85\xd2\x69\x9c\x7a\x3f\xc6\x5e\x48\x74\xbd\x35\xa0\xc9\xa8\x59\x73\x0a\x25\xf0\xfa\x6a\x42\xdb\xff\xf7\x02\x9c\xa7\x4d\xcb\x65\xa5\xcf\x97\x35\x9b\xc2\x2e\x84\xa9\x50\xf3\x5e\x1c\x57\x17\x45\x8b\x83\x21\xd8\x82\x68\xc1\x90\xbd\x09\x03\xd9\x35\xba\x46\x43\xae\x09\x20\x0c\x20\xb8\x85\x1b\xe2\x12\x16\x0f\x17\x07\x5b\x8f\xbe\xe6\xbc\xde\x58\x10\x78\x97\x0d\x49\x65\xc9\xad\x57\x96\xad\x18\xc8\x7f\x25\x98\x33\xbd\xbe\xad\xcd\x8b\xb0\xbd\x08\x29\xad\x43\x87\x80\xd7\x76\x14\x14\xd7\x59\x13\x63\x71\xc8\x9b\x62\x97\x60\xb1\xdb\xd1\xdd\x21\xc5\xbb\x43\x83\x87\x8c\x1f\x36\x68\xad\x16\xf5\x6c\xc3\xad\x40\x9f\xad\x46\x90\x64\xfd\x17\xc6\x55\xe7\x7d\x3f\x0b\xd9\x94\x70\xff\xf0\xb0\x6c\xce\xbd\xbb\x04\x25\xcd\x1a\x87\x8b\xd5\xff\xee\x1f\x1e\x6e\xe0\xa3\xfb\x63\x8c\xfd\xdf\x9b\x7a\x62\x09\xd9\x55\x0d\x5a\x34\xe4\x86\xc8\xfb\xfb\xf7\x90\xc6\xc9\x9d\x9b\x80\x41\x78\xf9\xd0\xf0\x93\x73\xb1\x58\x8e\x88\x14\x2d\x19\x5b\xe1\x6c\x7b\xa5\x4b\xf8\x93\xe4\xed\x3b\x23\x50\x76\x70\xdf\xa3\xec\x36\x7f\x07\x00\x00\xff\xff\x84\x31\x69\x9d\x98\x09\x00\x00"
+var _torchvision_fcn_resnet101_yml = "\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x56\xdf\x6f\xe3\x38\x0e\x7e\xcf\x5f\x41\x20\x2f\x33\x87\xc4\xbf\x12\xa7\x8d\x81\xbb\x87\x2b\x70\x37\x4f\x9d\xc3\x60\x70\x8b\x45\x51\x18\xb4\x4c\xdb\xda\xda\x92\x21\xc9\x49\x33\x7f\xfd\x82\xb2\x92\xba\xb3\xb3\x8b\x59\xf4\x47\x62\xf1\x13\x49\x7d\xfc\x48\x59\xe1\x40\x05\x7c\xd5\x46\x74\xff\x97\x56\x6a\x55\xfe\x47\xa8\xf2\x0b\x59\x45\x2e\x4d\x52\x58\x03\x23\x40\x37\x70\xd1\x93\x81\x41\xd7\xd4\xaf\x1a\x83\x03\x9d\xb5\x79\x29\x56\x00\xe0\x11\x05\xfc\xef\xe2\xbd\xc0\x1a\x6e\x66\x68\xb4\x01\xd7\x51\xd8\xc6\xd8\x13\x19\x8e\x52\x40\x1a\xdd\x47\xe9\x3b\x70\x30\x81\xd0\xca\x3a\x83\x52\xb9\xd5\x02\x9d\xc0\xfa\x86\x90\xaa\xd1\x66\x40\xc7\x68\xa9\xc0\xd2\x80\xca\x49\x71\xb3\xcf\xd6\x95\xd0\xca\xa1\x54\x64\x0a\x58\xc3\xed\xc1\xc2\x64\xa9\x06\xa7\x61\x24\xc3\xc8\x39\x3b\x18\x0d\xd5\x52\xb0\x4f\x7f\xa8\x35\x0c\x53\xef\xe4\xd8\x13\x8c\x3d\x3a\x06\x5a\x10\xa8\xa0\x22\xb0\x23\x09\xd9\x48\xaa\x3d\x12\x87\xfa\xb0\x9f\x99\xe0\x1f\x31\x4e\x05\x18\x94\xa3\xd1\xbf\x91\x70\xb1\x40\x33\xf4\xdb\xf1\xe2\x98\x9d\xc2\x83\xb7\x62\x9c\x6e\xf8\xf6\x27\xf0\x6d\xc0\x8f\xa3\x38\xec\x7b\xfa\xd9\x60\x01\xbe\x6d\x7f\x3a\xdc\x72\x47\x4d\x56\x18\x39\x32\x23\x05\xfc\xcb\x87\xfc\xda\x11\xc8\x01\x5b\xb2\xd0\xe1\x89\x98\xc4\x8a\xa0\xd7\x58\x53\x0d\x52\xf1\x33\x82\x41\xd5\x7a\xc5\x3c\x25\x1b\x48\x9f\x01\x55\xcd\x22\x50\xa0\xb8\x2c\xbd\xfc\x46\x35\x4c\x56\xaa\x16\x06\x42\x05\xff\x84\xa7\x24\xda\xdf\xe7\x1b\x48\xa2\x7d\x7e\xf0\x1f\xc9\x61\xde\x66\x5d\x3d\xdb\xb3\xec\xc8\x86\x2c\xdb\xcf\x1f\xf9\x73\x74\x4b\xc8\x67\x7e\xf2\xe2\x0d\xa5\x34\xe4\x26\xa3\x2c\x20\x70\x45\x37\x70\xee\xa4\xe8\x38\x87\x9b\x48\x2b\xa9\x6a\xce\x40\xa0\x52\xda\x81\x21\xac\x0b\x0f\x68\x0d\x8e\x1d\x74\x68\xc3\xd9\x66\x0a\x42\xa5\x1b\xa3\x07\x40\x38\x1b\x1c\x47\x32\x21\x0c\xbb\xe1\x9d\x6e\x62\xad\x7c\xd0\x93\xdb\x00\x4e\xaf\x1f\x99\x01\xe9\x2c\x9c\xb0\x9f\xc8\x72\x12\xda\x12\x0c\x68\x5f\x6c\xd9\xe3\x85\x0c\x58\xea\x49\x38\xeb\xe3\xea\xc9\x81\x15\xda\x90\x8d\x56\x86\x1a\x32\xa4\x04\x59\x16\xee\xdb\x13\xa7\x34\xe2\xc8\x12\x8e\xe1\x4c\x95\x95\x8e\xf8\x2b\x39\x11\x45\x30\x97\xab\xba\xa6\xf3\xd6\x72\x5b\xe8\x9c\x1b\x6d\x11\xc7\x68\x5e\xe5\x29\xd2\xa6\x8d\xc7\xba\x89\xd3\x7d\x9a\x46\xfb\x64\x77\x1f\x8d\x75\xf3\x1d\xb2\x95\xae\x9b\xaa\x48\xe8\x21\x0e\xd2\x88\x67\x86\xe3\xaa\xd7\x55\x7c\x4a\xa2\x7d\x94\xc4\x0b\xe6\x63\x1f\xcf\xc6\x96\xda\x81\x94\xf3\xbd\x19\x37\x42\x45\xe3\xe5\x3b\xd7\xc1\x9f\x4f\xa3\xd6\xc2\xc6\xd6\x61\xd5\xd3\x0f\x9c\x45\x9d\x1b\xfa\xd5\x1a\x7a\x29\x48\x59\x2f\xa9\xb7\x93\x85\xc5\x02\x26\x65\xc8\x3a\x23\x85\xa3\x7a\xb5\x06\xa9\xc6\x89\x39\xd5\x0b\x16\xe6\xb5\x22\xf4\x75\x23\x8d\x75\x33\x0e\xdc\x65\xa4\x1f\x4c\xa9\xad\x37\x14\xb3\xd6\x43\xeb\xac\x03\xc3\xbe\x21\xae\xb9\x2c\x7c\x05\xd8\xbb\xae\x61\x88\x37\xbe\xf3\x34\x22\x0f\x48\x47\xc6\xd7\x97\x23\x2d\x96\x02\x86\x7f\xa9\x27\xe6\xb2\x64\x44\x01\x4d\xaf\xd1\xed\xb2\x85\xdd\x7b\x9e\xb5\x54\x40\xb2\x30\xf4\x78\xd1\x93\x2b\xe0\xe1\xd3\x2f\x8b\x55\xa1\x7b\x6d\x4a\x66\xaf\x80\x2f\xff\xfd\xf7\xc2\xc2\x7d\x58\xc0\x53\x9a\xed\xa2\xc3\x5d\xbe\x81\x34\x3d\x44\xd9\x3d\x77\x6f\xb2\x8b\xf2\x5d\xf2\x0c\xeb\x3f\x69\xd2\x7f\x40\x96\xe7\x0b\x4f\x56\x60\x4f\x05\x3c\xe5\xf7\xd1\xee\x98\x6f\x20\xbf\x8b\xd2\x2c\xf1\x9f\xbb\xbb\xfc\xd9\xd3\xf8\xae\x9d\xa3\xd0\xce\xc1\x93\x9e\xdc\x38\x39\xae\xd4\x7c\xe8\xeb\x68\x0f\xb2\x5a\xfd\x80\xdf\x79\xcb\xdb\x25\x10\xa0\xb0\x06\x5c\x82\xaf\x15\x0b\xf0\x1b\xe1\xab\x77\xf5\x58\xfd\x35\xef\xb5\x1c\x48\xb1\x42\x6d\x01\x4f\x59\xba\x81\xad\xff\x63\x7e\x16\xbe\xdf\x50\x70\x96\xae\xe3\xd6\x66\x6b\x85\x4e\x74\x0c\xf7\x82\x43\x75\x01\x2b\xbf\x91\xf7\xbb\x98\x0a\x05\xe8\xa0\xa5\x86\xd0\x4d\x86\x6c\x39\x99\xbe\xb8\xb5\x8f\xdd\x45\x38\xe0\x37\xad\xf0\x6c\x7d\x7f\x5a\xa7\x0d\x45\x7e\x8c\xfb\x9e\x0a\x8d\xe8\x48\x59\x6d\x9a\x5e\x9f\xaf\xad\x59\x13\x8d\x3d\x56\xa7\x5d\x39\xa8\x53\x56\x8e\xc8\xc5\x2a\xfd\x4d\x5b\xe2\xd4\x96\x59\x92\xde\x97\x49\x5a\x66\xc7\x78\xb6\x6d\x4f\x5a\x6c\x45\x8f\xd6\x92\x8d\xdc\xeb\x77\x59\x89\x8e\xc4\x8b\x9d\x86\x02\x8e\x82\xf6\xbb\x63\x25\x9a\x6a\xbf\x17\xbb\x64\x4f\xfb\x23\x26\x0d\xa5\x48\xbb\xe3\x7d\x73\x38\xae\x7c\x02\xac\xf5\xeb\xc5\x69\xc3\xa0\x9e\xa7\x2d\x4f\xfa\x33\xc9\xb6\x73\x16\x0c\x59\x3d\x19\x41\xd6\x07\xf3\xf6\x72\x44\xd7\xfd\x7d\x02\xc2\x9c\xe1\x21\x54\x9a\xeb\xfb\x4c\x34\xce\xa7\x90\xb6\x44\x23\x3a\x79\x5a\xdc\xa5\x0d\xf6\x96\x60\x0d\xb2\x01\x4b\x6e\xc3\xe2\x52\xa1\x70\x96\xb8\x08\x20\xf9\x4a\xe1\x2f\x7c\xcf\x29\x08\x1e\x6e\x0e\xd6\x6f\x37\x88\xcf\x79\x79\xb0\x79\xc1\xbb\xac\x49\x69\x47\x8c\x5d\xec\x6c\x64\x4f\xfe\x45\xca\x5e\x85\xfa\x47\x6e\x58\x4c\x7c\xc9\x76\xf4\x2e\xb4\x07\x2e\xca\x91\x53\x52\xed\xea\x04\xf3\xe3\xbe\xce\x0f\x29\xe6\x87\x03\xdd\x1d\x33\xbc\x3b\xd6\x78\xdc\x89\xe3\x0a\x9d\x33\xb2\x9a\xdc\x7c\xbf\xd0\xab\x33\x08\x8a\x1c\xbf\xd0\xc1\x9b\xcd\xfb\x7e\x91\xaa\x2e\xe0\xe1\xf1\x31\x28\x9c\x9f\x39\x41\x45\x93\xc1\xfe\xb6\xeb\xc3\xc3\xe3\xe3\x06\xbe\xf0\xbf\x28\x8a\x3e\xfa\xad\x5e\x58\x52\xb5\x65\x8d\x0e\x2d\xf1\x38\xfa\xfc\xf0\x19\xb2\x24\xbd\x83\x35\x84\xc5\xdb\xbb\x18\xb7\xc4\x75\x47\x68\x09\x25\x1b\xb2\xae\xc4\xc9\x75\xda\x14\xf0\x2b\xa9\xed\x27\x2b\x51\xb5\xf0\xd0\xa1\x6a\x57\xbf\x0f\x00\xa4\x70\xb7\x24\xbb\x0a\x00\x00"
 
 func torchvision_fcn_resnet101_yml() ([]byte, error) {
 	return bindata_read(
@@ -20,9 +20,12 @@ require (
 	github.com/c3sr/tracer v1.0.4
 	github.com/elazarl/go-bindata-assetfs v1.0.1
 	github.com/k0kubun/pp/v3 v3.0.7
+	github.com/oliamb/cutter v0.2.2
 	github.com/opentracing/opentracing-go v1.2.0
 	github.com/pkg/errors v0.9.1
 	github.com/sirupsen/logrus v1.8.1
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/text v0.3.6
+	gopkg.in/yaml.v2 v2.4.0
 	gorgonia.org/tensor v0.9.14
 )
@@ -0,0 +1,182 @@
+package predictor
+
+import (
+	"math"
+	"strconv"
+
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/pkg/errors"
+)
+
+// ssdAnchorSpec describes the prior boxes of an SSD head, one entry per feature map.
+// The sizes and steps are in input pixels.
+type ssdAnchorSpec struct {
+	imageSize       float32
+	featureMapSizes []int
+	steps           []float32
+	minSizes        []float32
+	maxSizes        []float32
+	aspectRatios    [][]float32
+	variances       []float32
+	clip            bool
+}
+
+// ssdAnchorSpecFromManifest reads the anchor parameters of the manifest output:
+//
+//	anchor_image_size:        size of the square input image
+//	anchor_feature_map_sizes: size of each feature map the head predicts from
+//	anchor_steps:             stride of each feature map, defaults to anchor_image_size / feature map size
+//	anchor_min_sizes:         size of the square prior of each feature map
+//	anchor_max_sizes:         optional, adds a square prior of size sqrt(min * max)
+//	anchor_aspect_ratios:     aspect ratios of each feature map, a single list applies to all of them
+//	anchor_variances:         center and size variances, defaults to [0.1, 0.2]
+//	anchor_clip:              clip the priors to the image, defaults to true
+//
+// It returns nil when the manifest does not declare anchors, in which case
+// the model is expected to output decoded boxes.
+func ssdAnchorSpecFromManifest(p common.Base) (*ssdAnchorSpec, error) {
+	spec := &ssdAnchorSpec{
+		imageSize: getOutputFloat32Parameter(p, "anchor_image_size", 0),
+		variances: []float32{0.1, 0.2},
+		clip:      true,
+	}
+
+	ok, err := getOutputListParameter(p, "anchor_feature_map_sizes", &spec.featureMapSizes)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	if _, err := getOutputListParameter(p, "anchor_steps", &spec.steps); err != nil {
+		return nil, err
+	}
+	if _, err := getOutputListParameter(p, "anchor_min_sizes", &spec.minSizes); err != nil {
+		return nil, err
+	}
+	if _, err := getOutputListParameter(p, "anchor_max_sizes", &spec.maxSizes); err != nil {
+		return nil, err
+	}
+	if _, err := getOutputListParameter(p, "anchor_aspect_ratios", &spec.aspectRatios); err != nil {
+		var ratios []float32
+		if _, err := getOutputListParameter(p, "anchor_aspect_ratios", &ratios); err != nil {
+			return nil, err
+		}
+		spec.aspectRatios = make([][]float32, len(spec.featureMapSizes))
+		for ii := range spec.aspectRatios {
+			spec.aspectRatios[ii] = ratios
+		}
+	}
+	if _, err := getOutputListParameter(p, "anchor_variances", &spec.variances); err != nil {
+		return nil, err
+	}
+	if str, err := p.GetTypeParameter(p.Model.GetOutput().GetParameters(), "anchor_clip"); err == nil && str != "" {
+		clip, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, errors.Errorf("unable to get anchor_clip %v as a boolean", str)
+		}
+		spec.clip = clip
+	}
+
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (s *ssdAnchorSpec) validate() error {
+	numMaps := len(s.featureMapSizes)
+	if numMaps == 0 {
+		return errors.New("anchor_feature_map_sizes is empty")
+	}
+	if s.imageSize <= 0 {
+		return errors.New("anchor_image_size must be set to a positive value")
+	}
+	if len(s.minSizes) != numMaps {
+		return errors.Errorf("expecting %v anchor_min_sizes, but got %v", numMaps, len(s.minSizes))
+	}
+	if len(s.maxSizes) != 0 && len(s.maxSizes) != numMaps {
+		return errors.Errorf("expecting %v anchor_max_sizes, but got %v", numMaps, len(s.maxSizes))
+	}
+	if len(s.steps) != 0 && len(s.steps) != numMaps {
+		return errors.Errorf("expecting %v anchor_steps, but got %v", numMaps, len(s.steps))
+	}
+	if len(s.aspectRatios) != 0 && len(s.aspectRatios) != numMaps {
+		return errors.Errorf("expecting %v anchor_aspect_ratios, but got %v", numMaps, len(s.aspectRatios))
+	}
+	if len(s.variances) != 2 {
+		return errors.Errorf("expecting 2 anchor_variances, but got %v", len(s.variances))
+	}
+	return nil
+}
+
+// generate returns the priors as a flat list of normalized center x, center y, width and height.
+// The priors are laid out by feature map, then row, then column, then box: the square prior
+// of the min size, the square prior of size sqrt(min * max), and a pair of priors for each
+// aspect ratio. This is the order used by the SSD reference implementations.
+func (s *ssdAnchorSpec) generate() []float32 {
+	var anchors []float32
+	for k, fmSize := range s.featureMapSizes {
+		scale := float32(fmSize)
+		if len(s.steps) != 0 {
+			scale = s.imageSize / s.steps[k]
+		}
+		for j := 0; j < fmSize; j++ {
+			for i := 0; i < fmSize; i++ {
+				cx := (float32(i) + 0.5) / scale
+				cy := (float32(j) + 0.5) / scale
+
+				size := s.minSizes[k] / s.imageSize
+				anchors = append(anchors, cx, cy, size, size)
+
+				if len(s.maxSizes) != 0 {
+					maxSize := float32(math.Sqrt(float64(s.minSizes[k]*s.maxSizes[k]))) / s.imageSize
+					anchors = append(anchors, cx, cy, maxSize, maxSize)
+				}
+
+				if len(s.aspectRatios) != 0 {
+					for _, ratio := range s.aspectRatios[k] {
+						r := float32(math.Sqrt(float64(ratio)))
+						anchors = append(anchors, cx, cy, size*r, size/r)
+						anchors = append(anchors, cx, cy, size/r, size*r)
+					}
+				}
+			}
+		}
+	}
+	if s.clip {
+		for ii, v := range anchors {
+			anchors[ii] = max32(0, min32(v, 1))
+		}
+	}
+	return anchors
+}
+
+// decodeAnchorOffsets converts the center-size offsets (dx, dy, dw, dh) predicted for each anchor
+// into normalized corner boxes. The boxes of every image in the batch are decoded against the same anchors.
+// The coordinates are written at the ymin, xmin, ymax and xmax positions given by boxIndex.
+func decodeAnchorOffsets(offsets, anchors, variances []float32, boxIndex []int) ([]float32, error) {
+	numAnchors := len(anchors) / 4
+	if numAnchors == 0 || len(offsets)%(numAnchors*4) != 0 {
+		return nil, errors.Errorf("cannot decode %v box offsets against %v anchors", len(offsets)/4, numAnchors)
+	}
+
+	centerVariance, sizeVariance := variances[0], variances[1]
+	boxes := make([]float32, len(offsets))
+	for ii := 0; ii < len(offsets)/4; ii++ {
+		offset := offsets[ii*4 : (ii+1)*4]
+		anchor := anchors[(ii%numAnchors)*4 : (ii%numAnchors+1)*4]
+
+		cx := offset[0]*centerVariance*anchor[2] + anchor[0]
+		cy := offset[1]*centerVariance*anchor[3] + anchor[1]
+		w := float32(math.Exp(float64(offset[2]*sizeVariance))) * anchor[2]
+		h := float32(math.Exp(float64(offset[3]*sizeVariance))) * anchor[3]
+
+		box := boxes[ii*4 : (ii+1)*4]
+		box[boxIndex[0]] = cy - h/2
+		box[boxIndex[1]] = cx - w/2
+		box[boxIndex[2]] = cy + h/2
+		box[boxIndex[3]] = cx + w/2
+	}
+	return boxes, nil
+}
@@ -0,0 +1,77 @@
+package predictor
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSDAnchorSpecGenerate(t *testing.T) {
+	spec := ssdAnchorSpec{
+		imageSize:       60,
+		featureMapSizes: []int{2, 1},
+		minSizes:        []float32{30, 60},
+		maxSizes:        []float32{60, 90},
+		aspectRatios:    [][]float32{{2}, {2, 3}},
+		variances:       []float32{0.1, 0.2},
+		clip:            true,
+	}
+	assert.NoError(t, spec.validate())
+
+	anchors := spec.generate()
+
+	// 4 cells with 4 priors for the first feature map and 1 cell with 6 priors for the second one
+	assert.Equal(t, (4*4+6)*4, len(anchors))
+
+	sqrt2 := float32(math.Sqrt(2))
+	maxSize := float32(math.Sqrt(30*60)) / 60
+	assert.InDeltaSlice(t, []float32{0.25, 0.25, 0.5, 0.5}, anchors[0:4], 1e-6)
+	assert.InDeltaSlice(t, []float32{0.25, 0.25, maxSize, maxSize}, anchors[4:8], 1e-6)
+	assert.InDeltaSlice(t, []float32{0.25, 0.25, 0.5 * sqrt2, 0.5 / sqrt2}, anchors[8:12], 1e-6)
+	assert.InDeltaSlice(t, []float32{0.25, 0.25, 0.5 / sqrt2, 0.5 * sqrt2}, anchors[12:16], 1e-6)
+	// second cell of the first row
+	assert.InDeltaSlice(t, []float32{0.75, 0.25, 0.5, 0.5}, anchors[16:20], 1e-6)
+	// the sqrt(min * max) prior of the last feature map is larger than the image and is clipped
+	assert.InDeltaSlice(t, []float32{0.5, 0.5, 1, 1}, anchors[68:72], 1e-6)
+	for _, v := range anchors {
+		assert.True(t, v >= 0 && v <= 1)
+	}
+}
+
+func TestSSDAnchorSpecValidate(t *testing.T) {
+	spec := ssdAnchorSpec{
+		imageSize:       300,
+		featureMapSizes: []int{19, 10},
+		minSizes:        []float32{60},
+		variances:       []float32{0.1, 0.2},
+	}
+	assert.Error(t, spec.validate())
+}
+
+func TestDecodeAnchorOffsets(t *testing.T) {
+	anchors := []float32{
+		0.25, 0.25, 0.5, 0.5,
+		0.75, 0.75, 0.5, 0.5,
+	}
+	variances := []float32{0.1, 0.2}
+	offsets := []float32{
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		// second image of the batch
+		1, 0, 0, 0,
+		0, 0, float32(math.Log(2)) / 0.2, 0,
+	}
+
+	boxes, err := decodeAnchorOffsets(offsets, anchors, variances, xyxyBoxIndex)
+	assert.NoError(t, err)
+	assert.InDeltaSlice(t, []float32{
+		0, 0, 0.5, 0.5,
+		0.5, 0.5, 1, 1,
+		0.05, 0, 0.55, 0.5,
+		0.25, 0.5, 1.25, 1,
+	}, boxes, 1e-6)
+
+	_, err = decodeAnchorOffsets(offsets[:12], anchors, variances, xyxyBoxIndex)
+	assert.Error(t, err)
+}
@@ -0,0 +1,422 @@
+package predictor
+
+import (
+	"context"
+	"image"
+	"math"
+
+	"github.com/c3sr/dlframework"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/c3sr/tracer"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// defaultAugmentationCropRatio is the crop_ratio of the corner crops when the resize does not enlarge the
+// images, as the usual resize to 256 pixels and crops of 224 pixels.
+const defaultAugmentationCropRatio = 0.875
+
+// the augmentations declared by the augmentations input parameter of the manifest
+const (
+	// augmentHFlip adds the horizontally mirrored image
+	augmentHFlip = "hflip"
+	// augmentFiveCrop adds the crops at the corners of the resized image to its center crop, the image being
+	// enlarged by 1/crop_ratio for the corner crops unless the center_crop resize_mode already enlarged it
+	augmentFiveCrop = "five_crop"
+	// augmentTenCrop adds the five crops and their mirrored images
+	augmentTenCrop = "ten_crop"
+	// augmentMultiScale resizes the image by each of the augmentation_scales
+	augmentMultiScale = "multi_scale"
+)
+
+// cropPosition is where a crop is cut from the resized image.
+type cropPosition int
+
+const (
+	cropCenter cropPosition = iota
+	cropTopLeft
+	cropTopRight
+	cropBottomLeft
+	cropBottomRight
+)
+
+// augmentationVariant is one of the variants of an image run through the model by the test-time augmentation.
+type augmentationVariant struct {
+	// scale multiplies the size of the resized image
+	scale float64
+	crop  cropPosition
+	// flip mirrors the input horizontally
+	flip bool
+}
+
+// augmentationOptions holds the test-time augmentation of a request.
+type augmentationOptions struct {
+	enabled bool
+	// variants are the variants of every image, the first one being the image as preprocessed without augmentation
+	// when the scales include 1
+	variants []augmentationVariant
+}
+
+// imageAugmentationOptions resolves the test-time augmentation from the test_time_augmentation, augmentations and
+// augmentation_scales input parameters of the manifest, overridden by the contexts in order.
+// The variants are the combinations of the scales, the crops and the flips of the declared augmentations.
+func imageAugmentationOptions(base common.Base, ctxs ...context.Context) (augmentationOptions, error) {
+	res := augmentationOptions{enabled: getInputBoolParameter(base, "test_time_augmentation", false)}
+	for _, ctx := range ctxs {
+		if ctx == nil {
+			continue
+		}
+		if val, ok := ctx.Value(testTimeAugmentationKey{}).(bool); ok {
+			res.enabled = val
+		}
+	}
+
+	var augmentations []string
+	if _, err := getInputListParameter(base, "augmentations", &augmentations); err != nil {
+		return augmentationOptions{}, err
+	}
+	scales := []float64{1}
+	crops := []cropPosition{cropCenter}
+	flips := []bool{false}
+	for _, augmentation := range augmentations {
+		switch augmentation {
+		case augmentHFlip:
+			flips = []bool{false, true}
+		case augmentFiveCrop:
+			crops = []cropPosition{cropCenter, cropTopLeft, cropTopRight, cropBottomLeft, cropBottomRight}
+		case augmentTenCrop:
+			crops = []cropPosition{cropCenter, cropTopLeft, cropTopRight, cropBottomLeft, cropBottomRight}
+			flips = []bool{false, true}
+		case augmentMultiScale:
+			scales = []float64{0.75, 1, 1.25}
+			ok, err := getInputListParameter(base, "augmentation_scales", &scales)
+			if err != nil {
+				return augmentationOptions{}, err
+			}
+			if ok && len(scales) == 0 {
+				return augmentationOptions{}, errors.New("the multi_scale augmentation needs at least one scale")
+			}
+		default:
+			return augmentationOptions{}, errors.Errorf("augmentation %v is not supported", augmentation)
+		}
+	}
+
+	cropScale := 1.0
+	if len(crops) > 1 {
+		var err error
+		if cropScale, err = cornerCropScale(base.Model); err != nil {
+			return augmentationOptions{}, err
+		}
+	}
+
+	for _, scale := range scales {
+		if !(scale > 0) {
+			return augmentationOptions{}, errors.Errorf("the augmentation scales must be positive, but got %v", scale)
+		}
+		for _, crop := range crops {
+			variantScale := scale
+			if crop != cropCenter {
+				variantScale *= cropScale
+			}
+			for _, flip := range flips {
+				res.variants = append(res.variants, augmentationVariant{scale: variantScale, crop: crop, flip: flip})
+			}
+		}
+	}
+	return res, nil
+}
+
+// cornerCropScale returns the scale of the corner crops, which enlarges the images by 1/crop_ratio when
+// the resize fits them to the input dimensions, as their corners would otherwise match the center crop.
+func cornerCropScale(model dlframework.ModelManifest) (float64, error) {
+	pre, err := NewImagePreprocessor(model)
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot create the image preprocessor")
+	}
+	cropRatio := float64(pre.CropRatio)
+	switch pre.ResizeMode {
+	case ResizeKeepAspect:
+		return 0, errors.Errorf("the corner crops need the input dimensions, but the resize_mode is %v", pre.ResizeMode)
+	case ResizeCenterCrop:
+		if cropRatio > 0 && cropRatio < 1 {
+			return 1, nil
+		}
+	}
+	if !(cropRatio > 0 && cropRatio < 1) {
+		cropRatio = defaultAugmentationCropRatio
+	}
+	return 1 / cropRatio, nil
+}
+
+// augmentedImages are the images whose variants were run through the model by the test-time augmentation.
+type augmentedImages struct {
+	// geometries are the placements of the images without augmentation, which the variants are relative to
+	geometries []imageGeometry
+	variants   []augmentationVariant
+}
+
+// count returns the number of variants run for every image.
+func (o augmentationOptions) count() int {
+	if !o.enabled {
+		return 1
+	}
+	return len(o.variants)
+}
+
+// geometry returns the placement of the variant onto the input, from the placement g of the image.
+// The scaled center crop keeps the center of the image in place, while the corner crops align the
+// corners of the resized image with the corners of the input.
+func (v augmentationVariant) geometry(g imageGeometry) imageGeometry {
+	res := g
+	res.resizedWidth = maxInt(1, int(math.Round(float64(g.resizedWidth)*v.scale)))
+	res.resizedHeight = maxInt(1, int(math.Round(float64(g.resizedHeight)*v.scale)))
+	switch v.crop {
+	case cropCenter:
+		res.offsetX = g.offsetX + (g.resizedWidth-res.resizedWidth)/2
+		res.offsetY = g.offsetY + (g.resizedHeight-res.resizedHeight)/2
+	case cropTopLeft:
+		res.offsetX, res.offsetY = 0, 0
+	case cropTopRight:
+		res.offsetX, res.offsetY = g.width-res.resizedWidth, 0
+	case cropBottomLeft:
+		res.offsetX, res.offsetY = 0, g.height-res.resizedHeight
+	case cropBottomRight:
+		res.offsetX, res.offsetY = g.width-res.resizedWidth, g.height-res.resizedHeight
+	}
+	return res
+}
+
+// preprocessVariants converts every variant of the images into a batched input tensor, the variants of an image
+// following each other. It returns the source images placed as without augmentation, the variants being relative to them.
+func (p *ImagePreprocessor) preprocessVariants(ctx context.Context, imgs []image.Image, variants []augmentationVariant) (gotensor.Tensor, []sourceImage, error) {
+	span, _ := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "preprocess_variants")
+	defer span.Finish()
+
+	if len(imgs) == 0 {
+		return nil, nil, errors.New("no images to preprocess")
+	}
+	if len(variants) == 0 {
+		return nil, nil, errors.New("no variants of the images to preprocess")
+	}
+
+	var batch []float32
+	var shape []int
+	sources := make([]sourceImage, len(imgs))
+	for ii, img := range imgs {
+		src, srcWidth, srcHeight := rgbPixels(img)
+		if srcWidth == 0 || srcHeight == 0 {
+			return nil, nil, errors.Errorf("cannot preprocess image %v, it is empty", ii)
+		}
+		g := p.imageGeometry(img)
+		sources[ii] = sourceImage{image: img, geometry: g}
+
+		for _, v := range variants {
+			data, imgShape, err := p.render(src, v.geometry(g), v.flip)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "cannot preprocess image %v", ii)
+			}
+			if shape == nil {
+				shape = imgShape
+				batch = make([]float32, 0, len(imgs)*len(variants)*len(data))
+			} else if !gotensor.Shape(shape).Eq(gotensor.Shape(imgShape)) {
+				return nil, nil, errors.Errorf("image %v has the shape %v, but the first image of the batch has the shape %v", ii, imgShape, shape)
+			}
+			batch = append(batch, data...)
+		}
+	}
+
+	return gotensor.New(
+		gotensor.WithShape(append([]int{len(imgs) * len(variants)}, shape...)...),
+		gotensor.WithBacking(batch),
+	), sources, nil
+}
+
+// averageVariants averages the rows of outputs of the variants of every image, such as their class scores.
+// It returns the averaged outputs and the number of images.
+func averageVariants(outputs []float32, batch, variants int) ([]float32, int, error) {
+	if variants <= 1 {
+		return outputs, batch, nil
+	}
+	if batch%variants != 0 || len(outputs)%batch != 0 {
+		return nil, 0, errors.Errorf("cannot split the outputs of %v inputs into %v variants of every image", batch, variants)
+	}
+	size := len(outputs) / batch
+	images := batch / variants
+	res := make([]float32, images*size)
+	for n := 0; n < images; n++ {
+		row := res[n*size : (n+1)*size]
+		for v := 0; v < variants; v++ {
+			for ii, val := range outputs[(n*variants+v)*size : (n*variants+v+1)*size] {
+				row[ii] += val
+			}
+		}
+		for ii := range row {
+			row[ii] /= float32(variants)
+		}
+	}
+	return res, images, nil
+}
+
+// variantSample is the position of an output pixel of the reference placement of an image on an axis of the
+// output of one of its variants, and whether the variant covers it.
+type variantSample struct {
+	axisSample
+	covered bool
+}
+
+// variantAxis maps the centers of the output pixels of an axis of the image placed as without augmentation onto
+// the output of a variant. The image was resized to refResized pixels drawn at refOffset for the reference placement,
+// and to resized pixels drawn at offset for the variant, on an input axis of input pixels whose output has output
+// pixels. The variant was mirrored when flip is set.
+func variantAxis(refResized, refOffset, resized, offset, input, output int, flip bool) []variantSample {
+	samples := make([]variantSample, output)
+	for o := range samples {
+		u := (float64(o)+0.5)*float64(input)/float64(output) - float64(refOffset)
+		u = u*float64(resized)/float64(refResized) + float64(offset)
+		if flip {
+			u = float64(input) - u
+		}
+		sample := variantSample{covered: u >= 0 && u < float64(input)}
+		switch v := u*float64(output)/float64(input) - 0.5; {
+		case v <= 0:
+			sample.axisSample = axisSample{lo: 0, hi: 0}
+		case v >= float64(output-1):
+			sample.axisSample = axisSample{lo: output - 1, hi: output - 1}
+		default:
+			lo := int(math.Floor(v))
+			sample.axisSample = axisSample{lo: lo, hi: lo + 1, frac: float32(v - float64(lo))}
+		}
+		samples[o] = sample
+	}
+	return samples
+}
+
+// variantAxes returns the samples of the rows and the columns of the output of every variant of the image g.
+func variantAxes(g imageGeometry, variants []augmentationVariant, height, width int) ([][]variantSample, [][]variantSample) {
+	ys := make([][]variantSample, len(variants))
+	xs := make([][]variantSample, len(variants))
+	for v, variant := range variants {
+		vg := variant.geometry(g)
+		ys[v] = variantAxis(g.resizedHeight, g.offsetY, vg.resizedHeight, vg.offsetY, g.height, height, false)
+		xs[v] = variantAxis(g.resizedWidth, g.offsetX, vg.resizedWidth, vg.offsetX, g.width, width, variant.flip)
+	}
+	return ys, xs
+}
+
+// coveringVariants returns the variants covering the output pixel y, x, or all of them when none does,
+// their samples being clamped to the border of their outputs.
+func coveringVariants(ys, xs [][]variantSample, y, x int, res []int) []int {
+	res = res[:0]
+	for v := range ys {
+		if ys[v][y].covered && xs[v][x].covered {
+			res = append(res, v)
+		}
+	}
+	if len(res) == 0 {
+		for v := range ys {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// mergeVariantScores averages the NCHW scores of the variants of every image onto the output of the image placed as
+// without augmentation, the variants being unflipped and resampled bilinearly. The geometries are the placements of
+// the images, whose variants follow each other in the scores. It returns the contiguous scores of the images.
+func mergeVariantScores(scores float32View, geometries []imageGeometry, variants []augmentationVariant) (float32View, error) {
+	if scores.shape[0] != len(geometries)*len(variants) {
+		return float32View{}, errors.Errorf("the outputs hold %v images, but %v variants of %v images were preprocessed",
+			scores.shape[0], len(variants), len(geometries))
+	}
+	if err := checkGeometries(geometries, len(geometries)); err != nil {
+		return float32View{}, err
+	}
+	classes, height, width := scores.shape[1], scores.shape[2], scores.shape[3]
+	sb, sc, sh, sw := scores.strides[0], scores.strides[1], scores.strides[2], scores.strides[3]
+
+	res := float32View{
+		data:    make([]float32, len(geometries)*classes*height*width),
+		shape:   []int{len(geometries), classes, height, width},
+		strides: []int{classes * height * width, height * width, width, 1},
+	}
+	covering := make([]int, 0, len(variants))
+	for n, g := range geometries {
+		ys, xs := variantAxes(g, variants, height, width)
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				covering = coveringVariants(ys, xs, y, x, covering)
+				dst := n*res.strides[0] + y*width + x
+				for _, v := range covering {
+					ry, rx := ys[v][y], xs[v][x]
+					b := (n*len(variants) + v) * sb
+					for c := 0; c < classes; c++ {
+						top := b + c*sc + ry.lo*sh
+						bottom := b + c*sc + ry.hi*sh
+						lo, hi := rx.lo*sw, rx.hi*sw
+						t := scores.data[top+lo] + (scores.data[top+hi]-scores.data[top+lo])*rx.frac
+						u := scores.data[bottom+lo] + (scores.data[bottom+hi]-scores.data[bottom+lo])*rx.frac
+						res.data[dst+c*res.strides[1]] += t + (u-t)*ry.frac
+					}
+				}
+				for c := 0; c < classes; c++ {
+					res.data[dst+c*res.strides[1]] /= float32(len(covering))
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+// mergeVariantMasks merges the HW class masks of the variants of every image onto the output of the image placed
+// as without augmentation, each pixel taking the class most variants vote for. The variants are unflipped and
+// resampled to the nearest class, and the ties go to the lowest class. The negative classes do not vote.
+func mergeVariantMasks(masks []int32, height, width int, geometries []imageGeometry, variants []augmentationVariant) ([]int32, error) {
+	if len(masks) != len(geometries)*len(variants)*height*width {
+		return nil, errors.Errorf("the outputs hold %v masks of %vx%v, but %v variants of %v images were preprocessed",
+			len(masks)/maxInt(1, height*width), width, height, len(variants), len(geometries))
+	}
+	if err := checkGeometries(geometries, len(geometries)); err != nil {
+		return nil, err
+	}
+	var classes int32
+	for _, class := range masks {
+		if class >= classes {
+			classes = class + 1
+		}
+	}
+
+	res := make([]int32, len(geometries)*height*width)
+	votes := make([]int, maxInt(int(classes), 1))
+	covering := make([]int, 0, len(variants))
+	for n, g := range geometries {
+		ys, xs := variantAxes(g, variants, height, width)
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				covering = coveringVariants(ys, xs, y, x, covering)
+				for _, v := range covering {
+					if class := masks[((n*len(variants)+v)*height+ys[v][y].nearest())*width+xs[v][x].nearest()]; class >= 0 {
+						votes[class]++
+					}
+				}
+				best := int32(backgroundClass)
+				for class, count := range votes {
+					if count > votes[best] {
+						best = int32(class)
+					}
+				}
+				res[(n*height+y)*width+x] = best
+				for class := range votes {
+					votes[class] = 0
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,282 @@
+package predictor
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"sync/atomic"
+	"testing"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/options"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+// augmentationInputs returns the image input of a model declaring the augmentations, whose images are stretched
+// to height x width pixels in the CHW layout and are not normalized.
+func augmentationInputs(augmentations string, height, width int) []*dlframework.ModelManifest_Type {
+	return []*dlframework.ModelManifest_Type{{
+		Type: "image",
+		Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+			"dimensions":    {Value: fmt.Sprintf("[3, %v, %v]", height, width)},
+			"layout":        {Value: "CHW"},
+			"mean":          {Value: "[0, 0, 0]"},
+			"scale":         {Value: "1"},
+			"augmentations": {Value: augmentations},
+		},
+	}}
+}
+
+// pixelImage returns an image of a row of RGB pixels.
+func pixelImage(pixels ...color.RGBA) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, len(pixels), 1))
+	for x, c := range pixels {
+		img.Set(x, 0, c)
+	}
+	return img
+}
+
+func TestImageAugmentationOptions(t *testing.T) {
+	base := common.Base{Model: dlframework.ModelManifest{Name: "fake", Inputs: augmentationInputs("[hflip, five_crop, multi_scale]", 2, 2)}}
+	base.Model.Inputs[0].Parameters["augmentation_scales"] = &dlframework.ModelManifest_Type_Parameter{Value: "[1, 1.5]"}
+
+	opts, err := imageAugmentationOptions(base)
+	assert.NoError(t, err)
+	assert.False(t, opts.enabled)
+	assert.Equal(t, 1, opts.count())
+	if assert.Len(t, opts.variants, 20) {
+		assert.Equal(t, augmentationVariant{scale: 1, crop: cropCenter}, opts.variants[0])
+		assert.Equal(t, augmentationVariant{scale: 1, crop: cropCenter, flip: true}, opts.variants[1])
+		// the corner crops are cut from the stretched image enlarged by 1/0.875
+		assert.Equal(t, augmentationVariant{scale: 1 / 0.875, crop: cropTopLeft}, opts.variants[2])
+		assert.Equal(t, augmentationVariant{scale: 1.5 / 0.875, crop: cropBottomRight, flip: true}, opts.variants[19])
+	}
+
+	opts, err = imageAugmentationOptions(base, options.New(TestTimeAugmentation(true)).Context())
+	assert.NoError(t, err)
+	assert.Equal(t, 20, opts.count())
+
+	base.Model.Inputs[0].Parameters["test_time_augmentation"] = &dlframework.ModelManifest_Type_Parameter{Value: "true"}
+	base.Model.Inputs[0].Parameters["augmentations"] = &dlframework.ModelManifest_Type_Parameter{Value: "[ten_crop]"}
+	opts, err = imageAugmentationOptions(base)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, opts.count())
+	opts, err = imageAugmentationOptions(base, options.New(TestTimeAugmentation(false)).Context())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, opts.count())
+
+	base.Model.Inputs[0].Parameters["resize_mode"] = &dlframework.ModelManifest_Type_Parameter{Value: "center_crop"}
+	base.Model.Inputs[0].Parameters["crop_ratio"] = &dlframework.ModelManifest_Type_Parameter{Value: "0.8"}
+	opts, err = imageAugmentationOptions(base)
+	assert.NoError(t, err)
+	assert.Equal(t, augmentationVariant{scale: 1, crop: cropTopLeft}, opts.variants[2])
+
+	base.Model.Inputs[0].Parameters["augmentations"] = &dlframework.ModelManifest_Type_Parameter{Value: "[vflip]"}
+	_, err = imageAugmentationOptions(base)
+	assert.Error(t, err)
+	base.Model.Inputs[0].Parameters["augmentations"] = &dlframework.ModelManifest_Type_Parameter{Value: "[multi_scale]"}
+	base.Model.Inputs[0].Parameters["augmentation_scales"] = &dlframework.ModelManifest_Type_Parameter{Value: "[1, -1]"}
+	_, err = imageAugmentationOptions(base)
+	assert.Error(t, err)
+}
+
+func TestAugmentationVariantGeometry(t *testing.T) {
+	// the 6x6 resized image is center cropped to the 4x4 input
+	g := imageGeometry{srcWidth: 12, srcHeight: 12, width: 4, height: 4, resizedWidth: 6, resizedHeight: 6, offsetX: -1, offsetY: -1}
+
+	assert.Equal(t, g, augmentationVariant{scale: 1}.geometry(g))
+	tl := augmentationVariant{scale: 1, crop: cropTopLeft}.geometry(g)
+	assert.Equal(t, []int{0, 0}, []int{tl.offsetX, tl.offsetY})
+	br := augmentationVariant{scale: 1, crop: cropBottomRight}.geometry(g)
+	assert.Equal(t, []int{-2, -2}, []int{br.offsetX, br.offsetY})
+	tr := augmentationVariant{scale: 1, crop: cropTopRight}.geometry(g)
+	assert.Equal(t, []int{-2, 0}, []int{tr.offsetX, tr.offsetY})
+
+	// the scaled image keeps its center
+	half := augmentationVariant{scale: 0.5}.geometry(g)
+	assert.Equal(t, []int{3, 3, 0, 0}, []int{half.resizedWidth, half.resizedHeight, half.offsetX, half.offsetY})
+}
+
+func TestPreprocessVariants(t *testing.T) {
+	model := dlframework.ModelManifest{Name: "fake", Inputs: augmentationInputs("[hflip]", 1, 2)}
+	pre, err := NewImagePreprocessor(model)
+	assert.NoError(t, err)
+
+	img := pixelImage(color.RGBA{R: 10, G: 20, B: 30, A: 255}, color.RGBA{R: 40, G: 50, B: 60, A: 255})
+	variants := []augmentationVariant{{scale: 1}, {scale: 1, flip: true}}
+	input, sources, err := pre.preprocessVariants(context.Background(), []image.Image{img, img}, variants)
+	assert.NoError(t, err)
+	assert.Equal(t, gotensor.Shape{4, 3, 1, 2}, input.Shape())
+	assert.Equal(t, []float32{
+		10, 40, 20, 50, 30, 60,
+		40, 10, 50, 20, 60, 30,
+		10, 40, 20, 50, 30, 60,
+		40, 10, 50, 20, 60, 30,
+	}, input.Data())
+	if assert.Len(t, sources, 2) {
+		assert.Equal(t, imageGeometry{srcWidth: 2, srcHeight: 1, width: 2, height: 1, resizedWidth: 2, resizedHeight: 1}, sources[0].geometry)
+	}
+
+	_, _, err = pre.preprocessVariants(context.Background(), []image.Image{img}, nil)
+	assert.Error(t, err)
+}
+
+func TestPreprocessFiveCrops(t *testing.T) {
+	base := common.Base{Model: dlframework.ModelManifest{Name: "fake", Inputs: augmentationInputs("[five_crop]", 7, 7)}}
+	opts, err := imageAugmentationOptions(base)
+	assert.NoError(t, err)
+	pre, err := NewImagePreprocessor(base.Model)
+	assert.NoError(t, err)
+
+	img := image.NewRGBA(image.Rect(0, 0, 7, 7))
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 7; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(30 * x), G: uint8(30 * y), A: 255})
+		}
+	}
+	input, _, err := pre.preprocessVariants(context.Background(), []image.Image{img}, opts.variants)
+	assert.NoError(t, err)
+	assert.Equal(t, gotensor.Shape{5, 3, 7, 7}, input.Shape())
+
+	// the stretched image is enlarged to 8x8 pixels, so that the crops differ
+	data := input.Data().([]float32)
+	size := len(data) / 5
+	for ii := 0; ii < 5; ii++ {
+		for jj := ii + 1; jj < 5; jj++ {
+			assert.NotEqual(t, data[ii*size:(ii+1)*size], data[jj*size:(jj+1)*size], "crops %v and %v", ii, jj)
+		}
+	}
+}
+
+func TestAverageVariants(t *testing.T) {
+	res, batch, err := averageVariants([]float32{1, 2, 3, 4, 5, 6, 7, 8}, 4, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, batch)
+	assert.Equal(t, []float32{2, 3, 6, 7}, res)
+
+	res, batch, err = averageVariants([]float32{1, 2}, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, batch)
+	assert.Equal(t, []float32{1, 2}, res)
+
+	_, _, err = averageVariants([]float32{1, 2, 3}, 3, 2)
+	assert.Error(t, err)
+}
+
+func TestVariantAxis(t *testing.T) {
+	// the reference maps onto itself, and the mirrored variant onto the mirrored pixels
+	for o, s := range variantAxis(4, 0, 4, 0, 4, 4, false) {
+		assert.Equal(t, o, s.lo)
+		assert.Zero(t, s.frac)
+		assert.True(t, s.covered)
+	}
+	for o, s := range variantAxis(4, 0, 4, 0, 4, 2, true) {
+		assert.Equal(t, 1-o, s.nearest())
+		assert.True(t, s.covered)
+	}
+
+	// the image twice as large centered on the input covers the middle of the reference
+	samples := variantAxis(4, 0, 8, -2, 4, 4, false)
+	assert.Equal(t, []bool{false, true, true, false}, []bool{samples[0].covered, samples[1].covered, samples[2].covered, samples[3].covered})
+	assert.Equal(t, axisSample{lo: 0, hi: 1, frac: 0.5}, samples[1].axisSample)
+	assert.Equal(t, axisSample{lo: 2, hi: 3, frac: 0.5}, samples[2].axisSample)
+}
+
+func TestMergeVariantScores(t *testing.T) {
+	geometries := []imageGeometry{identityGeometry(2, 1)}
+	variants := []augmentationVariant{{scale: 1}, {scale: 1, flip: true}}
+
+	// the mirrored variant is unflipped before the average
+	output := gotensor.New(gotensor.WithShape(2, 2, 1, 2), gotensor.WithBacking([]float32{
+		1, 2, 3, 4,
+		6, 5, 8, 7,
+	}))
+	scores, err := newFloat32View(output, 4)
+	assert.NoError(t, err)
+	merged, err := mergeVariantScores(scores, geometries, variants)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 1, 2}, merged.shape)
+	assert.Equal(t, []float32{3, 4, 5, 6}, merged.data)
+
+	_, err = mergeVariantScores(scores, geometries, variants[:1])
+	assert.Error(t, err)
+}
+
+func TestMergeVariantMasks(t *testing.T) {
+	geometries := []imageGeometry{identityGeometry(3, 1)}
+	variants := []augmentationVariant{{scale: 1}, {scale: 1, flip: true}, {scale: 1}}
+
+	masks := []int32{
+		1, 2, 3,
+		3, 5, 1,
+		1, 4, -1,
+	}
+	merged, err := mergeVariantMasks(masks, 1, 3, geometries, variants)
+	assert.NoError(t, err)
+	// the middle pixel is a tie won by the lowest class, and -1 does not vote
+	assert.Equal(t, []int32{1, 2, 3}, merged)
+
+	_, err = mergeVariantMasks(masks[:6], 1, 3, geometries, variants)
+	assert.Error(t, err)
+}
+
+func TestImageClassificationAugmentation(t *testing.T) {
+	labels := []string{"r0", "r1", "g0", "g1", "b0", "b1"}
+	p, inferences := openFakeImageClassificationPredictor(t, labels)
+	p.Model.Inputs = augmentationInputs("[hflip]", 1, 2)
+	ctx := context.Background()
+
+	// the fake model returns its inputs, one class per pixel and channel
+	imgs := []image.Image{pixelImage(color.RGBA{R: 10, G: 20, B: 90, A: 255}, color.RGBA{R: 40, G: 50, B: 60, A: 255})}
+	features, outputs, err := p.InferAndDecode(ctx, imgs, TestTimeAugmentation(true))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(inferences))
+	if assert.Len(t, outputs, 1) {
+		assert.Equal(t, gotensor.Shape{2, 3, 1, 2}, outputs[0].Shape())
+	}
+	if assert.Len(t, features, 1) {
+		assert.Len(t, features[0], len(labels))
+		assert.InDelta(t, 75, features[0][0].GetProbability(), 1e-4)
+	}
+
+	features, _, err = p.InferAndDecode(ctx, imgs)
+	assert.NoError(t, err)
+	if assert.Len(t, features, 1) {
+		assert.Equal(t, "b0", features[0][0].GetClassification().GetLabel())
+		assert.InDelta(t, 90, features[0][0].GetProbability(), 1e-4)
+	}
+
+	_, _, err = p.InferAndDecode(ctx, []gotensor.Tensor{oneHot([]int{0}, len(labels))}, TestTimeAugmentation(true))
+	assert.Error(t, err)
+}
+
+func TestSemanticSegmentationAugmentation(t *testing.T) {
+	ctx := context.Background()
+	p := &SemanticSegmentationPredictor{}
+	p.Base = segmentationBase(nil)
+	p.Model.Inputs = augmentationInputs("[hflip]", 1, 2)
+	p.Model.Inputs[0].Parameters["test_time_augmentation"] = &dlframework.ModelManifest_Type_Parameter{Value: "true"}
+	assert.NoError(t, p.loadModelFiles(ctx))
+
+	// the scores of the identity model are the channels of the pixels, and are unflipped before the average
+	imgs := []image.Image{pixelImage(color.RGBA{R: 100, A: 255}, color.RGBA{B: 60, A: 255})}
+	inputs, decoder, err := p.prepare(ctx, imgs)
+	assert.NoError(t, err)
+	assert.Equal(t, gotensor.Shape{2, 3, 1, 2}, inputs[0].Shape())
+	features, err := decoder(ctx, inputs)
+	assert.NoError(t, err)
+	if assert.Len(t, features, 1) {
+		assert.Equal(t, []int32{0, 2}, features[0][0].GetSemanticSegment().GetIntMask())
+	}
+
+	// the masks of the variants vote
+	p.Base.Model.Output.Parameters["element_type"] = &dlframework.ModelManifest_Type_Parameter{Value: "int64"}
+	features, err = decoder(ctx, []gotensor.Tensor{gotensor.New(gotensor.WithShape(2, 1, 2), gotensor.WithBacking([]int64{3, 1, 1, 3}))})
+	assert.NoError(t, err)
+	if assert.Len(t, features, 1) {
+		assert.Equal(t, []int32{3, 1}, features[0][0].GetSemanticSegment().GetIntMask())
+	}
+}
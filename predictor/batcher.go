@@ -0,0 +1,279 @@
+package predictor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/options"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/c3sr/tracer"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// defaultMaxBatchLatency is the time a Batcher waits for more requests when MaxBatchLatency is not set.
+const defaultMaxBatchLatency = 5 * time.Millisecond
+
+// ErrBatcherClosed is returned by the requests sent to a closed Batcher.
+var ErrBatcherClosed = errors.New("the batcher is closed")
+
+// batchPredictor is the predictor run by a Batcher. The predictors of this package implement it.
+type batchPredictor interface {
+	Infer(ctx context.Context, inputs []gotensor.Tensor) ([]gotensor.Tensor, error)
+	decode(ctx context.Context, outputs []gotensor.Tensor, rows int) ([]dlframework.Features, error)
+}
+
+// Batcher collects the requests sent to a predictor into dynamic batches.
+// A batch is run once it holds the batch size of the predictor, or once the max batch latency
+// elapsed since its first request. The inputs of the requests are concatenated along their first
+// dimension, run through one inference, and the features are split back to each request.
+//
+// Only the requests whose inputs share their data types and their shapes past the first dimension
+// are batched together. A batch flushed by the max batch latency holds fewer rows than the batch size,
+// and is decoded as such. The batches are decoded with the postprocessing options of the manifest and
+// of the Load of the predictor, the per-request options are not supported.
+type Batcher struct {
+	predictor  batchPredictor
+	maxBatch   int
+	maxLatency time.Duration
+	requests   chan *batchRequest
+	done       chan struct{}
+	closeOnce  sync.Once
+	wg         sync.WaitGroup
+}
+
+type batchRequest struct {
+	inputs []gotensor.Tensor
+	rows   int
+	key    string
+	result chan batchResult
+}
+
+type batchResult struct {
+	features []dlframework.Features
+	err      error
+}
+
+// NewBatcher starts batching the requests of the predictor, up to the batch size it was loaded with.
+// The max batch latency is set through the MaxBatchLatency option.
+func NewBatcher(predictor common.Predictor, opts ...options.Option) (*Batcher, error) {
+	bp, ok := predictor.(batchPredictor)
+	if !ok {
+		return nil, errors.Errorf("the predictor of type %T does not support dynamic batching", predictor)
+	}
+
+	predictionOpts, err := predictor.GetPredictionOptions()
+	if err != nil {
+		return nil, err
+	}
+	maxBatch := predictionOpts.BatchSize()
+	if maxBatch < 1 {
+		return nil, errors.Errorf("the batch size must be positive, but got %v", maxBatch)
+	}
+
+	maxLatency := defaultMaxBatchLatency
+	if ctx := options.New(opts...).Context(); ctx != nil {
+		if val, ok := ctx.Value(maxBatchLatencyKey{}).(time.Duration); ok {
+			maxLatency = val
+		}
+	}
+
+	b := &Batcher{
+		predictor:  bp,
+		maxBatch:   maxBatch,
+		maxLatency: maxLatency,
+		requests:   make(chan *batchRequest),
+		done:       make(chan struct{}),
+	}
+	b.wg.Add(1)
+	go b.loop()
+
+	return b, nil
+}
+
+// Predict runs the inputs in the next batch and returns the features of their rows.
+// The inputs hold one or more rows along their first dimension, up to the batch size.
+// They are the input tensors of the model, which run through Infer: Predict takes no per-request options,
+// and runs neither the test-time augmentation nor the tiling, even when the manifest enables them.
+// Predict is safe for concurrent use.
+func (b *Batcher) Predict(ctx context.Context, inputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	req, err := newBatchRequest(inputs, b.maxBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case b.requests <- req:
+	case <-b.done:
+		return nil, ErrBatcherClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case res := <-req.result:
+		return res.features, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops the batcher once the batches already collected are run.
+// It does not close the predictor.
+func (b *Batcher) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.done)
+	})
+	b.wg.Wait()
+	return nil
+}
+
+func newBatchRequest(inputs []gotensor.Tensor, maxBatch int) (*batchRequest, error) {
+	if len(inputs) == 0 {
+		return nil, errors.New("input data nil")
+	}
+
+	rows := -1
+	key := ""
+	for ii, input := range inputs {
+		if _, ok := input.(*gotensor.Dense); !ok {
+			return nil, errors.Errorf("input %v is not a dense tensor", ii)
+		}
+		shape := input.Shape()
+		if len(shape) == 0 {
+			return nil, errors.Errorf("input %v is a scalar, expecting a batch dimension", ii)
+		}
+		if rows != -1 && shape[0] != rows {
+			return nil, errors.Errorf("input %v has %v rows, expecting %v", ii, shape[0], rows)
+		}
+		rows = shape[0]
+		key += fmt.Sprintf("%v%v;", input.Dtype(), []int(shape[1:]))
+	}
+	if rows < 1 || rows > maxBatch {
+		return nil, errors.Errorf("expecting between 1 and %v rows, but got %v", maxBatch, rows)
+	}
+
+	return &batchRequest{
+		inputs: inputs,
+		rows:   rows,
+		key:    key,
+		result: make(chan batchResult, 1),
+	}, nil
+}
+
+// loop collects the requests into batches and starts running them.
+// A request that does not fit the batch being collected starts the next one.
+func (b *Batcher) loop() {
+	defer b.wg.Done()
+
+	var next *batchRequest
+	for {
+		first := next
+		next = nil
+		if first == nil {
+			select {
+			case first = <-b.requests:
+			case <-b.done:
+				return
+			}
+		}
+
+		batch := []*batchRequest{first}
+		rows := first.rows
+		timer := time.NewTimer(b.maxLatency)
+	collect:
+		for rows < b.maxBatch {
+			select {
+			case req := <-b.requests:
+				if req.key != first.key || rows+req.rows > b.maxBatch {
+					next = req
+					break collect
+				}
+				batch = append(batch, req)
+				rows += req.rows
+			case <-timer.C:
+				break collect
+			case <-b.done:
+				break collect
+			}
+		}
+		timer.Stop()
+
+		b.wg.Add(1)
+		go b.run(batch, rows)
+	}
+}
+
+// run runs the batch and hands its features back to the requests.
+func (b *Batcher) run(batch []*batchRequest, rows int) {
+	defer b.wg.Done()
+
+	features, err := b.runBatch(batch, rows)
+	offset := 0
+	for _, req := range batch {
+		if err != nil {
+			req.result <- batchResult{err: err}
+			continue
+		}
+		req.result <- batchResult{features: features[offset : offset+req.rows]}
+		offset += req.rows
+	}
+}
+
+func (b *Batcher) runBatch(batch []*batchRequest, rows int) ([]dlframework.Features, error) {
+	span, ctx := tracer.StartSpanFromContext(
+		context.Background(),
+		tracer.APPLICATION_TRACE,
+		"dynamic_batch",
+		opentracing.Tags{
+			"requests":   len(batch),
+			"batch_size": rows,
+		},
+	)
+	defer span.Finish()
+
+	inputs, err := concatBatchInputs(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := b.predictor.Infer(ctx, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	features, err := b.predictor.decode(ctx, outputs, rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(features) != rows {
+		return nil, errors.Errorf("the predictor returned the features of %v rows, expecting %v", len(features), rows)
+	}
+
+	return features, nil
+}
+
+// concatBatchInputs concatenates the inputs of the requests along their first dimension.
+func concatBatchInputs(batch []*batchRequest) ([]gotensor.Tensor, error) {
+	inputs := make([]gotensor.Tensor, len(batch[0].inputs))
+	for ii := range inputs {
+		if len(batch) == 1 {
+			inputs[ii] = batch[0].inputs[ii]
+			continue
+		}
+		others := make([]gotensor.Tensor, len(batch)-1)
+		for jj, req := range batch[1:] {
+			others[jj] = req.inputs[ii]
+		}
+		input, err := gotensor.Concat(0, batch[0].inputs[ii], others...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot concatenate input %v", ii)
+		}
+		inputs[ii] = input
+	}
+	return inputs, nil
+}
@@ -0,0 +1,192 @@
+package predictor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/options"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+// countingTorchModule counts the inferences run on the module.
+type countingTorchModule struct {
+	torchModule
+	inferences *int32
+}
+
+func (m countingTorchModule) Predict(ctx context.Context, inputs []gotensor.Tensor) error {
+	atomic.AddInt32(m.inferences, 1)
+	return m.torchModule.Predict(ctx, inputs)
+}
+
+// openFakeImageClassificationPredictor loads an image classification predictor whose model returns its inputs,
+// one row of class scores per image, and returns the number of inferences it ran.
+func openFakeImageClassificationPredictor(t *testing.T, labels []string, opts ...options.Option) (*ImageClassificationPredictor, *int32) {
+	withFakeTorchModules(t)
+	inferences := new(int32)
+	newFakeModule := newTorchModule
+	newTorchModule = func(ctx context.Context, opts ...options.Option) (torchModule, error) {
+		module, err := newFakeModule(ctx, opts...)
+		return countingTorchModule{torchModule: module, inferences: inferences}, err
+	}
+
+	dir, err := ioutil.TempDir("", "batcher")
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "fake.features"), []byte(strings.Join(labels, "\n")), 0644))
+
+	p := &ImageClassificationPredictor{
+		ImagePredictor: common.ImagePredictor{
+			Base: common.Base{
+				Model:   dlframework.ModelManifest{Name: "fake"},
+				WorkDir: dir,
+				Options: options.New(opts...),
+			},
+		},
+	}
+	assert.NoError(t, p.open(context.Background(), p.Base, p))
+	t.Cleanup(func() {
+		p.Close()
+	})
+
+	return p, inferences
+}
+
+// openFakeObjectDetectionPredictor loads an object detection predictor whose model returns its inputs,
+// the boxes, the classes and the scores of the detections of every image.
+func openFakeObjectDetectionPredictor(t *testing.T, labels []string, opts ...options.Option) *ObjectDetectionPredictor {
+	withFakeTorchModules(t)
+
+	dir, err := ioutil.TempDir("", "batcher")
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "fake.features"), []byte(strings.Join(labels, "\n")), 0644))
+
+	p := &ObjectDetectionPredictor{
+		ImagePredictor: common.ImagePredictor{
+			Base: common.Base{
+				Model: dlframework.ModelManifest{
+					Name: "fake",
+					Output: &dlframework.ModelManifest_Type{
+						Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+							"boxes_layer":         {Value: "0"},
+							"classes_layer":       {Value: "1"},
+							"probabilities_layer": {Value: "2"},
+						},
+					},
+				},
+				WorkDir: dir,
+				Options: options.New(opts...),
+			},
+		},
+	}
+	assert.NoError(t, p.open(context.Background(), p.Base, p))
+	t.Cleanup(func() {
+		p.Close()
+	})
+
+	return p
+}
+
+func oneHot(rows []int, numClasses int) gotensor.Tensor {
+	data := make([]float32, len(rows)*numClasses)
+	for ii, class := range rows {
+		data[ii*numClasses+class] = 10
+	}
+	return gotensor.New(gotensor.WithShape(len(rows), numClasses), gotensor.WithBacking(data))
+}
+
+func TestBatcherFillsBatches(t *testing.T) {
+	labels := []string{"cat", "dog", "fish", "bird"}
+	p, inferences := openFakeImageClassificationPredictor(t, labels, options.BatchSize(4))
+
+	b, err := NewBatcher(p, MaxBatchLatency(time.Minute))
+	assert.NoError(t, err)
+	defer b.Close()
+
+	var wg sync.WaitGroup
+	for ii := 0; ii < 4; ii++ {
+		wg.Add(1)
+		go func(ii int) {
+			defer wg.Done()
+			features, err := b.Predict(context.Background(), []gotensor.Tensor{oneHot([]int{ii}, 4)})
+			assert.NoError(t, err)
+			if assert.Len(t, features, 1) {
+				assert.Equal(t, labels[ii], features[0][0].GetClassification().GetLabel())
+			}
+		}(ii)
+	}
+	wg.Wait()
+
+	// the four requests filled one batch, without waiting for the latency
+	assert.Equal(t, int32(1), atomic.LoadInt32(inferences))
+}
+
+func TestBatcherLatency(t *testing.T) {
+	labels := []string{"cat", "dog", "fish", "bird"}
+	p, inferences := openFakeImageClassificationPredictor(t, labels, options.BatchSize(8))
+
+	b, err := NewBatcher(p, MaxBatchLatency(10*time.Millisecond))
+	assert.NoError(t, err)
+
+	features, err := b.Predict(context.Background(), []gotensor.Tensor{oneHot([]int{2, 1}, 4)})
+	assert.NoError(t, err)
+	if assert.Len(t, features, 2) {
+		assert.Equal(t, "fish", features[0][0].GetClassification().GetLabel())
+		assert.Equal(t, "dog", features[1][0].GetClassification().GetLabel())
+	}
+
+	// the requests of different shapes run in different batches
+	features, err = b.Predict(context.Background(), []gotensor.Tensor{oneHot([]int{1}, 2)})
+	assert.NoError(t, err)
+	assert.Len(t, features, 1)
+	assert.Equal(t, int32(2), atomic.LoadInt32(inferences))
+
+	_, err = b.Predict(context.Background(), []gotensor.Tensor{oneHot(make([]int, 9), 4)})
+	assert.Error(t, err)
+	_, err = b.Predict(context.Background(), nil)
+	assert.Error(t, err)
+
+	assert.NoError(t, b.Close())
+	assert.NoError(t, b.Close())
+	_, err = b.Predict(context.Background(), []gotensor.Tensor{oneHot([]int{0}, 4)})
+	assert.Equal(t, ErrBatcherClosed, err)
+}
+
+func TestBatcherPartialDetectionBatch(t *testing.T) {
+	labels := []string{"cat", "dog", "fish", "bird"}
+	p := openFakeObjectDetectionPredictor(t, labels, options.BatchSize(4))
+
+	b, err := NewBatcher(p, MaxBatchLatency(10*time.Millisecond))
+	assert.NoError(t, err)
+	defer b.Close()
+
+	// the batch is flushed by the latency with 2 images of one detection each, out of a batch size of 4
+	boxes := gotensor.New(gotensor.WithShape(2, 1, 4), gotensor.WithBacking([]float32{0, 0, 1, 1, 0, 0, 2, 2}))
+	classes := gotensor.New(gotensor.WithShape(2, 1), gotensor.WithBacking([]float32{2, 3}))
+	scores := gotensor.New(gotensor.WithShape(2, 1), gotensor.WithBacking([]float32{0.9, 0.8}))
+	features, err := b.Predict(context.Background(), []gotensor.Tensor{boxes, classes, scores})
+	assert.NoError(t, err)
+	if assert.Len(t, features, 2) {
+		if assert.Len(t, features[0], 1) {
+			assert.Equal(t, "fish", features[0][0].GetBoundingBox().GetLabel())
+		}
+		if assert.Len(t, features[1], 1) {
+			assert.Equal(t, "bird", features[1][0].GetBoundingBox().GetLabel())
+		}
+	}
+}
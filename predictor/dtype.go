@@ -0,0 +1,58 @@
+package predictor
+
+import (
+	"strings"
+
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// elementTypeDtypes maps the element types of the manifests to the dtypes of the tensors holding them.
+// The PyTorch binding reads no half precision tensors, float16 is not one of them.
+var elementTypeDtypes = map[string]gotensor.Dtype{
+	"float32": gotensor.Float32,
+	"float":   gotensor.Float32,
+	"float64": gotensor.Float64,
+	"double":  gotensor.Float64,
+	"int64":   gotensor.Int64,
+	"int32":   gotensor.Int32,
+	"int16":   gotensor.Int16,
+	"int8":    gotensor.Int8,
+	"uint8":   gotensor.Uint8,
+	"bool":    gotensor.Bool,
+}
+
+// outputElementType returns the element type the manifest declares for the output, in lower case,
+// or an empty string when it is not set.
+func outputElementType(base common.Base) string {
+	return strings.ToLower(strings.TrimSpace(getOutputParameter(base, "element_type")))
+}
+
+// checkOutputElementType returns an error when the output of the model does not hold
+// the element type declared by the manifest.
+func checkOutputElementType(base common.Base, index int, output gotensor.Tensor) error {
+	elementType := outputElementType(base)
+	if elementType == "" {
+		return nil
+	}
+	dtype, ok := elementTypeDtypes[elementType]
+	if !ok {
+		return errors.Errorf("model %v declares the unsupported output element_type %v", base.Model.GetName(), elementType)
+	}
+	if output.Dtype() != dtype {
+		return errors.Errorf("output %v of model %v holds %v elements, but the manifest declares element_type %v",
+			index, base.Model.GetName(), output.Dtype(), elementType)
+	}
+	return nil
+}
+
+// outputToFloat32s checks the output of a single output model against the element type of the manifest,
+// and converts it to float32. The models with several outputs of mixed dtypes, such as the detection models,
+// read their outputs with tensorToFloat32s instead, the element type of the manifest describing none of them.
+func outputToFloat32s(base common.Base, index int, output gotensor.Tensor) ([]float32, error) {
+	if err := checkOutputElementType(base, index, output); err != nil {
+		return nil, err
+	}
+	return tensorToFloat32s(output)
+}
@@ -0,0 +1,91 @@
+package predictor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/c3sr/dlframework"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+func elementTypeBase(elementType string) common.Base {
+	params := map[string]*dlframework.ModelManifest_Type_Parameter{}
+	if elementType != "" {
+		params["element_type"] = &dlframework.ModelManifest_Type_Parameter{Value: elementType}
+	}
+	return common.Base{
+		Model: dlframework.ModelManifest{
+			Name:   "fake",
+			Output: &dlframework.ModelManifest_Type{Parameters: params},
+		},
+	}
+}
+
+func TestOutputToFloat32s(t *testing.T) {
+	float64s := gotensor.New(gotensor.WithShape(1, 2), gotensor.WithBacking([]float64{0.5, 2}))
+	int64s := gotensor.New(gotensor.WithShape(1, 2), gotensor.WithBacking([]int64{3, 4}))
+	uint16s := gotensor.New(gotensor.WithShape(1, 2), gotensor.WithBacking([]uint16{0x3800, 0x4000}))
+
+	// without an element type, the output is converted from its own dtype
+	res, err := outputToFloat32s(elementTypeBase(""), 0, float64s)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{0.5, 2}, res)
+
+	res, err = outputToFloat32s(elementTypeBase("int64"), 0, int64s)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{3, 4}, res)
+
+	// the half precision floats are not supported, and the uint16 outputs are not read as their bits
+	_, err = outputToFloat32s(elementTypeBase("float16"), 0, uint16s)
+	assert.Error(t, err)
+	_, err = outputToFloat32s(elementTypeBase(""), 0, uint16s)
+	assert.Error(t, err)
+
+	_, err = outputToFloat32s(elementTypeBase("float32"), 1, int64s)
+	if assert.Error(t, err) {
+		assert.Equal(t, "output 1 of model fake holds int64 elements, but the manifest declares element_type float32", err.Error())
+	}
+	_, err = outputToFloat32s(elementTypeBase("complex64"), 0, float64s)
+	assert.Error(t, err)
+
+	// the 0-d outputs, whose data is a scalar rather than a slice, hold a single element
+	res, err = outputToFloat32s(elementTypeBase("float32"), 0, gotensor.New(gotensor.FromScalar(float32(1))))
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1}, res)
+	_, err = outputToFloat32s(elementTypeBase("float32"), 0, gotensor.New(gotensor.FromScalar(int64(1))))
+	assert.Error(t, err)
+
+	res, err = tensorToFloat32s(gotensor.New(gotensor.WithShape(3), gotensor.WithBacking([]bool{true, false, true})))
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1, 0, 1}, res)
+	res, err = tensorToFloat32s(gotensor.New(gotensor.WithShape(2), gotensor.WithBacking([]int8{-1, 7})))
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{-1, 7}, res)
+}
+
+func TestSemanticSegmentationDecodeDtypes(t *testing.T) {
+	scores := []float64{
+		0, 1,
+		1, 0,
+	}
+	output := gotensor.New(gotensor.WithShape(1, 2, 1, 2), gotensor.WithBacking(scores))
+
+	p := &SemanticSegmentationPredictor{}
+	p.Base = elementTypeBase("float64")
+	features, err := p.decodeOutputs(context.Background(), []gotensor.Tensor{output})
+	assert.NoError(t, err)
+	if assert.Len(t, features, 1) {
+		assert.Equal(t, []int32{1, 0}, features[0][0].GetSemanticSegment().GetIntMask())
+	}
+
+	// a mismatch with the manifest, or an output of another rank, is an error rather than a panic
+	p.Base = elementTypeBase("int64")
+	_, err = p.decodeOutputs(context.Background(), []gotensor.Tensor{output})
+	assert.Error(t, err)
+
+	p.Base = elementTypeBase("")
+	_, err = p.decodeOutputs(context.Background(), []gotensor.Tensor{gotensor.New(gotensor.WithShape(2, 2), gotensor.WithBacking(scores))})
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package predictor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/feature"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// outputBatchSize returns the batch size shared by the outputs, their first dimension.
+func outputBatchSize(outputs []gotensor.Tensor) (int, error) {
+	if len(outputs) == 0 {
+		return 0, errors.New("the model returned no outputs")
+	}
+	batchSize := -1
+	for ii, output := range outputs {
+		shape := output.Shape()
+		if len(shape) == 0 {
+			return 0, errors.Errorf("output %v is a scalar, expecting a batch dimension", ii)
+		}
+		if batchSize != -1 && shape[0] != batchSize {
+			return 0, errors.Errorf("output %v has a batch of %v, expecting %v", ii, shape[0], batchSize)
+		}
+		batchSize = shape[0]
+	}
+	return batchSize, nil
+}
+
+// outputRows splits the backing data of the output along its batch dimension.
+func outputRows(output gotensor.Tensor, batchSize int) ([]interface{}, error) {
+	data := tensorData(output)
+	numElements := output.Shape().TotalSize()
+	if batchSize <= 0 || numElements%batchSize != 0 {
+		return nil, errors.Errorf("cannot split output of shape %v into a batch of %v", output.Shape(), batchSize)
+	}
+	rowSize := numElements / batchSize
+
+	res := make([]interface{}, batchSize)
+	for b := 0; b < batchSize; b++ {
+		switch d := data.(type) {
+		case []float32:
+			res[b] = d[b*rowSize : (b+1)*rowSize]
+		case []float64:
+			res[b] = d[b*rowSize : (b+1)*rowSize]
+		case []int64:
+			res[b] = d[b*rowSize : (b+1)*rowSize]
+		case []int32:
+			res[b] = d[b*rowSize : (b+1)*rowSize]
+		case []int16:
+			res[b] = d[b*rowSize : (b+1)*rowSize]
+		case []int8:
+			res[b] = d[b*rowSize : (b+1)*rowSize]
+		case []uint8:
+			res[b] = d[b*rowSize : (b+1)*rowSize]
+		case []bool:
+			res[b] = d[b*rowSize : (b+1)*rowSize]
+		default:
+			return nil, errors.Errorf("unsupported tensor data type %v", output.Dtype())
+		}
+	}
+	return res, nil
+}
+
+// createRawFeatures returns, for every batch element, one raw feature per output holding the little endian bytes
+// of the element. The dtype and the shape of the element, without the batch dimension, are set in the
+// format and the metadata of the feature.
+func createRawFeatures(outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	batchSize, err := outputBatchSize(outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	features := make([]dlframework.Features, batchSize)
+	for ii, output := range outputs {
+		rows, err := outputRows(output, batchSize)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read output %v", ii)
+		}
+		dtype := output.Dtype().String()
+		shape := fmt.Sprint([]int(output.Shape()[1:]))
+		for b, row := range rows {
+			var buf bytes.Buffer
+			if err := binary.Write(&buf, binary.LittleEndian, row); err != nil {
+				return nil, errors.Wrapf(err, "cannot encode output %v", ii)
+			}
+			features[b] = append(features[b], feature.New(
+				feature.Raw(&dlframework.Raw{
+					Data:   buf.Bytes(),
+					Format: dtype,
+				}),
+				feature.AppendMetadata("output_index", ii),
+				feature.AppendMetadata("dtype", dtype),
+				feature.AppendMetadata("shape", shape),
+			))
+		}
+	}
+
+	return features, nil
+}
+
+// createTextFeatures returns, for every batch element, one text feature per output.
+// Byte outputs are read as utf-8 text, with the trailing zero padding removed,
+// and the other outputs are serialized as a json array.
+func createTextFeatures(outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	batchSize, err := outputBatchSize(outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	features := make([]dlframework.Features, batchSize)
+	for ii, output := range outputs {
+		rows, err := outputRows(output, batchSize)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read output %v", ii)
+		}
+		for b, row := range rows {
+			var text []byte
+			switch r := row.(type) {
+			case []uint8:
+				text = bytes.TrimRight(r, "\x00")
+			case []int8:
+				text = make([]byte, len(r))
+				for jj, v := range r {
+					text[jj] = byte(v)
+				}
+				text = bytes.TrimRight(text, "\x00")
+			default:
+				text, err = json.Marshal(row)
+				if err != nil {
+					return nil, errors.Wrapf(err, "cannot serialize output %v", ii)
+				}
+			}
+			features[b] = append(features[b], feature.New(
+				feature.Text(&dlframework.Text{Data: text}),
+				feature.AppendMetadata("output_index", ii),
+			))
+		}
+	}
+
+	return features, nil
+}
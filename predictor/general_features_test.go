@@ -0,0 +1,66 @@
+package predictor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+func TestCreateRawFeatures(t *testing.T) {
+	outputs := []gotensor.Tensor{
+		gotensor.New(gotensor.WithShape(2, 3), gotensor.WithBacking([]float32{1, 2, 3, 4, 5, 6})),
+		gotensor.New(gotensor.WithShape(2), gotensor.WithBacking([]int64{7, 8})),
+	}
+
+	features, err := createRawFeatures(outputs)
+	assert.NoError(t, err)
+	assert.Len(t, features, 2)
+	assert.Len(t, features[1], 2)
+
+	f := features[1][0]
+	assert.Equal(t, "float32", f.GetRaw().GetFormat())
+	assert.Equal(t, "float32", f.GetMetadata()["dtype"])
+	assert.Equal(t, "[3]", f.GetMetadata()["shape"])
+	row := make([]float32, 3)
+	assert.NoError(t, binary.Read(bytes.NewReader(f.GetRaw().GetData()), binary.LittleEndian, row))
+	assert.Equal(t, []float32{4, 5, 6}, row)
+
+	f = features[1][1]
+	assert.Equal(t, "1", f.GetMetadata()["output_index"])
+	assert.Equal(t, "[]", f.GetMetadata()["shape"])
+	var val int64
+	assert.NoError(t, binary.Read(bytes.NewReader(f.GetRaw().GetData()), binary.LittleEndian, &val))
+	assert.Equal(t, int64(8), val)
+
+	// the outputs of a single element are sliced like the others
+	features, err = createRawFeatures([]gotensor.Tensor{gotensor.New(gotensor.WithShape(1), gotensor.WithBacking([]int64{7}))})
+	assert.NoError(t, err)
+	if assert.Len(t, features, 1) && assert.Len(t, features[0], 1) {
+		assert.NoError(t, binary.Read(bytes.NewReader(features[0][0].GetRaw().GetData()), binary.LittleEndian, &val))
+		assert.Equal(t, int64(7), val)
+	}
+
+	_, err = createRawFeatures([]gotensor.Tensor{
+		outputs[0],
+		gotensor.New(gotensor.WithShape(3), gotensor.WithBacking([]int64{7, 8, 9})),
+	})
+	assert.Error(t, err)
+}
+
+func TestCreateTextFeatures(t *testing.T) {
+	outputs := []gotensor.Tensor{
+		gotensor.New(gotensor.WithShape(2, 4), gotensor.WithBacking([]uint8{'a', 'b', 0, 0, 'c', 'd', 'e', 0})),
+		gotensor.New(gotensor.WithShape(2, 2), gotensor.WithBacking([]float32{1, 2.5, 3, 4})),
+	}
+
+	features, err := createTextFeatures(outputs)
+	assert.NoError(t, err)
+	assert.Len(t, features, 2)
+	assert.Equal(t, "ab", string(features[0][0].GetText().GetData()))
+	assert.Equal(t, "cde", string(features[1][0].GetText().GetData()))
+	assert.Equal(t, "[1,2.5]", string(features[0][1].GetText().GetData()))
+	assert.Equal(t, "[3,4]", string(features[1][1].GetText().GetData()))
+}
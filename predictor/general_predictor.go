@@ -2,18 +2,15 @@ package predictor
 
 import (
 	"context"
+	"strings"
 
 	"github.com/c3sr/config"
 	"github.com/c3sr/dlframework"
 	"github.com/c3sr/dlframework/framework/agent"
 	"github.com/c3sr/dlframework/framework/options"
 	common "github.com/c3sr/dlframework/framework/predictor"
-	"github.com/c3sr/downloadmanager"
-	gopytorch "github.com/c3sr/go-pytorch"
 	"github.com/c3sr/pytorch"
 	"github.com/c3sr/tracer"
-	opentracing "github.com/opentracing/opentracing-go"
-	olog "github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
 	gotensor "gorgonia.org/tensor"
 )
@@ -21,7 +18,8 @@ import (
 // GeneralPredictor ...
 type GeneralPredictor struct {
 	common.Base
-	predictor *gopytorch.Predictor
+	torchPredictor
+	postprocess classificationPostprocessOptions
 }
 
 // NewGeneralPredictor ...
@@ -39,180 +37,139 @@ func NewGeneralPredictor(model dlframework.ModelManifest, os ...options.Option)
 
 // Download ...
 func (p *GeneralPredictor) Download(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) error {
-	framework, err := model.ResolveFramework()
-	if err != nil {
+	if err := checkFirstInputType(model); err != nil {
 		return err
 	}
+	if tp := textPredictor(model); tp != nil {
+		return tp.Download(ctx, model, opts...)
+	}
 
-	workDir, err := model.WorkDir()
+	base, err := newTorchBase(model, opts...)
 	if err != nil {
 		return err
 	}
 
 	gp := &GeneralPredictor{
-		Base: common.Base{
-			Framework: framework,
-			Model:     model,
-			WorkDir:   workDir,
-			Options:   options.New(opts...),
-		},
-	}
-
-	if err = gp.download(ctx); err != nil {
-		return err
+		Base: base,
 	}
 
-	return nil
+	return downloadModel(ctx, gp.Base, gp.modelFiles())
 }
 
 // Load ...
+// The text models are loaded by their own predictor, since dlframework reports the general modality for them.
 func (p *GeneralPredictor) Load(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) (common.Predictor, error) {
-	framework, err := model.ResolveFramework()
-	if err != nil {
+	if err := checkFirstInputType(model); err != nil {
 		return nil, err
 	}
+	if tp := textPredictor(model); tp != nil {
+		return tp.Load(ctx, model, opts...)
+	}
 
-	workDir, err := model.WorkDir()
+	base, err := newTorchBase(model, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	gp := &GeneralPredictor{
-		Base: common.Base{
-			Framework: framework,
-			Model:     model,
-			WorkDir:   workDir,
-			Options:   options.New(opts...),
-		},
-	}
-
-	if err = gp.download(ctx); err != nil {
-		return nil, err
+		Base: base,
 	}
 
-	if err = gp.loadPredictor(ctx); err != nil {
+	if err = gp.load(ctx, gp.Base, gp); err != nil {
 		return nil, err
 	}
 
 	return gp, nil
 }
 
-func (p *GeneralPredictor) download(ctx context.Context) error {
-	span, ctx := tracer.StartSpanFromContext(
-		ctx,
-		tracer.APPLICATION_TRACE,
-		"download",
-		opentracing.Tags{
-			"graph_url":         p.GetGraphUrl(),
-			"target_graph_file": p.GetGraphPath(),
-		},
-	)
-	defer span.Finish()
-
-	model := p.Model
-	if model.Model.IsArchive {
-		baseURL := model.Model.BaseUrl
-		span.LogFields(
-			olog.String("event", "download model archive"),
-		)
-		_, err := downloadmanager.DownloadInto(baseURL, p.WorkDir, downloadmanager.Context(ctx))
-		if err != nil {
-			return errors.Wrapf(err, "failed to download model archive from %v", model.Model.BaseUrl)
-		}
-	} else {
-		span.LogFields(
-			olog.String("event", "download graph"),
-		)
-		checksum := p.GetGraphChecksum()
-		if checksum != "" {
-			if _, _, err := downloadmanager.DownloadFile(p.GetGraphUrl(), p.GetGraphPath(), downloadmanager.MD5Sum(checksum)); err != nil {
-				return err
-			}
-		} else {
-			if _, _, err := downloadmanager.DownloadFile(p.GetGraphUrl(), p.GetGraphPath()); err != nil {
-				return err
-			}
-		}
+// textPredictor returns the predictor of the text models, which take general inputs,
+// based on their output type. It returns nil for the other models.
+func textPredictor(model dlframework.ModelManifest) common.Predictor {
+	switch strings.ToLower(model.GetOutput().GetType()) {
+	case "question_answering":
+		return new(QuestionAnsweringPredictor)
+	case "text_classification":
+		return new(TextClassificationPredictor)
+	case "token_classification":
+		return new(TokenClassificationPredictor)
 	}
-
 	return nil
 }
 
-func (p *GeneralPredictor) loadPredictor(ctx context.Context) error {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "load_predictor")
-	defer span.Finish()
-
-	span.LogFields(
-		olog.String("event", "creating predictor"),
-	)
-
-	opts, err := p.GetPredictionOptions()
-	if err != nil {
-		return err
-	}
-
-	pred, err := gopytorch.New(
-		ctx,
-		options.WithOptions(opts),
-		options.Graph([]byte(p.GetGraphPath())),
-	)
-	if err != nil {
-		return err
+// modelFiles returns the labels of the classification models, the models with a features_url.
+func (p *GeneralPredictor) modelFiles() []modelFile {
+	if p.GetFeaturesUrl() == "" {
+		return nil
 	}
+	return []modelFile{featuresFile(p.Base)}
+}
 
-	p.predictor = pred
+func (p *GeneralPredictor) loadModelFiles(ctx context.Context) error {
+	p.postprocess = p.postprocessOptions()
 
 	return nil
 }
 
-// Predict ...
-func (p *GeneralPredictor) Predict(ctx context.Context, data interface{}, opts ...options.Option) error {
-
-	if data == nil {
-		return errors.New("input data nil")
-	}
-
-	gotensors, ok := data.([]gotensor.Tensor)
-	if !ok {
-		return errors.New("input data is not slice of dense tensors")
+// prepare converts the data into the input tensors of the model.
+// The data is either the input tensors, or the typed inputs declared in the manifest as a []ModelInput,
+// the general inputs of the manifest being given as TensorInput.
+func (p *GeneralPredictor) prepare(ctx context.Context, data interface{}, opts ...options.Option) ([]gotensor.Tensor, outputDecoder, error) {
+	var gotensors []gotensor.Tensor
+	switch in := data.(type) {
+	case []gotensor.Tensor:
+		gotensors = in
+	case []ModelInput:
+		var err error
+		if gotensors, _, err = modelInputTensors(ctx, p.Model, in); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, errors.New("input data is not slice of dense tensors")
 	}
 
-	return p.predictor.Predict(ctx, gotensors)
-}
+	postprocess := p.postprocessOptions(opts...)
 
-// ReadPredictedFeatures ...
-func (p *GeneralPredictor) ReadPredictedFeatures(ctx context.Context) ([]dlframework.Features, error) {
-	return nil, errors.New("Not Implemented.")
+	return gotensors, func(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+		return p.decodeFeatures(ctx, outputs, postprocess)
+	}, nil
 }
 
-// ReadPredictedFeaturesAsMap ...
-func (p *GeneralPredictor) ReadPredictedFeaturesAsMap(ctx context.Context) (map[string]interface{}, error) {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features_as_map")
-	defer span.Finish()
-
-	outputs, err := p.predictor.ReadPredictionOutput(ctx)
-	if err != nil {
-		return nil, err
+// postprocessOptions resolves the probabilities transform and the top-k of the classification outputs.
+func (p *GeneralPredictor) postprocessOptions(opts ...options.Option) classificationPostprocessOptions {
+	res := classificationPostprocessOptions{
+		transform:   getOutputParameter(p.Base, "probabilities_transform"),
+		temperature: getOutputFloat32Parameter(p.Base, "temperature", 1),
+		topK:        getOutputIntParameter(p.Base, "top_k", 0),
 	}
-
-	res := make(map[string]interface{})
-	res["outputs"] = outputs
-
-	return res, nil
+	return res.withOverrides(p.Options.Context()).withOverrides(options.New(opts...).Context())
 }
 
-// Reset ...
-func (p *GeneralPredictor) Reset(ctx context.Context) error {
-	return nil
+// decodeOutputs decodes the outputs with the postprocessing options of the manifest and of the Load.
+func (p *GeneralPredictor) decodeOutputs(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	return p.decodeFeatures(ctx, outputs, p.postprocess)
 }
 
-// Close ...
-func (p *GeneralPredictor) Close() error {
-	if p.predictor != nil {
-		p.predictor.Close()
+// decodeFeatures builds the features from the output type of the manifest.
+// Models with a features_url return the classes of their first output, models with a text output
+// return text features, and the other models return their outputs as raw features.
+func (p *GeneralPredictor) decodeFeatures(ctx context.Context, outputs []gotensor.Tensor, postprocess classificationPostprocessOptions) ([]dlframework.Features, error) {
+	if p.labels != nil {
+		batchSize, err := outputBatchSize(outputs[:1])
+		if err != nil {
+			return nil, err
+		}
+		probabilities, err := outputToFloat32s(p.Base, 0, outputs[0])
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot read the probabilities output")
+		}
+		return createClassificationFeatures(probabilities, batchSize, p.labels, postprocess)
 	}
 
-	return nil
+	if strings.ToLower(p.Model.GetOutput().GetType()) == "text" {
+		return createTextFeatures(outputs)
+	}
+
+	return createRawFeatures(outputs)
 }
 
 // Modality ...
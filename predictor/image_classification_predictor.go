@@ -1,22 +1,15 @@
 package predictor
 
 import (
-	"bufio"
 	"context"
-	"os"
-	"strings"
 
 	"github.com/c3sr/config"
 	"github.com/c3sr/dlframework"
 	"github.com/c3sr/dlframework/framework/agent"
 	"github.com/c3sr/dlframework/framework/options"
 	common "github.com/c3sr/dlframework/framework/predictor"
-	"github.com/c3sr/downloadmanager"
-	gopytorch "github.com/c3sr/go-pytorch"
 	"github.com/c3sr/pytorch"
 	"github.com/c3sr/tracer"
-	opentracing "github.com/opentracing/opentracing-go"
-	olog "github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
 	gotensor "gorgonia.org/tensor"
 )
@@ -24,8 +17,8 @@ import (
 // ImageClassificationPredictor ...
 type ImageClassificationPredictor struct {
 	common.ImagePredictor
-	predictor *gopytorch.Predictor
-	labels    []string
+	torchPredictor
+	postprocess classificationPostprocessOptions
 }
 
 // NewImageClassificationPredictor ...
@@ -36,13 +29,8 @@ func NewImageClassificationPredictor(model dlframework.ModelManifest, os ...opti
 	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "new_predictor")
 	defer span.Finish()
 
-	modelInputs := model.GetInputs()
-	if len(modelInputs) != 1 {
-		return nil, errors.New("number of inputs not supported")
-	}
-	firstInputType := modelInputs[0].GetType()
-	if strings.ToLower(firstInputType) != "image" {
-		return nil, errors.New("input type not supported")
+	if err := checkImageModelInputs(model); err != nil {
+		return nil, err
 	}
 
 	predictor := new(ImageClassificationPredictor)
@@ -52,226 +40,126 @@ func NewImageClassificationPredictor(model dlframework.ModelManifest, os ...opti
 
 // Download ...
 func (p *ImageClassificationPredictor) Download(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) error {
-	framework, err := model.ResolveFramework()
-	if err != nil {
-		return err
-	}
-
-	workDir, err := model.WorkDir()
+	base, err := newTorchBase(model, opts...)
 	if err != nil {
 		return err
 	}
 
 	ip := &ImageClassificationPredictor{
 		ImagePredictor: common.ImagePredictor{
-			Base: common.Base{
-				Framework: framework,
-				Model:     model,
-				WorkDir:   workDir,
-				Options:   options.New(opts...),
-			},
+			Base: base,
 		},
 	}
 
-	if err = ip.download(ctx); err != nil {
-		return err
-	}
-
-	return nil
+	return downloadModel(ctx, ip.Base, ip.modelFiles())
 }
 
 // Load ...
 func (p *ImageClassificationPredictor) Load(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) (common.Predictor, error) {
-	framework, err := model.ResolveFramework()
-	if err != nil {
-		return nil, err
-	}
-
-	workDir, err := model.WorkDir()
+	base, err := newTorchBase(model, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	ip := &ImageClassificationPredictor{
 		ImagePredictor: common.ImagePredictor{
-			Base: common.Base{
-				Framework: framework,
-				Model:     model,
-				WorkDir:   workDir,
-				Options:   options.New(opts...),
-			},
+			Base: base,
 		},
 	}
 
-	if err = ip.download(ctx); err != nil {
-		return nil, err
-	}
-
-	if err = ip.loadPredictor(ctx); err != nil {
+	if err = ip.load(ctx, ip.Base, ip); err != nil {
 		return nil, err
 	}
 
 	return ip, nil
 }
 
-func (p *ImageClassificationPredictor) download(ctx context.Context) error {
-	span, ctx := tracer.StartSpanFromContext(
-		ctx,
-		tracer.APPLICATION_TRACE,
-		"download",
-		opentracing.Tags{
-			"graph_url":           p.GetGraphUrl(),
-			"target_graph_file":   p.GetGraphPath(),
-			"feature_url":         p.GetFeaturesUrl(),
-			"target_feature_file": p.GetFeaturesPath(),
-		},
-	)
-	defer span.Finish()
-
-	model := p.Model
-	if model.Model.IsArchive {
-		baseURL := model.Model.BaseUrl
-		span.LogFields(
-			olog.String("event", "download model archive"),
-		)
-		_, err := downloadmanager.DownloadInto(baseURL, p.WorkDir, downloadmanager.Context(ctx))
-		if err != nil {
-			return errors.Wrapf(err, "failed to download model archive from %v", model.Model.BaseUrl)
-		}
-	} else {
-		span.LogFields(
-			olog.String("event", "download graph"),
-		)
-		checksum := p.GetGraphChecksum()
-		if checksum != "" {
-			if _, _, err := downloadmanager.DownloadFile(p.GetGraphUrl(), p.GetGraphPath(), downloadmanager.MD5Sum(checksum)); err != nil {
-				return err
-			}
-		} else {
-			if _, _, err := downloadmanager.DownloadFile(p.GetGraphUrl(), p.GetGraphPath()); err != nil {
-				return err
-			}
-		}
-	}
+func (p *ImageClassificationPredictor) modelFiles() []modelFile {
+	return []modelFile{featuresFile(p.Base)}
+}
 
-	span.LogFields(
-		olog.String("event", "download features"),
-	)
-	checksum := p.GetFeaturesChecksum()
-	if checksum != "" {
-		if _, _, err := downloadmanager.DownloadFile(p.GetFeaturesUrl(), p.GetFeaturesPath(), downloadmanager.MD5Sum(checksum)); err != nil {
-			return err
-		}
-	} else {
-		if _, _, err := downloadmanager.DownloadFile(p.GetFeaturesUrl(), p.GetFeaturesPath()); err != nil {
-			return err
-		}
+func (p *ImageClassificationPredictor) loadModelFiles(ctx context.Context) error {
+	p.postprocess = p.postprocessOptions()
+	if _, err := p.augmentationOptions(); err != nil {
+		return err
 	}
 
 	return nil
 }
 
-func (p *ImageClassificationPredictor) loadPredictor(ctx context.Context) error {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "load_predictor")
-	defer span.Finish()
-
-	span.LogFields(
-		olog.String("event", "read features"),
-	)
-
-	var labels []string
-	f, err := os.Open(p.GetFeaturesPath())
-	if err != nil {
-		return errors.Wrapf(err, "cannot read %s", p.GetFeaturesPath())
+// postprocessOptions resolves the probabilities transform and the top-k of a request.
+func (p *ImageClassificationPredictor) postprocessOptions(opts ...options.Option) classificationPostprocessOptions {
+	res := classificationPostprocessOptions{
+		transform:   p.GetProbabilitiesTransform(),
+		temperature: getOutputFloat32Parameter(p.Base, "temperature", 1),
+		topK:        getOutputIntParameter(p.Base, "top_k", 0),
 	}
-	defer f.Close()
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		line := scanner.Text()
-		labels = append(labels, line)
-	}
-	p.labels = labels
+	return res.withOverrides(p.Options.Context()).withOverrides(options.New(opts...).Context())
+}
 
-	span.LogFields(
-		olog.String("event", "creating predictor"),
-	)
+// augmentationOptions resolves the test-time augmentation of the images.
+func (p *ImageClassificationPredictor) augmentationOptions(opts ...options.Option) (augmentationOptions, error) {
+	return imageAugmentationOptions(p.Base, p.Options.Context(), options.New(opts...).Context())
+}
 
-	opts, err := p.GetPredictionOptions()
+// prepare converts the data into the input tensors of the model.
+// With the test-time augmentation, the inputs hold the variants of every image, whose outputs the decoder averages.
+func (p *ImageClassificationPredictor) prepare(ctx context.Context, data interface{}, opts ...options.Option) ([]gotensor.Tensor, outputDecoder, error) {
+	postprocess := p.postprocessOptions(opts...)
+	augmentation, err := p.augmentationOptions(opts...)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	pred, err := gopytorch.New(
-		ctx,
-		options.WithOptions(opts),
-		options.Graph([]byte(p.GetGraphPath())),
-	)
+	var gotensors []gotensor.Tensor
+	if augmentation.enabled {
+		gotensors, _, err = preprocessAugmentedInputs(ctx, p.Model, data, augmentation.variants)
+	} else {
+		gotensors, err = imageInputTensors(ctx, p.Model, data)
+	}
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	p.predictor = pred
-
-	return nil
+	return gotensors, func(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+		return p.decodeFeatures(ctx, outputs, augmentation.count(), postprocess)
+	}, nil
 }
 
-// Predict ...
-func (p *ImageClassificationPredictor) Predict(ctx context.Context, data interface{}, opts ...options.Option) error {
-
-	if data == nil {
-		return errors.New("input data nil")
-	}
-
-	gotensors, ok := data.([]gotensor.Tensor)
-	if !ok {
-		return errors.New("input data is not slice of dense tensors")
-	}
-
-	return p.predictor.Predict(ctx, gotensors)
+// decodeOutputs decodes the outputs with the postprocessing options of the manifest and of the Load.
+func (p *ImageClassificationPredictor) decodeOutputs(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	return p.decodeFeatures(ctx, outputs, 1, p.postprocess)
 }
 
-// ReadPredictedFeatures ...
-func (p *ImageClassificationPredictor) ReadPredictedFeatures(ctx context.Context) ([]dlframework.Features, error) {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features")
-	defer span.Finish()
-
-	outputs, err := p.predictor.ReadPredictionOutput(ctx)
+// decodeFeatures builds the classifications from the outputs, averaging the scores of the variants of every image first.
+func (p *ImageClassificationPredictor) decodeFeatures(ctx context.Context, outputs []gotensor.Tensor, variants int,
+	postprocess classificationPostprocessOptions) ([]dlframework.Features, error) {
+	probabilities, err := outputToFloat32s(p.Base, 0, outputs[0])
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "cannot read the probabilities output")
 	}
 
-	return p.CreateClassificationFeatures(ctx, outputs[0], p.labels)
-}
-
-// ReadPredictedFeaturesAsMap ...
-func (p *ImageClassificationPredictor) ReadPredictedFeaturesAsMap(ctx context.Context) (map[string]interface{}, error) {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features_as_map")
-	defer span.Finish()
-
-	outputs, err := p.predictor.ReadPredictionOutput(ctx)
+	// the batch follows the outputs, which hold fewer images than the batch size for the dynamic batches
+	batchSize := p.BatchSize()
+	if shape := outputs[0].Shape(); len(shape) > 1 {
+		batchSize = shape[0]
+	}
+	probabilities, batchSize, err = averageVariants(probabilities, batchSize, variants)
 	if err != nil {
 		return nil, err
 	}
 
-	res := make(map[string]interface{})
-	res["outputs"] = outputs
-	res["labels"] = p.labels
-
-	return res, nil
+	return createClassificationFeatures(probabilities, batchSize, p.labels, postprocess)
 }
 
 // Reset ...
 func (p *ImageClassificationPredictor) Reset(ctx context.Context) error {
-
-	return nil
+	return p.torchPredictor.Reset(ctx)
 }
 
 // Close ...
 func (p *ImageClassificationPredictor) Close() error {
-	if p.predictor != nil {
-		p.predictor.Close()
-	}
-	return nil
+	return p.torchPredictor.Close()
 }
 
 // Modality ...
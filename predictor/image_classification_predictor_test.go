@@ -2,14 +2,11 @@ package predictor
 
 import (
 	"context"
-	"image"
-	"os"
+	"io/ioutil"
 	"path/filepath"
 	"testing"
 
 	"github.com/c3sr/dlframework/framework/options"
-	raiimage "github.com/c3sr/image"
-	"github.com/c3sr/image/types"
 	nvidiasmi "github.com/c3sr/nvidia-smi"
 	py "github.com/c3sr/pytorch"
 	"github.com/k0kubun/pp/v3"
@@ -17,73 +14,6 @@ import (
 	gotensor "gorgonia.org/tensor"
 )
 
-func normalizeImageHWC(in0 image.Image, mean []float32, scale []float32) ([]float32, error) {
-	height := in0.Bounds().Dy()
-	width := in0.Bounds().Dx()
-	out := make([]float32, 3*height*width)
-	switch in := in0.(type) {
-	case *types.RGBImage:
-		for y := 0; y < height; y++ {
-			for x := 0; x < width; x++ {
-				offset := y*in.Stride + x*3
-				rgb := in.Pix[offset : offset+3]
-				r, g, b := rgb[0], rgb[1], rgb[2]
-				out[offset+0] = (float32(r) - mean[0]) / scale[0]
-				out[offset+1] = (float32(g) - mean[1]) / scale[1]
-				out[offset+2] = (float32(b) - mean[2]) / scale[2]
-			}
-		}
-	case *types.BGRImage:
-		for y := 0; y < height; y++ {
-			for x := 0; x < width; x++ {
-				offset := y*in.Stride + x*3
-				bgr := in.Pix[offset : offset+3]
-				b, g, r := bgr[0], bgr[1], bgr[2]
-				out[offset+0] = (float32(b) - mean[0]) / scale[0]
-				out[offset+1] = (float32(g) - mean[1]) / scale[1]
-				out[offset+2] = (float32(r) - mean[2]) / scale[2]
-			}
-		}
-	default:
-		panic("unreachable")
-	}
-
-	return out, nil
-}
-
-func normalizeImageCHW(in0 image.Image, mean []float32, scale []float32) ([]float32, error) {
-	height := in0.Bounds().Dy()
-	width := in0.Bounds().Dx()
-	out := make([]float32, 3*height*width)
-	switch in := in0.(type) {
-	case *types.RGBImage:
-		for y := 0; y < height; y++ {
-			for x := 0; x < width; x++ {
-				offset := y*in.Stride + x*3
-				rgb := in.Pix[offset : offset+3]
-				r, g, b := rgb[0], rgb[1], rgb[2]
-				out[0*width*height+y*width+x] = (float32(r) - mean[0]) / scale[0]
-				out[1*width*height+y*width+x] = (float32(g) - mean[1]) / scale[1]
-				out[2*width*height+y*width+x] = (float32(b) - mean[2]) / scale[2]
-			}
-		}
-	case *types.BGRImage:
-		for y := 0; y < height; y++ {
-			for x := 0; x < width; x++ {
-				offset := y*in.Stride + x*3
-				rgb := in.Pix[offset : offset+3]
-				r, g, b := rgb[0], rgb[1], rgb[2]
-				out[0*width*height+y*width+x] = (float32(b) - mean[0]) / scale[0]
-				out[1*width*height+y*width+x] = (float32(g) - mean[1]) / scale[1]
-				out[2*width*height+y*width+x] = (float32(r) - mean[2]) / scale[2]
-			}
-		}
-	default:
-		panic("unreachable")
-	}
-	return out, nil
-}
-
 func TestPredictorNew(t *testing.T) {
 	py.Register()
 	model, err := py.FrameworkManifest.FindModel("torchvision_alexnet:1.0")
@@ -122,59 +52,30 @@ func TestImageClassification(t *testing.T) {
 	assert.NotEmpty(t, predictor)
 	defer predictor.Close()
 
-	imgDir, _ := filepath.Abs("./_fixtures")
-	imgPath := filepath.Join(imgDir, "platypus.jpg")
-	r, err := os.Open(imgPath)
-	if err != nil {
-		panic(err)
-	}
-
-	preprocessOpts, err := predictor.GetPreprocessOptions()
+	preprocessor, err := NewImagePreprocessor(*model)
 	assert.NoError(t, err)
-	channels := preprocessOpts.Dims[0]
-	height := preprocessOpts.Dims[1]
-	width := preprocessOpts.Dims[2]
-	mode := preprocessOpts.ColorMode
-
-	var imgOpts []raiimage.Option
-	if mode == types.RGBMode {
-		imgOpts = append(imgOpts, raiimage.Mode(types.RGBMode))
-	} else {
-		imgOpts = append(imgOpts, raiimage.Mode(types.BGRMode))
-	}
-
-	img, err := raiimage.Read(r, imgOpts...)
-	if err != nil {
-		panic(err)
-	}
-
-	imgOpts = append(imgOpts, raiimage.Resized(height, width))
-	imgOpts = append(imgOpts, raiimage.ResizeAlgorithm(types.ResizeAlgorithmLinear))
-	resized, err := raiimage.Resize(img, imgOpts...)
 	if err != nil {
-		panic(err)
+		return
 	}
 
-	input := make([]gotensor.Tensor, batchSize)
-	imgFloats, err := normalizeImageCHW(resized, preprocessOpts.MeanImage, preprocessOpts.Scale)
+	imgDir, _ := filepath.Abs("./_fixtures")
+	imgPath := filepath.Join(imgDir, "platypus.jpg")
+	imgBytes, err := ioutil.ReadFile(imgPath)
 	if err != nil {
 		panic(err)
 	}
 
-	for ii := 0; ii < batchSize; ii++ {
-		input[ii] = gotensor.New(
-			gotensor.WithShape(channels, height, width),
-			gotensor.WithBacking(imgFloats),
-		)
+	batch := make([][]byte, batchSize)
+	for ii := range batch {
+		batch[ii] = imgBytes
 	}
-
-	joined, err := gotensor.Concat(0, input[0], input[1:]...)
+	input, err := preprocessor.PreprocessBytes(ctx, batch)
+	assert.NoError(t, err)
 	if err != nil {
 		return
 	}
-	joined.Reshape(append([]int{len(input)}, input[0].Shape()...)...)
 
-	err = predictor.Predict(ctx, []gotensor.Tensor{joined})
+	err = predictor.Predict(ctx, []gotensor.Tensor{input})
 
 	assert.NoError(t, err)
 	if err != nil {
@@ -191,7 +92,7 @@ func TestImageClassification(t *testing.T) {
 	pp.Println("Label: ", pred[0][0].GetClassification().GetLabel())
 	pp.Println("Probability: ", pred[0][0].GetProbability())
 
-	// The value not applied softmax for torchvision alexnet
-	// assert.InDelta(t, float32(15.774), pred[0][0].GetProbability(), 0.001)
+	// The probabilities_transform of the manifest turns the torchvision alexnet logits into probabilities
+	assert.True(t, pred[0][0].GetProbability() >= 0 && pred[0][0].GetProbability() <= 1)
 	// assert.Equal(t, int32(103), pred[0][0].GetClassification().GetIndex())
 }
@@ -10,12 +10,8 @@ import (
 	"github.com/c3sr/dlframework/framework/agent"
 	"github.com/c3sr/dlframework/framework/options"
 	common "github.com/c3sr/dlframework/framework/predictor"
-	"github.com/c3sr/downloadmanager"
-	gopytorch "github.com/c3sr/go-pytorch"
 	"github.com/c3sr/pytorch"
 	"github.com/c3sr/tracer"
-	opentracing "github.com/opentracing/opentracing-go"
-	olog "github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
 	gotensor "gorgonia.org/tensor"
 )
@@ -23,8 +19,9 @@ import (
 // ImageEnhancementPredictor ...
 type ImageEnhancementPredictor struct {
 	common.ImagePredictor
-	predictor *gopytorch.Predictor
-	images    interface{}
+	torchPredictor
+	images      interface{}
+	postprocess imagePostprocessOptions
 }
 
 // NewImageEnhancementPredictor ...
@@ -35,13 +32,8 @@ func NewImageEnhancementPredictor(model dlframework.ModelManifest, os ...options
 	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "new_predictor")
 	defer span.Finish()
 
-	modelInputs := model.GetInputs()
-	if len(modelInputs) != 1 {
-		return nil, errors.New("number of inputs not supported")
-	}
-	firstInputType := modelInputs[0].GetType()
-	if strings.ToLower(firstInputType) != "image" {
-		return nil, errors.New("input type not supported")
+	if err := checkImageModelInputs(model); err != nil {
+		return nil, err
 	}
 
 	predictor := new(ImageEnhancementPredictor)
@@ -51,140 +43,70 @@ func NewImageEnhancementPredictor(model dlframework.ModelManifest, os ...options
 
 // Download ...
 func (p *ImageEnhancementPredictor) Download(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) error {
-	framework, err := model.ResolveFramework()
-	if err != nil {
-		return err
-	}
-
-	workDir, err := model.WorkDir()
+	base, err := newTorchBase(model, opts...)
 	if err != nil {
 		return err
 	}
 
 	ip := &ImageEnhancementPredictor{
 		ImagePredictor: common.ImagePredictor{
-			Base: common.Base{
-				Framework: framework,
-				Model:     model,
-				WorkDir:   workDir,
-				Options:   options.New(opts...),
-			},
+			Base: base,
 		},
 	}
 
-	if err = ip.download(ctx); err != nil {
-		return err
-	}
-
-	return nil
+	return downloadModel(ctx, ip.Base, ip.modelFiles())
 }
 
 // Load ...
 func (p *ImageEnhancementPredictor) Load(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) (common.Predictor, error) {
-	framework, err := model.ResolveFramework()
-	if err != nil {
-		return nil, err
-	}
-
-	workDir, err := model.WorkDir()
+	base, err := newTorchBase(model, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	ip := &ImageEnhancementPredictor{
 		ImagePredictor: common.ImagePredictor{
-			Base: common.Base{
-				Framework: framework,
-				Model:     model,
-				WorkDir:   workDir,
-				Options:   options.New(opts...),
-			},
+			Base: base,
 		},
 	}
 
-	if err = ip.download(ctx); err != nil {
-		return nil, err
-	}
-
-	if err = ip.loadPredictor(ctx); err != nil {
+	if err = ip.load(ctx, ip.Base, ip); err != nil {
 		return nil, err
 	}
 
 	return ip, nil
 }
 
-func (p *ImageEnhancementPredictor) download(ctx context.Context) error {
-	span, ctx := tracer.StartSpanFromContext(
-		ctx,
-		tracer.APPLICATION_TRACE,
-		"download",
-		opentracing.Tags{
-			"graph_url":           p.GetGraphUrl(),
-			"target_graph_file":   p.GetGraphPath(),
-			"feature_url":         p.GetFeaturesUrl(),
-			"target_feature_file": p.GetFeaturesPath(),
-		},
-	)
-	defer span.Finish()
-
-	model := p.Model
-	if model.Model.IsArchive {
-		baseURL := model.Model.BaseUrl
-		span.LogFields(
-			olog.String("event", "download model archive"),
-		)
-		_, err := downloadmanager.DownloadInto(baseURL, p.WorkDir, downloadmanager.Context(ctx))
-		if err != nil {
-			return errors.Wrapf(err, "failed to download model archive from %v", model.Model.BaseUrl)
-		}
-	} else {
-		span.LogFields(
-			olog.String("event", "download graph"),
-		)
-		checksum := p.GetGraphChecksum()
-		if checksum != "" {
-			if _, _, err := downloadmanager.DownloadFile(p.GetGraphUrl(), p.GetGraphPath(), downloadmanager.MD5Sum(checksum)); err != nil {
-				return err
-			}
-		} else {
-			if _, _, err := downloadmanager.DownloadFile(p.GetGraphUrl(), p.GetGraphPath()); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-func (p *ImageEnhancementPredictor) loadPredictor(ctx context.Context) error {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "load_predictor")
-	defer span.Finish()
-
-	span.LogFields(
-		olog.String("event", "load predictor"),
-	)
-
-	opts, err := p.GetPredictionOptions()
+func (p *ImageEnhancementPredictor) loadModelFiles(ctx context.Context) error {
+	postprocess, err := p.postprocessOptions()
 	if err != nil {
 		return err
 	}
-
-	pred, err := gopytorch.New(
-		ctx,
-		options.WithOptions(opts),
-		options.Graph([]byte(p.GetGraphPath())),
-	)
-	if err != nil {
+	p.postprocess = postprocess
+	if _, err := p.tileOptions(); err != nil {
 		return err
 	}
 
-	p.predictor = pred
-
 	return nil
 }
 
+// postprocessOptions resolves the clamping and the encoding of the enhanced images.
+func (p *ImageEnhancementPredictor) postprocessOptions(opts ...options.Option) (imagePostprocessOptions, error) {
+	res := imagePostprocessOptionsFromManifest(p.Base)
+	res = res.withOverrides(p.Options.Context()).withOverrides(options.New(opts...).Context())
+	if err := res.validate(); err != nil {
+		return imagePostprocessOptions{}, err
+	}
+	return res, nil
+}
+
+// tileOptions resolves the tiling of the images.
+func (p *ImageEnhancementPredictor) tileOptions(opts ...options.Option) (tileOptions, error) {
+	return imageTileOptions(p.Base, p.Options.Context(), options.New(opts...).Context())
+}
+
 // GetInputLayerName ...
-func (p ImageEnhancementPredictor) GetInputLayerName(reader io.Reader, layer string) (string, error) {
+func (p *ImageEnhancementPredictor) GetInputLayerName(reader io.Reader, layer string) (string, error) {
 	model := p.Model
 	modelInputs := model.GetInputs()
 	typeParameters := modelInputs[0].GetParameters()
@@ -197,7 +119,7 @@ func (p ImageEnhancementPredictor) GetInputLayerName(reader io.Reader, layer str
 }
 
 // GetOutputLayerName ...
-func (p ImageEnhancementPredictor) GetOutputLayerName(reader io.Reader, layer string) (string, error) {
+func (p *ImageEnhancementPredictor) GetOutputLayerName(reader io.Reader, layer string) (string, error) {
 	model := p.Model
 	modelOutput := model.GetOutput()
 	typeParameters := modelOutput.GetParameters()
@@ -209,106 +131,82 @@ func (p ImageEnhancementPredictor) GetOutputLayerName(reader io.Reader, layer st
 	return name, nil
 }
 
-func makeUniformImage() [][][][]float32 {
-	images := make([][][][]float32, 10)
-	width := 1000
-	height := 1000
-	for ii := range images {
-		sl := make([][][]float32, height)
-		for jj := range sl {
-			el := make([][]float32, width)
-			for kk := range el {
-				el[kk] = []float32{1, 0, 1}
-			}
-			sl[jj] = el
-		}
-		images[ii] = sl
-	}
-	return images
-}
-
-// Predict ...
-func (p *ImageEnhancementPredictor) Predict(ctx context.Context, data interface{}, opts ...options.Option) error {
-	if data == nil {
-		return errors.New("input data nil")
+// prepare converts the data into the input tensors of the model.
+func (p *ImageEnhancementPredictor) prepare(ctx context.Context, data interface{}, opts ...options.Option) ([]gotensor.Tensor, outputDecoder, error) {
+	postprocess, err := p.postprocessOptions(opts...)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	gotensors, ok := data.([]gotensor.Tensor)
-	if !ok {
-		return errors.New("input data is not slice of dense tensors")
+	gotensors, err := imageInputTensors(ctx, p.Model, data)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return p.predictor.Predict(ctx, gotensors)
+	return gotensors, func(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+		return p.decodeFeatures(ctx, outputs, postprocess)
+	}, nil
 }
 
-// ReadPredictedFeatures ...
-func (p *ImageEnhancementPredictor) ReadPredictedFeatures(ctx context.Context) ([]dlframework.Features, error) {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features")
-	defer span.Finish()
+// decodeOutputs decodes the outputs with the postprocessing options of the manifest and of the Load.
+func (p *ImageEnhancementPredictor) decodeOutputs(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	return p.decodeFeatures(ctx, outputs, p.postprocess)
+}
 
-	outputs, err := p.predictor.ReadPredictionOutput(ctx)
-	if err != nil {
+// decodeFeatures undoes the normalization of the inputs on the output images, of 1, 3 or 4 channels,
+// then returns them as raw or encoded images.
+func (p *ImageEnhancementPredictor) decodeFeatures(ctx context.Context, outputs []gotensor.Tensor, postprocess imagePostprocessOptions) ([]dlframework.Features, error) {
+	// the images are NCHW
+	if err := checkSingleOutput(p.Base, outputs, anyDim, anyDim, anyDim); err != nil {
 		return nil, err
 	}
 
-	outputarray := outputs[0].Data().([]float32)
-	outputbatch := outputs[0].Shape()[0]
-	outputchannels := outputs[0].Shape()[1]
-	outputheight := outputs[0].Shape()[2]
-	outputwidth := outputs[0].Shape()[3]
-
-	// convert 1D array to a 4D array in order to make it compatible with CreateRawImageFeatures function call
-	e := make([][][][]float32, outputbatch)
-	for b := 0; b < outputbatch; b++ {
-		e[b] = make([][][]float32, outputheight)
-		for h := 0; h < outputheight; h++ {
-			e[b][h] = make([][]float32, outputwidth)
-			for w := 0; w < outputwidth; w++ {
-				e[b][h][w] = make([]float32, 3)
-			}
-		}
-	}
-	for b := 0; b < outputbatch; b++ {
-		for h := 0; h < outputheight; h++ {
-			for w := 0; w < outputwidth; w++ {
-				e[b][h][w][0] = outputarray[b*outputheight*outputwidth*outputchannels+0*outputheight*outputwidth+h*outputwidth+w]
-				e[b][h][w][1] = outputarray[b*outputheight*outputwidth*outputchannels+1*outputheight*outputwidth+h*outputwidth+w]
-				e[b][h][w][2] = outputarray[b*outputheight*outputwidth*outputchannels+2*outputheight*outputwidth+h*outputwidth+w]
-			}
-		}
+	images, err := newOutputView(p.Base, 0, outputs[0], 4)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the image output")
 	}
+	channels, height, width := images.shape[1], images.shape[2], images.shape[3]
 
-	return p.CreateRawImageFeatures(ctx, e)
-}
-
-// ReadPredictedFeaturesAsMap ...
-func (p *ImageEnhancementPredictor) ReadPredictedFeaturesAsMap(ctx context.Context) (map[string]interface{}, error) {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features_as_map")
-	defer span.Finish()
-
-	outputs, err := p.predictor.ReadPredictionOutput(ctx)
+	mean, err := p.GetMeanImage()
 	if err != nil {
 		return nil, err
 	}
+	scale, err := p.GetScale()
+	if err != nil {
+		return nil, err
+	}
+	// the alpha channel is assumed to be normalized to the range of the first channel
+	alphaScale := float32(1)
+	if len(scale) != 0 {
+		alphaScale = scale[0]
+	}
+	if mean, err = channelParameters("mean", mean, channels, 0); err != nil {
+		return nil, err
+	}
+	if scale, err = channelParameters("scale", scale, channels, alphaScale); err != nil {
+		return nil, err
+	}
 
-	res := make(map[string]interface{})
-	res["outputs"] = outputs
-
-	return res, nil
+	pixels := denormalizeImages(images, scale, mean, postprocess.clamp)
+	if postprocess.encoding == "" || postprocess.encoding == EncodingRaw {
+		return rawImageFeatures(pixels, width, height, channels), nil
+	}
+	bgr := strings.EqualFold(strings.TrimSpace(getInputParameter(p.Base, "color_mode")), "BGR")
+	return encodedImageFeatures(pixels, width, height, channels, bgr, postprocess)
 }
 
 // Reset ...
 func (p *ImageEnhancementPredictor) Reset(ctx context.Context) error {
-	return nil
+	return p.torchPredictor.Reset(ctx)
 }
 
 // Close ...
 func (p *ImageEnhancementPredictor) Close() error {
-	return nil
+	return p.torchPredictor.Close()
 }
 
 // Modality ...
-func (p ImageEnhancementPredictor) Modality() (dlframework.Modality, error) {
+func (p *ImageEnhancementPredictor) Modality() (dlframework.Modality, error) {
 	return dlframework.ImageEnhancementModality, nil
 }
 
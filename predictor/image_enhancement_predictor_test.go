@@ -5,6 +5,7 @@ import (
 	"image"
 	"image/color"
 	"image/jpeg"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -12,7 +13,6 @@ import (
 	"github.com/c3sr/dlframework"
 
 	"github.com/c3sr/dlframework/framework/options"
-	raiimage "github.com/c3sr/image"
 	"github.com/c3sr/image/types"
 	nvidiasmi "github.com/c3sr/nvidia-smi"
 	py "github.com/c3sr/pytorch"
@@ -42,53 +42,30 @@ func TestImageEnhancement(t *testing.T) {
 	assert.NotEmpty(t, predictor)
 	defer predictor.Close()
 
-	imgDir, _ := filepath.Abs("./_fixtures")
-	imgPath := filepath.Join(imgDir, "penguin.png")
-	r, err := os.Open(imgPath)
-	if err != nil {
-		panic(err)
-	}
-
-	preprocessOpts, err := predictor.GetPreprocessOptions()
+	preprocessor, err := NewImagePreprocessor(*model)
 	assert.NoError(t, err)
-	mode := preprocessOpts.ColorMode
-
-	var imgOpts []raiimage.Option
-	if mode == types.RGBMode {
-		imgOpts = append(imgOpts, raiimage.Mode(types.RGBMode))
-	} else {
-		imgOpts = append(imgOpts, raiimage.Mode(types.BGRMode))
-	}
-
-	img, err := raiimage.Read(r, imgOpts...)
 	if err != nil {
-		panic(err)
+		return
 	}
 
-	channels := 3
-	height := img.Bounds().Dy()
-	width := img.Bounds().Dx()
-
-	input := make([]gotensor.Tensor, batchSize)
-	imgFloats, err := normalizeImageCHW(img, preprocessOpts.MeanImage, preprocessOpts.Scale)
+	imgDir, _ := filepath.Abs("./_fixtures")
+	imgPath := filepath.Join(imgDir, "penguin.png")
+	imgBytes, err := ioutil.ReadFile(imgPath)
 	if err != nil {
 		panic(err)
 	}
 
-	for ii := 0; ii < batchSize; ii++ {
-		input[ii] = gotensor.New(
-			gotensor.WithShape(channels, height, width),
-			gotensor.WithBacking(imgFloats),
-		)
+	batch := make([][]byte, batchSize)
+	for ii := range batch {
+		batch[ii] = imgBytes
 	}
-
-	joined, err := gotensor.Concat(0, input[0], input[1:]...)
+	input, err := preprocessor.PreprocessBytes(ctx, batch)
+	assert.NoError(t, err)
 	if err != nil {
 		return
 	}
-	joined.Reshape(append([]int{len(input)}, input[0].Shape()...)...)
 
-	err = predictor.Predict(ctx, []gotensor.Tensor{joined})
+	err = predictor.Predict(ctx, []gotensor.Tensor{input})
 
 	assert.NoError(t, err)
 	if err != nil {
@@ -141,3 +118,37 @@ func TestImageEnhancement(t *testing.T) {
 		B: 0xc6,
 	}, outImg.At(0, 0))
 }
+
+func TestImageEnhancementLoadClose(t *testing.T) {
+	py.Register()
+	model, err := py.FrameworkManifest.FindModel("srgan_v1.0:1.0")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, model)
+
+	device := options.CPU_DEVICE
+	if nvidiasmi.HasGPU {
+		device = options.CUDA_DEVICE
+	}
+
+	ctx := context.Background()
+	opts := options.New(options.Context(ctx),
+		options.Device(device, 0),
+		options.BatchSize(1))
+
+	before := OpenTorchModules()
+	for ii := 0; ii < 5; ii++ {
+		predictor, err := NewImageEnhancementPredictor(*model, options.WithOptions(opts))
+		assert.NoError(t, err)
+		if err != nil {
+			return
+		}
+		assert.Equal(t, before+1, OpenTorchModules())
+
+		assert.NoError(t, predictor.Close())
+		assert.NoError(t, predictor.Close())
+		assert.Equal(t, before, OpenTorchModules())
+
+		err = predictor.Predict(ctx, []gotensor.Tensor{gotensor.New(gotensor.WithShape(1, 3, 8, 8), gotensor.Of(gotensor.Float32))})
+		assert.True(t, IsPredictorClosed(err))
+	}
+}
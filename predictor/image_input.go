@@ -0,0 +1,187 @@
+package predictor
+
+import (
+	"context"
+	"image"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/image/types"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// imageInputTensors converts the data passed to Predict by the image predictors into the input tensors of the model.
+// The data is either the input tensors, or a batch of images given as []image.Image, encoded images
+// given as [][]byte, or file paths and http(s) URLs given as []string. The images are preprocessed
+// according to the input parameters of the model manifest. The models declaring several inputs take
+// them as a []ModelInput.
+func imageInputTensors(ctx context.Context, model dlframework.ModelManifest, data interface{}) ([]gotensor.Tensor, error) {
+	gotensors, _, err := preprocessImageInputs(ctx, model, data)
+	return gotensors, err
+}
+
+// sourceImage is an image given to a predictor, along with how it was placed onto the input of the model.
+type sourceImage struct {
+	image    image.Image
+	geometry imageGeometry
+}
+
+// imageGeometries returns the geometries of the source images.
+func imageGeometries(sources []sourceImage) []imageGeometry {
+	if sources == nil {
+		return nil
+	}
+	res := make([]imageGeometry, len(sources))
+	for ii, src := range sources {
+		res[ii] = src.geometry
+	}
+	return res
+}
+
+// preprocessImageInputs converts the data into the input tensors of the model like imageInputTensors,
+// and also returns the source images. They are nil when the data already is the input tensors.
+// The models declaring several inputs take them as a []ModelInput.
+func preprocessImageInputs(ctx context.Context, model dlframework.ModelManifest, data interface{}) ([]gotensor.Tensor, []sourceImage, error) {
+	if data == nil {
+		return nil, nil, errors.New("input data nil")
+	}
+	if gotensors, ok := data.([]gotensor.Tensor); ok {
+		return gotensors, nil, nil
+	}
+	if inputs, ok := data.([]ModelInput); ok {
+		return modelInputTensors(ctx, model, inputs)
+	}
+	if n := len(model.GetInputs()); n > 1 {
+		return nil, nil, errors.Errorf("model %v takes %v inputs, expecting them as a []ModelInput, but got %T", model.GetName(), n, data)
+	}
+
+	imgs, err := inputImages(ctx, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	preprocessor, err := NewImagePreprocessor(model)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot create the image preprocessor")
+	}
+
+	input, err := preprocessor.Preprocess(ctx, imgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sources := make([]sourceImage, len(imgs))
+	for ii, img := range imgs {
+		sources[ii] = sourceImage{image: img, geometry: preprocessor.imageGeometry(img)}
+	}
+
+	return []gotensor.Tensor{input}, sources, nil
+}
+
+// preprocessAugmentedInputs converts the images of the data into a single input tensor holding every variant
+// of every image for the test-time augmentation, and returns the source images. The variants are cut from
+// the source images, so the data given as input tensors is not supported.
+func preprocessAugmentedInputs(ctx context.Context, model dlframework.ModelManifest, data interface{},
+	variants []augmentationVariant) ([]gotensor.Tensor, []sourceImage, error) {
+	if data == nil {
+		return nil, nil, errors.New("input data nil")
+	}
+	if _, ok := data.([]gotensor.Tensor); ok {
+		return nil, nil, errors.New("the test-time augmentation needs the images, but got the input tensors")
+	}
+	if n := len(model.GetInputs()); n > 1 {
+		return nil, nil, errors.Errorf("the test-time augmentation supports the models of a single image input, but model %v takes %v inputs", model.GetName(), n)
+	}
+
+	imgs, err := inputImages(ctx, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	preprocessor, err := NewImagePreprocessor(model)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot create the image preprocessor")
+	}
+
+	input, sources, err := preprocessor.preprocessVariants(ctx, imgs, variants)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []gotensor.Tensor{input}, sources, nil
+}
+
+// inputImages returns the images given as []image.Image, encoded images given as [][]byte,
+// or file paths and http(s) URLs given as []string.
+func inputImages(ctx context.Context, data interface{}) ([]image.Image, error) {
+	var imgs []image.Image
+	switch in := data.(type) {
+	case []image.Image:
+		imgs = in
+	case []types.Image:
+		imgs = make([]image.Image, len(in))
+		for ii, img := range in {
+			imgs[ii] = img
+		}
+	case [][]byte:
+		var err error
+		imgs, err = decodeImages(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+	case []string:
+		encoded, err := readImages(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		imgs, err = decodeImages(ctx, encoded)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Errorf("input data of type %T is not supported, expecting tensors, images, encoded images or image paths", data)
+	}
+
+	return imgs, nil
+}
+
+// readImages reads the encoded images from the file paths or http(s) URLs.
+func readImages(ctx context.Context, paths []string) ([][]byte, error) {
+	res := make([][]byte, len(paths))
+	for ii, path := range paths {
+		var buf []byte
+		var err error
+		if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+			buf, err = fetchURL(ctx, path)
+		} else {
+			buf, err = ioutil.ReadFile(path)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read the image %v", path)
+		}
+		res[ii] = buf
+	}
+	return res, nil
+}
+
+func fetchURL(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %v", resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
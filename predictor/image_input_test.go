@@ -0,0 +1,92 @@
+package predictor
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/c3sr/dlframework"
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+func testImageModel() dlframework.ModelManifest {
+	return dlframework.ModelManifest{
+		Inputs: []*dlframework.ModelManifest_Type{
+			{
+				Type: "image",
+				Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+					"element_type": {Value: "float32"},
+					"layout":       {Value: "CHW"},
+					"color_mode":   {Value: "RGB"},
+					"dimensions":   {Value: "[3, 4, 4]"},
+					"mean":         {Value: "[0, 0, 0]"},
+					"scale":        {Value: "255"},
+				},
+			},
+		},
+	}
+}
+
+func TestImageInputTensors(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			img.Set(x, y, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+
+	dir, err := ioutil.TempDir("", "image_input")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "white.png")
+	assert.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/white.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	model := testImageModel()
+	for _, data := range []interface{}{
+		[]image.Image{img, img},
+		[][]byte{buf.Bytes(), buf.Bytes()},
+		[]string{path, server.URL + "/white.png"},
+	} {
+		input, err := imageInputTensors(ctx, model, data)
+		assert.NoError(t, err)
+		if err != nil {
+			continue
+		}
+		assert.Len(t, input, 1)
+		assert.Equal(t, []int{2, 3, 4, 4}, []int(input[0].Shape()))
+		for _, v := range input[0].Data().([]float32) {
+			assert.InDelta(t, 1, v, 1e-6)
+		}
+	}
+
+	tensors := []gotensor.Tensor{gotensor.New(gotensor.WithShape(1, 3, 4, 4), gotensor.Of(gotensor.Float32))}
+	input, err := imageInputTensors(ctx, model, tensors)
+	assert.NoError(t, err)
+	assert.Equal(t, tensors, input)
+
+	_, err = imageInputTensors(ctx, model, []string{server.URL + "/missing.png"})
+	assert.Error(t, err)
+	_, err = imageInputTensors(ctx, model, []int{1})
+	assert.Error(t, err)
+}
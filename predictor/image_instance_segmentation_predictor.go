@@ -1,337 +1,282 @@
 package predictor
 
-// import (
-// 	"bufio"
-// 	"context"
-// 	"io"
-// 	"os"
-// 	"strings"
-
-// 	opentracing "github.com/opentracing/opentracing-go"
-// 	olog "github.com/opentracing/opentracing-go/log"
-// 	"github.com/pkg/errors"
-// 	"github.com/c3sr/config"
-// 	"github.com/c3sr/dlframework"
-// 	"github.com/c3sr/dlframework/framework/agent"
-// 	"github.com/c3sr/dlframework/framework/options"
-// 	common "github.com/c3sr/dlframework/framework/predictor"
-// 	"github.com/c3sr/downloadmanager"
-// 	gopytorch "github.com/c3sr/go-pytorch"
-// 	"github.com/c3sr/pytorch"
-// 	"github.com/c3sr/tracer"
-// 	gotensor "gorgonia.org/tensor"
-// )
-
-// type InstanceSegmentationPredictor struct {
-// 	common.ImagePredictor
-// 	labels        []string
-// 	predictor     *gopytorch.Predictor
-// 	boxes         interface{}
-// 	probabilities interface{}
-// 	classes       interface{}
-// 	masks         interface{}
-// }
-
-// func NewInstanceSegmentationPredictor(model dlframework.ModelManifest, opts ...options.Option) (common.Predictor, error) {
-// 	ctx := context.Background()
-// 	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "new_predictor")
-// 	defer span.Finish()
-
-// 	modelInputs := model.GetInputs()
-// 	if len(modelInputs) != 1 {
-// 		return nil, errors.New("number of inputs not supported")
-// 	}
-// 	firstInputType := modelInputs[0].GetType()
-// 	if strings.ToLower(firstInputType) != "image" {
-// 		return nil, errors.New("input type not supported")
-// 	}
-
-// 	predictor := new(InstanceSegmentationPredictor)
-
-// 	return predictor.Load(context.Background(), model, opts...)
-// }
-
-// // Download ...
-// func (p *InstanceSegmentationPredictor) Download(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) error {
-// 	framework, err := model.ResolveFramework()
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	workDir, err := model.WorkDir()
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	ip := &InstanceSegmentationPredictor{
-// 		ImagePredictor: common.ImagePredictor{
-// 			Base: common.Base{
-// 				Framework: framework,
-// 				Model:     model,
-// 				WorkDir:   workDir,
-// 				Options:   options.New(opts...),
-// 			},
-// 		},
-// 	}
-
-// 	if err = ip.download(ctx); err != nil {
-// 		return err
-// 	}
-
-// 	return nil
-// }
-
-// func (p *InstanceSegmentationPredictor) Load(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) (common.Predictor, error) {
-// 	framework, err := model.ResolveFramework()
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	workDir, err := model.WorkDir()
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	ip := &InstanceSegmentationPredictor{
-// 		ImagePredictor: common.ImagePredictor{
-// 			Base: common.Base{
-// 				Framework: framework,
-// 				Model:     model,
-// 				WorkDir:   workDir,
-// 				Options:   options.New(opts...),
-// 			},
-// 		},
-// 	}
-
-// 	if err = ip.download(ctx); err != nil {
-// 		return nil, err
-// 	}
-
-// 	if err = ip.loadPredictor(ctx); err != nil {
-// 		return nil, err
-// 	}
-
-// 	return ip, nil
-// }
-
-// func (p *ImageClassificationPredictor) download(ctx context.Context) error {
-// 	span, ctx := tracer.StartSpanFromContext(
-// 		ctx,
-// 		tracer.APPLICATION_TRACE,
-// 		"download",
-// 		opentracing.Tags{
-// 			"graph_url":           p.GetGraphUrl(),
-// 			"target_graph_file":   p.GetGraphPath(),
-// 			"feature_url":         p.GetFeaturesUrl(),
-// 			"target_feature_file": p.GetFeaturesPath(),
-// 		},
-// 	)
-// 	defer span.Finish()
-
-// 	model := p.Model
-// 	if model.Model.IsArchive {
-// 		baseURL := model.Model.BaseUrl
-// 		span.LogFields(
-// 			olog.String("event", "download model archive"),
-// 		)
-// 		_, err := downloadmanager.DownloadInto(baseURL, p.WorkDir, downloadmanager.Context(ctx))
-// 		if err != nil {
-// 			return errors.Wrapf(err, "failed to download model archive from %v", model.Model.BaseUrl)
-// 		}
-// 	} else {
-// 		span.LogFields(
-// 			olog.String("event", "download graph"),
-// 		)
-// 		checksum := p.GetGraphChecksum()
-// 		if checksum != "" {
-// 			if _, err := downloadmanager.DownloadFile(p.GetGraphUrl(), p.GetGraphPath(), downloadmanager.MD5Sum(checksum)); err != nil {
-// 				return err
-// 			}
-// 		} else {
-// 			if _, err := downloadmanager.DownloadFile(p.GetGraphUrl(), p.GetGraphPath()); err != nil {
-// 				return err
-// 			}
-// 		}
-// 	}
-
-// 	span.LogFields(
-// 		olog.String("event", "download features"),
-// 	)
-// 	checksum := p.GetFeaturesChecksum()
-// 	if checksum != "" {
-// 		if _, err := downloadmanager.DownloadFile(p.GetFeaturesUrl(), p.GetFeaturesPath(), downloadmanager.MD5Sum(checksum)); err != nil {
-// 			return err
-// 		}
-// 	} else {
-// 		if _, err := downloadmanager.DownloadFile(p.GetFeaturesUrl(), p.GetFeaturesPath()); err != nil {
-// 			return err
-// 		}
-// 	}
-
-// 	return nil
-// }
-
-// 	span.LogFields(
-// 		olog.String("event", "download features"),
-// 	)
-// 	checksum := p.GetFeaturesChecksum()
-// 	if checksum != "" {
-// 		if _, err := downloadmanager.DownloadFile(p.GetFeaturesUrl(), p.GetFeaturesPath(), downloadmanager.MD5Sum(checksum)); err != nil {
-// 			return err
-// 		}
-// 	} else {
-// 		if _, err := downloadmanager.DownloadFile(p.GetFeaturesUrl(), p.GetFeaturesPath()); err != nil {
-// 			return err
-// 		}
-// 	}
-
-// 	return nil
-// }
-
-// func (p *ImageClassificationPredictor) loadPredictor(ctx context.Context) error {
-// 	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "load_predictor")
-// 	defer span.Finish()
-
-// 	span.LogFields(
-// 		olog.String("event", "read features"),
-// 	)
-
-// 	var labels []string
-// 	f, err := os.Open(p.GetFeaturesPath())
-// 	if err != nil {
-// 		return errors.Wrapf(err, "cannot read %s", p.GetFeaturesPath())
-// 	}
-// 	defer f.Close()
-// 	scanner := bufio.NewScanner(f)
-// 	for scanner.Scan() {
-// 		line := scanner.Text()
-// 		labels = append(labels, line)
-// 	}
-// 	p.labels = labels
-
-// 	span.LogFields(
-// 		olog.String("event", "creating predictor"),
-// 	)
-
-// 	opts, err := p.GetPredictionOptions(ctx)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	pred, err := gopytorch.New(
-// 		ctx,
-// 		options.WithOptions(opts),
-// 		options.Graph([]byte(p.GetGraphPath())),
-// 	)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	p.predictor = pred
-
-// 	return nil
-// }
-
-// func (p InstanceSegmentationPredictor) GetInputLayerName(reader io.Reader, layer string) (string, error) {
-// 	model := p.Model
-// 	modelInputs := model.GetInputs()
-// 	typeParameters := modelInputs[0].GetParameters()
-// 	name, err := p.GetTypeParameter(typeParameters, layer)
-// 	if err != nil {
-// 		// TODO get input layer name => for what..?
-// 		return "", errors.New("cannot determine the name of the input layer")
-// 	}
-// 	return name, nil
-// }
-
-// func (p InstanceSegmentationPredictor) GetOutputLayerName(reader io.Reader, layer string) (string, error) {
-// 	model := p.Model
-// 	modelOutput := model.GetOutput()
-// 	typeParameters := modelOutput.GetParameters()
-// 	name, err := p.GetTypeParameter(typeParameters, layer)
-// 	if err != nil {
-// 		// TODO get output layer name => for what..?
-// 		return "", errors.New("cannot determine the name of the output layer")
-// 	}
-// 	return name, nil
-// }
-
-// func (p *InstanceSegmentationPredictor) runOptions() *proto.RunOptions {
-// 	if p.TraceLevel() >= tracer.FRAMEWORK_TRACE {
-// 		return &proto.RunOptions{
-// 			TraceLevel: proto.RunOptions_SOFTWARE_TRACE,
-// 		}
-// 	}
-// 	return nil
-// }
-
-// // Predict ...
-// func (p *InstanceSegmentationPredictor) Predict(ctx context.Context, data interface{}, opts ...options.Option) error {
-
-// 	if data == nil {
-// 		return errors.New("input data nil")
-// 	}
-
-// 	input, ok := data.([]gotensor.Tensor)
-// 	if !ok {
-// 		return errors.New("input data is not slice of dense tensors")
-// 	}
-
-// 	return p.predictor.Predict(ctx, gotensors)
-// }
-
-// // ReadPredictedFeatures ...
-// func (p *InstanceSegmentationPredictor) ReadPredictedFeatures(ctx context.Context) ([]dlframework.Features, error) {
-// 	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features")
-// 	defer span.Finish()
-
-// 	boxes := p.boxes.([][][]float32)
-// 	probabilities := p.probabilities.([][]float32)
-// 	classes := p.classes.([][]float32)
-// 	masks := p.masks.([][][][]float32)
-
-// 	return p.CreateInstanceSegmentFeatures(ctx, probabilities, classes, boxes, masks, p.labels)
-// }
-
-// ReadPredictedFeaturesAsMap ...
-// func (p *InstanceSegmentationPredictor) ReadPredictedFeaturesAsMap(ctx context.Context) (map[string]interface{}, error) {
-//	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features_as_map")
-//	defer span.Finish()
-//
-//	outputs, err := p.predictor.ReadPredictionOutput(ctx)
-//	if err != nil {
-//		return nil, err
-//	}
-//
-//	res := make(map[string]interface{})
-//	res["outputs"] = outputs
-//	res["labels"] = p.labels
-//
-//	return res, nil
-// }
-
-// func (p *InstanceSegmentationPredictor) Reset(ctx context.Context) error {
-
-// 	return nil
-// }
-
-// func (p *InstanceSegmentationPredictor) Close() error {
-// 	return nil
-// }
-
-// func (p InstanceSegmentationPredictor) Modality() (dlframework.Modality, error) {
-// 	return dlframework.ImageInstanceSegmentationModality, nil
-// }
-
-// func init() {
-// 	config.AfterInit(func() {
-// 		framework := pytorch.FrameworkManifest
-// 		agent.AddPredictor(framework, &InstanceSegmentationPredictor{
-// 			ImagePredictor: common.ImagePredictor{
-// 				Base: common.Base{
-// 					Framework: framework,
-// 				},
-// 			},
-// 		})
-// 	})
-// }
+import (
+	"context"
+
+	"github.com/c3sr/config"
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/agent"
+	"github.com/c3sr/dlframework/framework/options"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/c3sr/pytorch"
+	"github.com/c3sr/tracer"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// InstanceSegmentationPredictor ...
+type InstanceSegmentationPredictor struct {
+	common.ImagePredictor
+	torchPredictor
+	postprocess segmentationExportOptions
+}
+
+// NewInstanceSegmentationPredictor ...
+func NewInstanceSegmentationPredictor(model dlframework.ModelManifest, os ...options.Option) (common.Predictor, error) {
+	opts := options.New(os...)
+	ctx := opts.Context()
+
+	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "new_predictor")
+	defer span.Finish()
+
+	if err := checkImageModelInputs(model); err != nil {
+		return nil, err
+	}
+
+	predictor := new(InstanceSegmentationPredictor)
+
+	return predictor.Load(ctx, model, os...)
+}
+
+// Download ...
+func (p *InstanceSegmentationPredictor) Download(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) error {
+	base, err := newTorchBase(model, opts...)
+	if err != nil {
+		return err
+	}
+
+	ip := &InstanceSegmentationPredictor{
+		ImagePredictor: common.ImagePredictor{
+			Base: base,
+		},
+	}
+
+	return downloadModel(ctx, ip.Base, ip.modelFiles())
+}
+
+// Load ...
+func (p *InstanceSegmentationPredictor) Load(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) (common.Predictor, error) {
+	base, err := newTorchBase(model, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := &InstanceSegmentationPredictor{
+		ImagePredictor: common.ImagePredictor{
+			Base: base,
+		},
+	}
+
+	if err = ip.load(ctx, ip.Base, ip); err != nil {
+		return nil, err
+	}
+
+	return ip, nil
+}
+
+func (p *InstanceSegmentationPredictor) modelFiles() []modelFile {
+	return []modelFile{featuresFile(p.Base)}
+}
+
+func (p *InstanceSegmentationPredictor) loadModelFiles(ctx context.Context) error {
+	// the Mask R-CNN models return the instances of a single image, whose count varies from image to image
+	if p.BatchSize() != 1 {
+		return errors.Errorf("model %v segments one image at a time, but the predictor was loaded with a batch size of %v",
+			p.Model.GetName(), p.BatchSize())
+	}
+
+	postprocess, err := p.postprocessOptions()
+	if err != nil {
+		return err
+	}
+	p.postprocess = postprocess
+
+	return nil
+}
+
+// postprocessOptions resolves the exports of the masks.
+func (p *InstanceSegmentationPredictor) postprocessOptions(opts ...options.Option) (segmentationExportOptions, error) {
+	res, err := segmentationExportOptionsFromManifest(p.Base)
+	if err != nil {
+		return segmentationExportOptions{}, err
+	}
+	res = res.withOverrides(p.Options.Context()).withOverrides(options.New(opts...).Context())
+	if err := res.validate(); err != nil {
+		return segmentationExportOptions{}, err
+	}
+	return res, nil
+}
+
+// outputIndex returns the index of the output tensor named by the layer
+// parameter in the manifest, or defaultIndex when the parameter is not set.
+func (p *InstanceSegmentationPredictor) outputIndex(layer string, defaultIndex int) int {
+	idx, err := p.GetOutputLayerIndex(layer)
+	if err != nil {
+		return defaultIndex
+	}
+	return idx
+}
+
+// prepare converts the data into the input tensors of the model.
+// The decoder keeps the source images, to render the exports over them.
+func (p *InstanceSegmentationPredictor) prepare(ctx context.Context, data interface{}, opts ...options.Option) ([]gotensor.Tensor, outputDecoder, error) {
+	postprocess, err := p.postprocessOptions(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gotensors, sources, err := preprocessImageInputs(ctx, p.Model, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gotensors, func(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+		return p.decodeFeatures(ctx, outputs, sources, postprocess)
+	}, nil
+}
+
+// decodeOutputs decodes the outputs with the exports of the manifest and of the Load, the source images being unknown.
+func (p *InstanceSegmentationPredictor) decodeOutputs(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	return p.decodeFeatures(ctx, outputs, nil, p.postprocess)
+}
+
+func (p *InstanceSegmentationPredictor) decodeFeatures(ctx context.Context, outputs []gotensor.Tensor, sources []sourceImage,
+	postprocess segmentationExportOptions) ([]dlframework.Features, error) {
+	// the defaults follow the torchvision Mask R-CNN output order: boxes, labels, scores, masks
+	boxesIdx := p.outputIndex("boxes_layer", 0)
+	classesIdx := p.outputIndex("classes_layer", 1)
+	probabilitiesIdx := p.outputIndex("probabilities_layer", 2)
+	masksIdx := p.outputIndex("masks_layer", 3)
+	for _, idx := range []int{boxesIdx, classesIdx, probabilitiesIdx, masksIdx} {
+		if idx < 0 || idx >= len(outputs) {
+			return nil, errors.Errorf("output index %v is out of range, the model returned %v outputs", idx, len(outputs))
+		}
+	}
+
+	boxes, err := tensorToFloat32s(outputs[boxesIdx])
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the boxes output")
+	}
+	classes, err := tensorToFloat32s(outputs[classesIdx])
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the classes output")
+	}
+	probabilities, err := tensorToFloat32s(outputs[probabilitiesIdx])
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the probabilities output")
+	}
+	masks, err := tensorToFloat32s(outputs[masksIdx])
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the masks output")
+	}
+
+	// boxes are the [N, 4] boxes of a single image, optionally with a leading batch dimension of 1
+	boxesShape := outputs[boxesIdx].Shape()
+	if !(len(boxesShape) == 2 || len(boxesShape) == 3 && boxesShape[0] == 1) || boxesShape[len(boxesShape)-1] != 4 {
+		return nil, errors.Errorf("expecting the boxes of a single image of shape [N, 4], but the model returned shape %v", boxesShape)
+	}
+
+	numInstances := len(probabilities)
+	if len(classes) != numInstances || len(boxes) != 4*numInstances {
+		return nil, errors.Errorf("mismatched number of instances: %v boxes, %v classes and %v probabilities",
+			len(boxes)/4, len(classes), len(probabilities))
+	}
+
+	// masks are either [N, 1, H, W] or [N, H, W], optionally with a leading batch dimension
+	masksShape := outputs[masksIdx].Shape()
+	if len(masksShape) < 2 {
+		return nil, errors.Errorf("expecting the masks output to be at least 2-dimensional, but got shape %v", masksShape)
+	}
+	maskHeight := masksShape[len(masksShape)-2]
+	maskWidth := masksShape[len(masksShape)-1]
+	maskSize := maskHeight * maskWidth
+	if len(masks) != numInstances*maskSize {
+		return nil, errors.Errorf("expecting %v masks of size %vx%v, but got %v values",
+			numInstances, maskHeight, maskWidth, len(masks))
+	}
+
+	// boxes are reported in input pixels and the masks are pasted at the input resolution,
+	// so the mask dimensions are used to normalize the boxes unless the manifest sets a scaling
+	boxIndex := p.GetBoxIndex()
+	scaleWidth, scaleHeight := p.GetBoxScaling()
+	if scaleWidth == 1 && scaleHeight == 1 {
+		scaleWidth, scaleHeight = float32(maskWidth), float32(maskHeight)
+	}
+
+	instanceBoxes := make([][]float32, numInstances)
+	instanceMasks := make([][][]float32, numInstances)
+	for ii := range instanceBoxes {
+		class := int(classes[ii])
+		if class < 0 || class >= len(p.labels) {
+			return nil, errors.Errorf("class index %v is out of range of the %v labels", class, len(p.labels))
+		}
+		box := boxes[ii*4 : (ii+1)*4]
+		instanceBoxes[ii] = []float32{
+			box[boxIndex[0]] / scaleHeight,
+			box[boxIndex[1]] / scaleWidth,
+			box[boxIndex[2]] / scaleHeight,
+			box[boxIndex[3]] / scaleWidth,
+		}
+		mask := masks[ii*maskSize : (ii+1)*maskSize]
+		instanceMasks[ii] = make([][]float32, maskHeight)
+		for h := 0; h < maskHeight; h++ {
+			instanceMasks[ii][h] = mask[h*maskWidth : (h+1)*maskWidth]
+		}
+	}
+
+	features, err := p.CreateInstanceSegmentFeatures(ctx, [][]float32{probabilities}, [][]float32{classes},
+		[][][]float32{instanceBoxes}, [][][][]float32{instanceMasks}, p.labels)
+	if err != nil || len(postprocess.exports) == 0 {
+		return features, err
+	}
+
+	var src *sourceImage
+	if sources != nil {
+		if err := checkGeometries(imageGeometries(sources), 1); err != nil {
+			return nil, err
+		}
+		src = &sources[0]
+	}
+	instances := make([]instanceMask, numInstances)
+	for ii := range instances {
+		instances[ii] = instanceMask{
+			class: int32(classes[ii]),
+			score: probabilities[ii],
+			data:  masks[ii*maskSize : (ii+1)*maskSize],
+		}
+	}
+	exports, err := instanceSegmentExports(instances, maskHeight, maskWidth, src, p.labels, postprocess)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot export the masks")
+	}
+	features[0] = append(features[0], exports...)
+	return features, nil
+}
+
+// Reset ...
+func (p *InstanceSegmentationPredictor) Reset(ctx context.Context) error {
+	return p.torchPredictor.Reset(ctx)
+}
+
+// Close ...
+func (p *InstanceSegmentationPredictor) Close() error {
+	return p.torchPredictor.Close()
+}
+
+// Modality ...
+func (p *InstanceSegmentationPredictor) Modality() (dlframework.Modality, error) {
+	return dlframework.ImageInstanceSegmentationModality, nil
+}
+
+func init() {
+	config.AfterInit(func() {
+		framework := pytorch.FrameworkManifest
+		agent.AddPredictor(framework, &InstanceSegmentationPredictor{
+			ImagePredictor: common.ImagePredictor{
+				Base: common.Base{
+					Framework: framework,
+				},
+			},
+		})
+	})
+}
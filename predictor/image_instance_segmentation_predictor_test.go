@@ -1,84 +1,104 @@
 package predictor
 
-// import (
-// 	"context"
-// 	"os"
-// 	"path/filepath"
-// 	"testing"
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
 
-// 	"github.com/c3sr/dlframework/framework/options"
-// 	"github.com/c3sr/image"
-// 	"github.com/c3sr/image/types"
-// 	nvidiasmi "github.com/c3sr/nvidia-smi"
-// 	py "github.com/c3sr/pytorch"
-// 	"github.com/stretchr/testify/assert"
-// 	gotensor "gorgonia.org/tensor"
-// )
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/options"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
 
-// func TestInstanceSegmentation(t *testing.T) {
-// 	py.Register()
-// 	model, err := py.FrameworkManifest.FindModel("mask_rcnn_inception_v2_coco:1.0")
-// 	assert.NoError(t, err)
-// 	assert.NotEmpty(t, model)
+func TestInstanceSegmentation(t *testing.T) {
+	withFakeTorchModules(t)
+	ctx := context.Background()
 
-// 	device := options.CPU_DEVICE
-// 	if nvidiasmi.HasGPU {
-// 		device = options.CUDA_DEVICE
-// 	}
+	dir, err := ioutil.TempDir("", "instance_segmentation")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "fake.features"), []byte("background\nperson\ncar"), 0644))
 
-// 	batchSize := 1
-// 	ctx := context.Background()
-// 	opts := options.New(options.Context(ctx),
-// 		options.Device(device, 0),
-// 		options.BatchSize(batchSize))
+	// the fake model returns its inputs, the outputs of the torchvision Mask R-CNN export wrapper
+	p := &InstanceSegmentationPredictor{}
+	p.Base = common.Base{
+		Model:   dlframework.ModelManifest{Name: "fake", Output: &dlframework.ModelManifest_Type{}},
+		WorkDir: dir,
+		Options: options.New(options.BatchSize(1)),
+	}
+	if !assert.NoError(t, p.open(ctx, p.Base, p)) {
+		return
+	}
+	defer p.Close()
 
-// 	predictor, err := NewInstanceSegmentationPredictor(*model, options.WithOptions(opts))
-// 	assert.NoError(t, err)
-// 	assert.NotEmpty(t, predictor)
-// 	defer predictor.Close()
+	height, width := 3, 4
+	masks := make([]float32, 2*height*width)
+	masks[0], masks[height*width+5] = 1, 1
+	err = p.Predict(ctx, []gotensor.Tensor{
+		gotensor.New(gotensor.WithShape(2, 4), gotensor.WithBacking([]float32{0, 0, 2, 2, 1, 1, 4, 3})),
+		gotensor.New(gotensor.WithShape(2), gotensor.WithBacking([]int64{1, 2})),
+		gotensor.New(gotensor.WithShape(2), gotensor.WithBacking([]float32{0.9, 0.6})),
+		gotensor.New(gotensor.WithShape(2, 1, height, width), gotensor.WithBacking(masks)),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
 
-// 	imgDir, _ := filepath.Abs("./_fixtures")
-// 	imgPath := filepath.Join(imgDir, "lane_control.jpg")
-// 	r, err := os.Open(imgPath)
-// 	if err != nil {
-// 		panic(err)
-// 	}
-// 	img, err := image.Read(r)
-// 	if err != nil {
-// 		panic(err)
-// 	}
+	pred, err := p.ReadPredictedFeatures(ctx)
+	assert.NoError(t, err)
+	if !assert.Len(t, pred, 1) || !assert.Len(t, pred[0], 2) {
+		return
+	}
+	iseg := pred[0][0].GetInstanceSegment()
+	assert.Equal(t, "person", iseg.GetLabel())
+	assert.Equal(t, int32(height), iseg.GetHeight())
+	assert.Equal(t, int32(width), iseg.GetWidth())
+	assert.InDelta(t, 0.9, pred[0][0].GetProbability(), 1e-6)
+	assert.Equal(t, "car", pred[0][1].GetInstanceSegment().GetLabel())
+}
 
-// 	height := img.Bounds().Dy()
-// 	width := img.Bounds().Dx()
-// 	channels := 3
-// 	input := make([]gotensor.Tensor, batchSize)
-// 	imgBytes := img.(*types.RGBImage).Pix
+func TestInstanceSegmentationBatchSize(t *testing.T) {
+	ctx := context.Background()
+	p := &InstanceSegmentationPredictor{}
+	p.Base = common.Base{
+		Model:   dlframework.ModelManifest{Name: "fake", Output: &dlframework.ModelManifest_Type{}},
+		Options: options.New(options.BatchSize(2)),
+	}
+	p.labels = []string{"background", "person"}
 
-// 	for ii := 0; ii < batchSize; ii++ {
-// 		input[ii] = gotensor.New(
-// 			gotensor.WithShape(height, width, channels),
-// 			gotensor.WithBacking(imgBytes),
-// 		)
-// 	}
+	// the model segments one image at a time
+	assert.Error(t, p.loadModelFiles(ctx))
+	p.Options = options.New(options.BatchSize(1))
+	assert.NoError(t, p.loadModelFiles(ctx))
 
-//  joined, err := gotensor.Concat(0, input[0], input[1:]...)
-//  if err != nil {
-//    return
-//  }
-//  joined.Reshape(append([]int{len(input)}, input[0].Shape()...)...)
+	masks := gotensor.New(gotensor.WithShape(1, 1, 2, 2), gotensor.WithBacking([]float32{1, 0, 0, 1}))
+	labels := gotensor.New(gotensor.WithShape(1), gotensor.WithBacking([]int64{1}))
+	scores := gotensor.New(gotensor.WithShape(1), gotensor.WithBacking([]float32{0.9}))
+	for _, shape := range [][]int{{1, 4}, {1, 1, 4}} {
+		boxes := gotensor.New(gotensor.WithShape(shape...), gotensor.WithBacking([]float32{0, 0, 2, 2}))
+		features, err := p.decodeOutputs(ctx, []gotensor.Tensor{boxes, labels, scores, masks})
+		assert.NoError(t, err, "boxes of shape %v", shape)
+		if assert.Len(t, features, 1) && assert.Len(t, features[0], 1) {
+			assert.Equal(t, "person", features[0][0].GetInstanceSegment().GetLabel())
+		}
+	}
 
-//  err = predictor.Predict(ctx, []gotensor.Tensor{joined})
+	// the single detection outputs are read as slices rather than scalars
+	features, err := p.decodeOutputs(ctx, []gotensor.Tensor{
+		gotensor.New(gotensor.WithShape(1, 4), gotensor.WithBacking([]float32{0, 0, 2, 2})),
+		gotensor.New(gotensor.FromScalar(int64(1))),
+		gotensor.New(gotensor.FromScalar(float32(0.9))),
+		masks,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, features, 1)
 
-// 	assert.NoError(t, err)
-// 	if err != nil {
-// 		return
-// 	}
-
-// 	pred, err := predictor.ReadPredictedFeatures(ctx)
-// 	assert.NoError(t, err)
-// 	if err != nil {
-// 		return
-// 	}
-
-// 	assert.InDelta(t, float32(0.998607), pred[0][0].GetProbability(), 0.001)
-// }
+	// the boxes of several images are an error rather than a misread batch
+	boxes := gotensor.New(gotensor.WithShape(2, 1, 4), gotensor.WithBacking([]float32{0, 0, 2, 2, 0, 0, 2, 2}))
+	_, err = p.decodeOutputs(ctx, []gotensor.Tensor{boxes, labels, scores, masks})
+	assert.Error(t, err)
+}
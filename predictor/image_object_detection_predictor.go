@@ -1,23 +1,17 @@
 package predictor
 
 import (
-	"bufio"
 	"context"
 	"io"
-	"os"
-	"strings"
 
 	"github.com/c3sr/config"
 	"github.com/c3sr/dlframework"
 	"github.com/c3sr/dlframework/framework/agent"
+	"github.com/c3sr/dlframework/framework/feature"
 	"github.com/c3sr/dlframework/framework/options"
 	common "github.com/c3sr/dlframework/framework/predictor"
-	"github.com/c3sr/downloadmanager"
-	gopytorch "github.com/c3sr/go-pytorch"
 	"github.com/c3sr/pytorch"
 	"github.com/c3sr/tracer"
-	opentracing "github.com/opentracing/opentracing-go"
-	olog "github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
 	gotensor "gorgonia.org/tensor"
 )
@@ -25,15 +19,14 @@ import (
 // ObjectDetectionPredictor ...
 type ObjectDetectionPredictor struct {
 	common.ImagePredictor
-	predictor          *gopytorch.Predictor
-	labels             []string
+	torchPredictor
 	inputLayer         string
-	boxesLayer         string
-	probabilitiesLayer string
-	classesLayer       string
-	boxes              interface{}
-	probabilities      interface{}
-	classes            interface{}
+	boxesLayer         int
+	probabilitiesLayer int
+	classesLayer       int
+	anchors            []float32
+	anchorVariances    []float32
+	postprocess        detectionPostprocessOptions
 }
 
 // NewObjectDetectionPredictor ...
@@ -44,13 +37,8 @@ func NewObjectDetectionPredictor(model dlframework.ModelManifest, os ...options.
 	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "new_predictor")
 	defer span.Finish()
 
-	modelInputs := model.GetInputs()
-	if len(modelInputs) != 1 {
-		return nil, errors.New("number of inputs not supported")
-	}
-	firstInputType := modelInputs[0].GetType()
-	if strings.ToLower(firstInputType) != "image" {
-		return nil, errors.New("input type not supported")
+	if err := checkImageModelInputs(model); err != nil {
+		return nil, err
 	}
 
 	predictor := new(ObjectDetectionPredictor)
@@ -60,165 +48,59 @@ func NewObjectDetectionPredictor(model dlframework.ModelManifest, os ...options.
 
 // Download ...
 func (p *ObjectDetectionPredictor) Download(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) error {
-	framework, err := model.ResolveFramework()
-	if err != nil {
-		return err
-	}
-
-	workDir, err := model.WorkDir()
+	base, err := newTorchBase(model, opts...)
 	if err != nil {
 		return err
 	}
 
 	ip := &ObjectDetectionPredictor{
 		ImagePredictor: common.ImagePredictor{
-			Base: common.Base{
-				Framework: framework,
-				Model:     model,
-				WorkDir:   workDir,
-				Options:   options.New(opts...),
-			},
+			Base: base,
 		},
 	}
 
-	if err = ip.download(ctx); err != nil {
-		return err
-	}
-
-	return nil
+	return downloadModel(ctx, ip.Base, ip.modelFiles())
 }
 
 // Load ...
 func (p *ObjectDetectionPredictor) Load(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) (common.Predictor, error) {
-	framework, err := model.ResolveFramework()
-	if err != nil {
-		return nil, err
-	}
-
-	workDir, err := model.WorkDir()
+	base, err := newTorchBase(model, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	ip := &ObjectDetectionPredictor{
 		ImagePredictor: common.ImagePredictor{
-			Base: common.Base{
-				Framework: framework,
-				Model:     model,
-				WorkDir:   workDir,
-				Options:   options.New(opts...),
-			},
+			Base: base,
 		},
 	}
 
-	if err = ip.download(ctx); err != nil {
-		return nil, err
-	}
-
-	if err = ip.loadPredictor(ctx); err != nil {
+	if err = ip.load(ctx, ip.Base, ip); err != nil {
 		return nil, err
 	}
 
 	return ip, nil
 }
 
-func (p *ObjectDetectionPredictor) download(ctx context.Context) error {
-	span, ctx := tracer.StartSpanFromContext(
-		ctx,
-		tracer.APPLICATION_TRACE,
-		"download",
-		opentracing.Tags{
-			"graph_url":           p.GetGraphUrl(),
-			"target_graph_file":   p.GetGraphPath(),
-			"feature_url":         p.GetFeaturesUrl(),
-			"target_feature_file": p.GetFeaturesPath(),
-		},
-	)
-	defer span.Finish()
-
-	model := p.Model
-	if model.Model.IsArchive {
-		baseURL := model.Model.BaseUrl
-		span.LogFields(
-			olog.String("event", "download model archive"),
-		)
-		_, err := downloadmanager.DownloadInto(baseURL, p.WorkDir, downloadmanager.Context(ctx))
-		if err != nil {
-			return errors.Wrapf(err, "failed to download model archive from %v", model.Model.BaseUrl)
-		}
-	} else {
-		span.LogFields(
-			olog.String("event", "download graph"),
-		)
-		checksum := p.GetGraphChecksum()
-		if checksum != "" {
-			if _, _, err := downloadmanager.DownloadFile(p.GetGraphUrl(), p.GetGraphPath(), downloadmanager.MD5Sum(checksum)); err != nil {
-				return err
-			}
-		} else {
-			if _, _, err := downloadmanager.DownloadFile(p.GetGraphUrl(), p.GetGraphPath()); err != nil {
-				return err
-			}
-		}
-	}
-
-	span.LogFields(
-		olog.String("event", "download features"),
-	)
-	checksum := p.GetFeaturesChecksum()
-	if checksum != "" {
-		if _, _, err := downloadmanager.DownloadFile(p.GetFeaturesUrl(), p.GetFeaturesPath(), downloadmanager.MD5Sum(checksum)); err != nil {
-			return err
-		}
-	} else {
-		if _, _, err := downloadmanager.DownloadFile(p.GetFeaturesUrl(), p.GetFeaturesPath()); err != nil {
-			return err
-		}
-	}
-
-	return nil
+func (p *ObjectDetectionPredictor) modelFiles() []modelFile {
+	return []modelFile{featuresFile(p.Base)}
 }
 
-func (p *ObjectDetectionPredictor) loadPredictor(ctx context.Context) error {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "load_predictor")
-	defer span.Finish()
-
-	span.LogFields(
-		olog.String("event", "read features"),
-	)
+func (p *ObjectDetectionPredictor) loadModelFiles(ctx context.Context) error {
+	p.probabilitiesLayer = p.outputIndex("probabilities_layer", 0)
+	p.boxesLayer = p.outputIndex("boxes_layer", 1)
+	p.classesLayer = p.outputIndex("classes_layer", -1)
 
-	var labels []string
-	f, err := os.Open(p.GetFeaturesPath())
+	anchorSpec, err := ssdAnchorSpecFromManifest(p.Base)
 	if err != nil {
-		return errors.Wrapf(err, "cannot read %s", p.GetFeaturesPath())
-	}
-	defer f.Close()
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		line := scanner.Text()
-		labels = append(labels, line)
+		return errors.Wrap(err, "invalid anchor parameters")
 	}
-	p.labels = labels
-
-	span.LogFields(
-		olog.String("event", "creating predictor"),
-	)
-
-	opts, err := p.GetPredictionOptions()
-	if err != nil {
-		return err
-	}
-
-	pred, err := gopytorch.New(
-		ctx,
-		options.WithOptions(opts),
-		options.Graph([]byte(p.GetGraphPath())),
-	)
-	if err != nil {
-		return err
+	if anchorSpec != nil {
+		p.anchors = anchorSpec.generate()
+		p.anchorVariances = anchorSpec.variances
 	}
 
-	p.predictor = pred
+	p.postprocess = p.postprocessOptions()
 
 	return nil
 }
@@ -249,91 +131,185 @@ func (p *ObjectDetectionPredictor) GetOutputLayerName(reader io.Reader, layer st
 	return name, nil
 }
 
-// Predict ...
-func (p *ObjectDetectionPredictor) Predict(ctx context.Context, data interface{}, opts ...options.Option) error {
-	if data == nil {
-		return errors.New("input data nil")
+// outputIndex returns the index of the output tensor named by the layer
+// parameter in the manifest, or defaultIndex when the parameter is not set.
+func (p *ObjectDetectionPredictor) outputIndex(layer string, defaultIndex int) int {
+	idx, err := p.GetOutputLayerIndex(layer)
+	if err != nil {
+		return defaultIndex
+	}
+	return idx
+}
+
+// postprocessOptions resolves the detection post-processing settings of a request.
+func (p *ObjectDetectionPredictor) postprocessOptions(opts ...options.Option) detectionPostprocessOptions {
+	res := detectionPostprocessOptions{
+		scoreThreshold:  getOutputFloat32Parameter(p.Base, "score_threshold", 0),
+		nmsThreshold:    getOutputFloat32Parameter(p.Base, "nms_threshold", 0),
+		maxDetections:   getOutputIntParameter(p.Base, "max_detections", 0),
+		softNMSSigma:    getOutputFloat32Parameter(p.Base, "soft_nms_sigma", 0),
+		backgroundIndex: -1,
+	}
+	if ok, idx := p.GetBackgroundIndex(); ok {
+		res.backgroundIndex = idx
+	}
+	return res.withOverrides(p.Options.Context()).withOverrides(options.New(opts...).Context())
+}
+
+// prepare converts the data into the input tensors of the model.
+func (p *ObjectDetectionPredictor) prepare(ctx context.Context, data interface{}, opts ...options.Option) ([]gotensor.Tensor, outputDecoder, error) {
+	postprocess := p.postprocessOptions(opts...)
+
+	gotensors, err := imageInputTensors(ctx, p.Model, data)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	gotensors, ok := data.([]gotensor.Tensor)
-	if !ok {
-		return errors.New("input data is not slice of dense tensors")
+	// the detections are split between the images of the request, which may be fewer than the batch size
+	batchSize := p.BatchSize()
+	if shape := gotensors[0].Shape(); len(shape) > 0 {
+		batchSize = shape[0]
 	}
 
-	return p.predictor.Predict(ctx, gotensors)
+	return gotensors, func(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+		return p.decodeFeatures(ctx, outputs, batchSize, postprocess)
+	}, nil
 }
 
-// ReadPredictedFeatures ...
-func (p *ObjectDetectionPredictor) ReadPredictedFeatures(ctx context.Context) ([]dlframework.Features, error) {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features")
-	defer span.Finish()
+// decodeOutputs decodes the outputs of a full batch with the postprocessing options of the manifest and of the Load.
+func (p *ObjectDetectionPredictor) decodeOutputs(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	return p.decodeFeatures(ctx, outputs, p.BatchSize(), p.postprocess)
+}
+
+// decodeBatch decodes the outputs of batchSize images with the postprocessing options of the manifest and of the Load.
+func (p *ObjectDetectionPredictor) decodeBatch(ctx context.Context, outputs []gotensor.Tensor, batchSize int) ([]dlframework.Features, error) {
+	return p.decodeFeatures(ctx, outputs, batchSize, p.postprocess)
+}
 
-	outputs, err := p.predictor.ReadPredictionOutput(ctx)
+func (p *ObjectDetectionPredictor) decodeFeatures(ctx context.Context, outputs []gotensor.Tensor, batchSize int,
+	postprocess detectionPostprocessOptions) ([]dlframework.Features, error) {
+	probabilities, classes, boxes, err := decodeDetections(outputs, p.boxesLayer, p.probabilitiesLayer, p.classesLayer)
 	if err != nil {
 		return nil, err
 	}
+	if p.anchors != nil {
+		boxes, err = decodeAnchorOffsets(boxes, p.anchors, p.anchorVariances, p.GetBoxIndex())
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, class := range classes {
+		if int(class) < 0 || int(class) >= len(p.labels) {
+			return nil, errors.Errorf("class index %v is out of range of the %v labels", int(class), len(p.labels))
+		}
+	}
+	if classes == nil {
+		// the class-score heads have at least a box
+		if numClasses := len(probabilities) / (len(boxes) / 4); numClasses > len(p.labels) {
+			return nil, errors.Errorf("the model scores %v classes, but there are %v labels", numClasses, len(p.labels))
+		}
+	}
 
-	scores := outputs[0].Data().([]float32)
-	boxes := outputs[1].Data().([]float32)
-	var inputclasses []float32
-	var inputscores []float32
-	for curObj := 0; curObj < len(boxes)/4; curObj++ {
-		maxscore := scores[curObj*len(p.labels)]
-		var maxindex int
-		maxindex = 0
-		for i := 1; i < len(p.labels); i++ {
-			sc := scores[curObj*len(p.labels)+i]
-			if sc > maxscore {
-				maxscore = sc
-				maxindex = i
+	if numBoxes := len(boxes) / 4; batchSize < 1 || numBoxes%batchSize != 0 {
+		return nil, errors.Errorf("cannot split %v detections into a batch of %v", numBoxes, batchSize)
+	}
+
+	batchProbabilities, batchClasses, batchBoxes := postprocessDetections(probabilities, classes, boxes, batchSize, p.GetBoxIndex(), postprocess)
+
+	return p.createBoundingBoxFeatures(batchProbabilities, batchClasses, batchBoxes), nil
+}
+
+// createBoundingBoxFeatures builds the bounding boxes of every image of the batch, in the order of postprocessDetections.
+// Unlike the CreateBoundingBoxFeatures of the ImagePredictor, the batch follows the detections rather than the batch size of the Load.
+func (p *ObjectDetectionPredictor) createBoundingBoxFeatures(probabilities, classes [][]float32, boxes [][][]float32) []dlframework.Features {
+	boxIndex := p.GetBoxIndex()
+	scaleWidth, scaleHeight := p.GetBoxScaling()
+	features := make([]dlframework.Features, len(probabilities))
+	for b := range probabilities {
+		features[b] = make(dlframework.Features, len(probabilities[b]))
+		for ii, probability := range probabilities[b] {
+			class := int(classes[b][ii])
+			label := p.labels[class]
+			if probability < 0 {
+				label = "none"
 			}
+			box := boxes[b][ii]
+			features[b][ii] = feature.New(
+				feature.BoundingBoxType(),
+				feature.BoundingBoxXmin(box[boxIndex[1]]/scaleWidth),
+				feature.BoundingBoxXmax(box[boxIndex[3]]/scaleWidth),
+				feature.BoundingBoxYmin(box[boxIndex[0]]/scaleHeight),
+				feature.BoundingBoxYmax(box[boxIndex[2]]/scaleHeight),
+				feature.BoundingBoxIndex(int32(class)),
+				feature.BoundingBoxLabel(label),
+				feature.Probability(probability),
+			)
 		}
-		inputscores = append(inputscores, float32(maxscore))
-		inputclasses = append(inputclasses, float32(maxindex))
 	}
-	dims := []int{1, len(boxes) / 4}
-	tensorclasses := gotensor.New(
-		gotensor.Of(gotensor.Float32),
-		gotensor.WithBacking(inputclasses),
-		gotensor.WithShape(dims...),
-	)
-	tensorscores := gotensor.New(
-		gotensor.Of(gotensor.Float32),
-		gotensor.WithBacking(inputscores),
-		gotensor.WithShape(dims...),
-	)
-
-	return p.CreateBoundingBoxFeatures(ctx, tensorscores, tensorclasses, outputs[1], p.labels)
+	return features
 }
 
-// ReadPredictedFeaturesAsMap ...
-func (p *ObjectDetectionPredictor) ReadPredictedFeaturesAsMap(ctx context.Context) (map[string]interface{}, error) {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features_as_map")
-	defer span.Finish()
+// decodeDetections flattens the detection outputs into per-box probabilities, classes and boxes.
+// The boxes are kept in the coordinate order and scale of the model, the xmin_index/ymin_index/xmax_index/ymax_index,
+// scale_width/scale_height and background_index parameters of the manifest are applied when creating the features.
+//
+// Two kinds of heads are supported:
+//   - when classesLayer is negative, the probabilities output is a class-score matrix of shape [N, C]
+//     (as produced by SSD heads), which is returned as is without classes: postprocessDetections
+//     makes each box a candidate of every foreground class;
+//   - otherwise the outputs are torchvision-style boxes [N, 4], labels [N] and scores [N] tensors.
+//
+// A leading batch dimension is allowed on all the outputs.
+func decodeDetections(outputs []gotensor.Tensor, boxesLayer, probabilitiesLayer, classesLayer int) ([]float32, []float32, []float32, error) {
+	for _, idx := range []int{boxesLayer, probabilitiesLayer, classesLayer} {
+		if idx >= len(outputs) {
+			return nil, nil, nil, errors.Errorf("output index %v is out of range, the model returned %v outputs", idx, len(outputs))
+		}
+	}
+	if boxesLayer < 0 || probabilitiesLayer < 0 {
+		return nil, nil, nil, errors.New("the boxes and probabilities output indices must be set")
+	}
 
-	outputs, err := p.predictor.ReadPredictionOutput(ctx)
+	boxes, err := tensorToFloat32s(outputs[boxesLayer])
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, errors.Wrap(err, "cannot read the boxes output")
+	}
+	if len(boxes)%4 != 0 {
+		return nil, nil, nil, errors.Errorf("expecting the boxes output to have 4 coordinates per box, but got shape %v", outputs[boxesLayer].Shape())
 	}
+	numBoxes := len(boxes) / 4
 
-	res := make(map[string]interface{})
-	res["outputs"] = outputs
-	res["labels"] = p.labels
+	scores, err := tensorToFloat32s(outputs[probabilitiesLayer])
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "cannot read the probabilities output")
+	}
 
-	return res, nil
+	if classesLayer >= 0 {
+		classes, err := tensorToFloat32s(outputs[classesLayer])
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "cannot read the classes output")
+		}
+		if len(scores) != numBoxes || len(classes) != numBoxes {
+			return nil, nil, nil, errors.Errorf("mismatched number of detections: %v boxes, %v classes and %v probabilities",
+				numBoxes, len(classes), len(scores))
+		}
+		return scores, classes, boxes, nil
+	}
+
+	if numBoxes == 0 || len(scores)%numBoxes != 0 {
+		return nil, nil, nil, errors.Errorf("expecting a class-score matrix for %v boxes, but got %v probabilities", numBoxes, len(scores))
+	}
+	return scores, nil, boxes, nil
 }
 
 // Reset ...
 func (p *ObjectDetectionPredictor) Reset(ctx context.Context) error {
-	return nil
+	return p.torchPredictor.Reset(ctx)
 }
 
 // Close ...
 func (p *ObjectDetectionPredictor) Close() error {
-	if p.predictor != nil {
-		p.predictor.Close()
-	}
-	return nil
+	return p.torchPredictor.Close()
 }
 
 // Modality ...
@@ -3,13 +3,11 @@ package predictor
 import (
 	"context"
 	"fmt"
-	"os"
+	"io/ioutil"
 	"path/filepath"
 	"testing"
 
 	"github.com/c3sr/dlframework/framework/options"
-	raiimage "github.com/c3sr/image"
-	"github.com/c3sr/image/types"
 	nvidiasmi "github.com/c3sr/nvidia-smi"
 	py "github.com/c3sr/pytorch"
 	"github.com/stretchr/testify/assert"
@@ -38,59 +36,30 @@ func TestObjectDetection(t *testing.T) {
 	assert.NotEmpty(t, predictor)
 	defer predictor.Close()
 
-	imgDir, _ := filepath.Abs("./_fixtures")
-	imgPath := filepath.Join(imgDir, "lane_control.jpg")
-	r, err := os.Open(imgPath)
-	if err != nil {
-		panic(err)
-	}
-
-	preprocessOpts, err := predictor.GetPreprocessOptions()
+	preprocessor, err := NewImagePreprocessor(*model)
 	assert.NoError(t, err)
-	channels := preprocessOpts.Dims[0]
-	height := preprocessOpts.Dims[1]
-	width := preprocessOpts.Dims[2]
-	mode := preprocessOpts.ColorMode
-
-	var imgOpts []raiimage.Option
-	if mode == types.RGBMode {
-		imgOpts = append(imgOpts, raiimage.Mode(types.RGBMode))
-	} else {
-		imgOpts = append(imgOpts, raiimage.Mode(types.BGRMode))
-	}
-
-	img, err := raiimage.Read(r, imgOpts...)
-	if err != nil {
-		panic(err)
-	}
-
-	imgOpts = append(imgOpts, raiimage.Resized(height, width))
-	imgOpts = append(imgOpts, raiimage.ResizeAlgorithm(types.ResizeAlgorithmLinear))
-	resized, err := raiimage.Resize(img, imgOpts...)
 	if err != nil {
-		panic(err)
+		return
 	}
 
-	input := make([]gotensor.Tensor, batchSize)
-	imgFloats, err := normalizeImageCHW(resized, preprocessOpts.MeanImage, preprocessOpts.Scale)
+	imgDir, _ := filepath.Abs("./_fixtures")
+	imgPath := filepath.Join(imgDir, "lane_control.jpg")
+	imgBytes, err := ioutil.ReadFile(imgPath)
 	if err != nil {
 		panic(err)
 	}
 
-	for ii := 0; ii < batchSize; ii++ {
-		input[ii] = gotensor.New(
-			gotensor.WithShape(channels, height, width),
-			gotensor.WithBacking(imgFloats),
-		)
+	batch := make([][]byte, batchSize)
+	for ii := range batch {
+		batch[ii] = imgBytes
 	}
-
-	joined, err := gotensor.Concat(0, input[0], input[1:]...)
+	input, err := preprocessor.PreprocessBytes(ctx, batch)
+	assert.NoError(t, err)
 	if err != nil {
 		return
 	}
-	joined.Reshape(append([]int{len(input)}, input[0].Shape()...)...)
 
-	err = predictor.Predict(ctx, []gotensor.Tensor{joined})
+	err = predictor.Predict(ctx, []gotensor.Tensor{input})
 
 	assert.NoError(t, err)
 	if err != nil {
@@ -116,3 +85,65 @@ func TestObjectDetection(t *testing.T) {
 		}
 	}
 }
+
+func TestDecodeDetectionsClassScoreMatrix(t *testing.T) {
+	scores := gotensor.New(
+		gotensor.WithShape(1, 2, 3),
+		gotensor.WithBacking([]float32{
+			0.1, 0.7, 0.2,
+			0.6, 0.1, 0.3,
+		}),
+	)
+	boxes := gotensor.New(
+		gotensor.WithShape(1, 2, 4),
+		gotensor.WithBacking([]float32{
+			0.1, 0.2, 0.3, 0.4,
+			0.5, 0.6, 0.7, 0.8,
+		}),
+	)
+
+	probabilities, classes, decodedBoxes, err := decodeDetections([]gotensor.Tensor{scores, boxes}, 1, 0, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, scores.Data(), probabilities)
+	assert.Nil(t, classes)
+	assert.Equal(t, boxes.Data(), decodedBoxes)
+
+	// each box is a candidate of the foreground classes above the threshold, even when the background scores higher
+	opts := detectionPostprocessOptions{scoreThreshold: 0.25, backgroundIndex: 0}
+	batchProbabilities, batchClasses, batchBoxes := postprocessDetections(probabilities, classes, decodedBoxes, 1, xyxyBoxIndex, opts)
+	assert.Equal(t, [][]float32{{0.7, 0.3}}, batchProbabilities)
+	assert.Equal(t, [][]float32{{1, 2}}, batchClasses)
+	assert.Equal(t, [][][]float32{{{0.1, 0.2, 0.3, 0.4}, {0.5, 0.6, 0.7, 0.8}}}, batchBoxes)
+
+	opts.scoreThreshold = 0
+	batchProbabilities, batchClasses, _ = postprocessDetections(probabilities, classes, decodedBoxes, 1, xyxyBoxIndex, opts)
+	assert.Equal(t, [][]float32{{0.7, 0.3, 0.2, 0.1}}, batchProbabilities)
+	assert.Equal(t, [][]float32{{1, 2, 2, 1}}, batchClasses)
+}
+
+func TestDecodeDetectionsBoxesLabelsScores(t *testing.T) {
+	boxes := gotensor.New(
+		gotensor.WithShape(2, 4),
+		gotensor.WithBacking([]float32{
+			10, 20, 30, 40,
+			50, 60, 70, 80,
+		}),
+	)
+	labels := gotensor.New(
+		gotensor.WithShape(2),
+		gotensor.WithBacking([]int64{3, 7}),
+	)
+	scores := gotensor.New(
+		gotensor.WithShape(2),
+		gotensor.WithBacking([]float32{0.9, 0.4}),
+	)
+
+	probabilities, classes, decodedBoxes, err := decodeDetections([]gotensor.Tensor{boxes, labels, scores}, 0, 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{0.9, 0.4}, probabilities)
+	assert.Equal(t, []float32{3, 7}, classes)
+	assert.Equal(t, boxes.Data(), decodedBoxes)
+
+	_, _, _, err = decodeDetections([]gotensor.Tensor{boxes, labels}, 0, 2, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package predictor
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"strings"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/feature"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/pkg/errors"
+)
+
+// ImageEncoding selects how the images produced by a model are returned.
+type ImageEncoding string
+
+const (
+	// EncodingRaw returns the float pixels of the images as raw image features.
+	EncodingRaw ImageEncoding = "raw"
+	// EncodingPNG returns the images as PNG encoded image features.
+	EncodingPNG ImageEncoding = "png"
+	// EncodingJPEG returns the images as JPEG encoded image features. The alpha channel is dropped.
+	EncodingJPEG ImageEncoding = "jpeg"
+)
+
+// imagePostprocessOptions holds how the images produced by a model are returned.
+type imagePostprocessOptions struct {
+	// clamp limits the pixels to the [0, 255] range of the images
+	clamp       bool
+	encoding    ImageEncoding
+	jpegQuality int
+}
+
+// imagePostprocessOptionsFromManifest reads the clamp, encoding and jpeg_quality output parameters.
+func imagePostprocessOptionsFromManifest(base common.Base) imagePostprocessOptions {
+	res := imagePostprocessOptions{
+		clamp:       getOutputBoolParameter(base, "clamp", true),
+		encoding:    EncodingRaw,
+		jpegQuality: getOutputIntParameter(base, "jpeg_quality", jpeg.DefaultQuality),
+	}
+	if encoding := strings.ToLower(strings.TrimSpace(getOutputParameter(base, "encoding"))); encoding != "" {
+		res.encoding = ImageEncoding(encoding)
+	}
+	return res
+}
+
+// withOverrides returns the options overridden by the values set on the context
+// through OutputEncoding and JPEGQuality.
+func (o imagePostprocessOptions) withOverrides(ctx context.Context) imagePostprocessOptions {
+	if ctx == nil {
+		return o
+	}
+	if val, ok := ctx.Value(imageEncodingKey{}).(ImageEncoding); ok {
+		o.encoding = val
+	}
+	if val, ok := ctx.Value(jpegQualityKey{}).(int); ok {
+		o.jpegQuality = val
+	}
+	return o
+}
+
+func (o imagePostprocessOptions) validate() error {
+	switch o.encoding {
+	case "", EncodingRaw, EncodingPNG, EncodingJPEG:
+	default:
+		return errors.Errorf("image encoding %v is not supported", o.encoding)
+	}
+	if o.jpegQuality < 1 || o.jpegQuality > 100 {
+		return errors.Errorf("the jpeg quality must be between 1 and 100, but got %v", o.jpegQuality)
+	}
+	return nil
+}
+
+// channelParameters returns the mean or the scale of every channel of a grayscale, RGB or RGBA output image.
+// A single value applies to every channel. The RGBA outputs take alpha for their alpha channel when
+// the manifest only describes the color channels, as the inputs are normalized without one.
+func channelParameters(name string, values []float32, channels int, alpha float32) ([]float32, error) {
+	if channels != 1 && channels != 3 && channels != 4 {
+		return nil, errors.Errorf("expecting an image of 1, 3 or 4 channels, but got %v channels", channels)
+	}
+	res := make([]float32, channels)
+	switch {
+	case len(values) == 1:
+		for c := range res {
+			res[c] = values[0]
+		}
+	case len(values) >= channels:
+		copy(res, values)
+	case channels == 4 && len(values) == 3:
+		copy(res, values)
+		res[3] = alpha
+	default:
+		return nil, errors.Errorf("expecting 1 or %v %v values for the %v channels, but got %v", channels, name, channels, values)
+	}
+	return res, nil
+}
+
+// encodedImageFeatures encodes the HWC pixels of the images, clamped to [0, 255] and rounded.
+// The channels of the BGR images are swapped into RGB.
+func encodedImageFeatures(images [][]float32, width, height, channels int, bgr bool, opts imagePostprocessOptions) ([]dlframework.Features, error) {
+	features := make([]dlframework.Features, len(images))
+	for b, pixels := range images {
+		img := pixelsToImage(pixels, width, height, channels, bgr)
+		var buf bytes.Buffer
+		var err error
+		if opts.encoding == EncodingJPEG {
+			err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: opts.jpegQuality})
+		} else {
+			err = png.Encode(&buf, img)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot encode image %v as %v", b, opts.encoding)
+		}
+		features[b] = dlframework.Features{
+			feature.New(
+				feature.ImageType(),
+				feature.ImageData(buf.Bytes()),
+				feature.AppendMetadata("encoding", string(opts.encoding)),
+			),
+		}
+	}
+	return features, nil
+}
+
+// pixelsToImage converts the HWC pixels of a grayscale, RGB or RGBA image into an 8 bit image.
+func pixelsToImage(pixels []float32, width, height, channels int, bgr bool) image.Image {
+	rect := image.Rect(0, 0, width, height)
+	if channels == 1 {
+		img := image.NewGray(rect)
+		for ii, v := range pixels {
+			img.Pix[ii] = toUint8(v)
+		}
+		return img
+	}
+
+	// the RGBA outputs are not premultiplied, while the RGB ones are opaque
+	img := image.NewNRGBA(rect)
+	for ii := 0; ii < width*height; ii++ {
+		src, dst := pixels[ii*channels:(ii+1)*channels], img.Pix[ii*4:ii*4+4]
+		dst[0], dst[1], dst[2], dst[3] = toUint8(src[0]), toUint8(src[1]), toUint8(src[2]), 255
+		if bgr {
+			dst[0], dst[2] = dst[2], dst[0]
+		}
+		if channels == 4 {
+			dst[3] = toUint8(src[3])
+		}
+	}
+	return img
+}
+
+func toUint8(v float32) uint8 {
+	if !(v >= 0) {
+		return 0
+	}
+	if v >= 255 {
+		return 255
+	}
+	return uint8(math.Round(float64(v)))
+}
@@ -0,0 +1,146 @@
+package predictor
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	"image/png"
+	"math"
+	"testing"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/options"
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+func TestChannelParameters(t *testing.T) {
+	res, err := channelParameters("mean", []float32{1, 2, 3}, 3, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1, 2, 3}, res)
+
+	res, err = channelParameters("scale", []float32{255}, 4, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{255, 255, 255, 255}, res)
+
+	res, err = channelParameters("mean", []float32{1, 2, 3}, 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1}, res)
+
+	res, err = channelParameters("scale", []float32{2, 3, 4}, 4, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{2, 3, 4, 2}, res)
+
+	_, err = channelParameters("mean", []float32{1, 2}, 3, 0)
+	assert.Error(t, err)
+	_, err = channelParameters("mean", []float32{1}, 2, 0)
+	assert.Error(t, err)
+}
+
+func TestDenormalizeImages(t *testing.T) {
+	output := gotensor.New(gotensor.WithShape(1, 1, 1, 4), gotensor.WithBacking([]float32{-0.5, 0.5, 2, float32(math.NaN())}))
+	view, err := newFloat32View(output, 4)
+	assert.NoError(t, err)
+
+	assert.Equal(t, [][]float32{{0, 127.5, 255, 0}}, denormalizeImages(view, []float32{255}, []float32{0}, true))
+	assert.Equal(t, []float32{-127.5, 127.5, 510}, denormalizeImages(view, []float32{255}, []float32{0}, false)[0][:3])
+}
+
+func TestEncodedImageFeatures(t *testing.T) {
+	decode := func(features []dlframework.Features) image.Image {
+		img, _, err := image.Decode(bytes.NewReader(features[0][0].GetImage().GetData()))
+		assert.NoError(t, err)
+		return img
+	}
+	opts := imagePostprocessOptions{encoding: EncodingPNG, jpegQuality: 90}
+
+	features, err := encodedImageFeatures([][]float32{{0, 127.6, 300}}, 3, 1, 1, false, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "png", features[0][0].GetMetadata()["encoding"])
+	img := decode(features)
+	for x, v := range []uint8{0, 128, 255} {
+		assert.Equal(t, color.Gray{Y: v}, color.GrayModel.Convert(img.At(x, 0)))
+	}
+
+	// the BGR pixels are swapped into RGB
+	features, err = encodedImageFeatures([][]float32{{10, 20, 30}}, 1, 1, 3, true, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, color.NRGBA{R: 30, G: 20, B: 10, A: 255}, color.NRGBAModel.Convert(decode(features).At(0, 0)))
+
+	features, err = encodedImageFeatures([][]float32{{10, 20, 30, 128}}, 1, 1, 4, false, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, color.NRGBA{R: 10, G: 20, B: 30, A: 128}, color.NRGBAModel.Convert(decode(features).At(0, 0)))
+
+	opts.encoding = EncodingJPEG
+	pixels := make([]float32, 8*8*3)
+	for ii := range pixels {
+		pixels[ii] = 200
+	}
+	features, err = encodedImageFeatures([][]float32{pixels}, 8, 8, 3, false, opts)
+	assert.NoError(t, err)
+	r, g, b, _ := decode(features).At(4, 4).RGBA()
+	assert.InDelta(t, 200, r>>8, 2)
+	assert.InDelta(t, 200, g>>8, 2)
+	assert.InDelta(t, 200, b>>8, 2)
+
+	assert.Error(t, imagePostprocessOptions{encoding: "gif", jpegQuality: 90}.validate())
+	assert.Error(t, imagePostprocessOptions{encoding: EncodingJPEG, jpegQuality: 0}.validate())
+}
+
+func TestImageEnhancementPostprocess(t *testing.T) {
+	ctx := context.Background()
+	p := &ImageEnhancementPredictor{}
+	p.Base = outputDimensionsBase("")
+	p.Options = options.New()
+	p.Model.Inputs = []*dlframework.ModelManifest_Type{{
+		Type: "image",
+		Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+			"mean":  {Value: "[0, 0, 0]"},
+			"scale": {Value: "255"},
+		},
+	}}
+	assert.NoError(t, p.loadModelFiles(ctx))
+
+	// the SRGAN outputs are in [0, 1], but overshoot it
+	rgb := gotensor.New(gotensor.WithShape(1, 3, 1, 2), gotensor.WithBacking([]float32{-0.1, 0.5, 1.2, 1, 0, 0.2}))
+	features, err := p.decodeOutputs(ctx, []gotensor.Tensor{rgb})
+	assert.NoError(t, err)
+	if assert.Len(t, features, 1) {
+		assert.Equal(t, []float32{0, 255, 0, 127.5, 255, 51}, features[0][0].GetRawImage().GetFloatList())
+	}
+
+	// the grayscale and RGBA outputs
+	gray := gotensor.New(gotensor.WithShape(1, 1, 1, 2), gotensor.WithBacking([]float32{0.2, 2}))
+	features, err = p.decodeOutputs(ctx, []gotensor.Tensor{gray})
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{51, 255}, features[0][0].GetRawImage().GetFloatList())
+	rgba := gotensor.New(gotensor.WithShape(1, 4, 1, 1), gotensor.WithBacking([]float32{1, 0, 0, 0.5}))
+	features, err = p.decodeOutputs(ctx, []gotensor.Tensor{rgba})
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{255, 0, 0, 127.5}, features[0][0].GetRawImage().GetFloatList())
+	_, err = p.decodeOutputs(ctx, []gotensor.Tensor{gotensor.New(gotensor.WithShape(1, 2, 1, 1), gotensor.Of(gotensor.Float32))})
+	assert.Error(t, err)
+
+	// the per-request encoding returns the PNG of the output
+	_, decoder, err := p.prepare(ctx, []gotensor.Tensor{rgb}, OutputEncoding(EncodingPNG))
+	assert.NoError(t, err)
+	features, err = decoder(ctx, []gotensor.Tensor{rgb})
+	assert.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(features[0][0].GetImage().GetData()))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 2, 1), img.Bounds())
+	assert.Equal(t, color.NRGBA{R: 0, G: 255, B: 0, A: 255}, color.NRGBAModel.Convert(img.At(0, 0)))
+	assert.Equal(t, color.NRGBA{R: 128, G: 255, B: 51, A: 255}, color.NRGBAModel.Convert(img.At(1, 0)))
+
+	_, _, err = p.prepare(ctx, []gotensor.Tensor{rgb}, OutputEncoding(EncodingJPEG), JPEGQuality(101))
+	assert.Error(t, err)
+
+	// the clamping can be disabled in the manifest
+	p.Model.Output.Parameters["clamp"] = &dlframework.ModelManifest_Type_Parameter{Value: "false"}
+	assert.NoError(t, p.loadModelFiles(ctx))
+	features, err = p.decodeOutputs(ctx, []gotensor.Tensor{rgb})
+	assert.NoError(t, err)
+	assert.InDelta(t, -25.5, features[0][0].GetRawImage().GetFloatList()[0], 1e-4)
+}
@@ -1,10 +1,9 @@
 package predictor
 
 import (
-	"bufio"
 	"context"
 	"io"
-	"os"
+	"strconv"
 	"strings"
 
 	"github.com/c3sr/config"
@@ -12,12 +11,8 @@ import (
 	"github.com/c3sr/dlframework/framework/agent"
 	"github.com/c3sr/dlframework/framework/options"
 	common "github.com/c3sr/dlframework/framework/predictor"
-	"github.com/c3sr/downloadmanager"
-	gopytorch "github.com/c3sr/go-pytorch"
 	"github.com/c3sr/pytorch"
 	"github.com/c3sr/tracer"
-	opentracing "github.com/opentracing/opentracing-go"
-	olog "github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
 	gotensor "gorgonia.org/tensor"
 )
@@ -25,8 +20,29 @@ import (
 // SemanticSegmentationPredictor ...
 type SemanticSegmentationPredictor struct {
 	common.ImagePredictor
-	predictor *gopytorch.Predictor
-	labels    []string
+	torchPredictor
+	masksLayer  int
+	postprocess segmentationPostprocessOptions
+}
+
+// segmentationPostprocessOptions holds how the semantic segmentation masks are returned.
+type segmentationPostprocessOptions struct {
+	// resizeMasks maps the masks back onto the source images
+	resizeMasks bool
+	export      segmentationExportOptions
+}
+
+// withOverrides returns the options overridden by the values set on the context
+// through ResizeMasks, SegmentationExports and OverlayAlpha.
+func (o segmentationPostprocessOptions) withOverrides(ctx context.Context) segmentationPostprocessOptions {
+	if ctx == nil {
+		return o
+	}
+	if val, ok := ctx.Value(resizeMasksKey{}).(bool); ok {
+		o.resizeMasks = val
+	}
+	o.export = o.export.withOverrides(ctx)
+	return o
 }
 
 // NewSemanticSegmentationPredictor ...
@@ -35,13 +51,8 @@ func NewSemanticSegmentationPredictor(model dlframework.ModelManifest, opts ...o
 	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "new_predictor")
 	defer span.Finish()
 
-	modelInputs := model.GetInputs()
-	if len(modelInputs) != 1 {
-		return nil, errors.New("number of inputs not supported")
-	}
-	firstInputType := modelInputs[0].GetType()
-	if strings.ToLower(firstInputType) != "image" {
-		return nil, errors.New("input type not supported")
+	if err := checkImageModelInputs(model); err != nil {
+		return nil, err
 	}
 
 	predictor := new(SemanticSegmentationPredictor)
@@ -51,167 +62,127 @@ func NewSemanticSegmentationPredictor(model dlframework.ModelManifest, opts ...o
 
 // Download ...
 func (p *SemanticSegmentationPredictor) Download(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) error {
-	framework, err := model.ResolveFramework()
-	if err != nil {
-		return err
-	}
-
-	workDir, err := model.WorkDir()
+	base, err := newTorchBase(model, opts...)
 	if err != nil {
 		return err
 	}
 
 	ip := &SemanticSegmentationPredictor{
 		ImagePredictor: common.ImagePredictor{
-			Base: common.Base{
-				Framework: framework,
-				Model:     model,
-				WorkDir:   workDir,
-				Options:   options.New(opts...),
-			},
+			Base: base,
 		},
 	}
 
-	if err = ip.download(ctx); err != nil {
-		return err
-	}
-
-	return nil
+	return downloadModel(ctx, ip.Base, ip.modelFiles())
 }
 
 // Load ...
 func (p *SemanticSegmentationPredictor) Load(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) (common.Predictor, error) {
-	framework, err := model.ResolveFramework()
-	if err != nil {
-		return nil, err
-	}
-
-	workDir, err := model.WorkDir()
+	base, err := newTorchBase(model, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	ip := &SemanticSegmentationPredictor{
 		ImagePredictor: common.ImagePredictor{
-			Base: common.Base{
-				Framework: framework,
-				Model:     model,
-				WorkDir:   workDir,
-				Options:   options.New(opts...),
-			},
+			Base: base,
 		},
 	}
 
-	if err = ip.download(ctx); err != nil {
-		return nil, err
-	}
-
-	if err = ip.loadPredictor(ctx); err != nil {
+	if err = ip.load(ctx, ip.Base, ip); err != nil {
 		return nil, err
 	}
 
 	return ip, nil
 }
 
-func (p *SemanticSegmentationPredictor) download(ctx context.Context) error {
-	span, ctx := tracer.StartSpanFromContext(
-		ctx,
-		tracer.APPLICATION_TRACE,
-		"download",
-		opentracing.Tags{
-			"graph_url":           p.GetGraphUrl(),
-			"target_graph_file":   p.GetGraphPath(),
-			"feature_url":         p.GetFeaturesUrl(),
-			"target_feature_file": p.GetFeaturesPath(),
-		},
-	)
-	defer span.Finish()
+func (p *SemanticSegmentationPredictor) modelFiles() []modelFile {
+	return []modelFile{featuresFile(p.Base)}
+}
 
-	model := p.Model
-	if model.Model.IsArchive {
-		baseURL := model.Model.BaseUrl
-		span.LogFields(
-			olog.String("event", "download model archive"),
-		)
-		_, err := downloadmanager.DownloadInto(baseURL, p.WorkDir, downloadmanager.Context(ctx))
-		if err != nil {
-			return errors.Wrapf(err, "failed to download model archive from %v", model.Model.BaseUrl)
-		}
-	} else {
-		span.LogFields(
-			olog.String("event", "download graph"),
-		)
-		checksum := p.GetGraphChecksum()
-		if checksum != "" {
-			if _, _, err := downloadmanager.DownloadFile(p.GetGraphUrl(), p.GetGraphPath(), downloadmanager.MD5Sum(checksum)); err != nil {
-				return err
-			}
-		} else {
-			if _, _, err := downloadmanager.DownloadFile(p.GetGraphUrl(), p.GetGraphPath()); err != nil {
-				return err
-			}
-		}
+func (p *SemanticSegmentationPredictor) loadModelFiles(ctx context.Context) error {
+	masksLayer, err := masksOutputIndex(p.Base)
+	if err != nil {
+		return err
 	}
-
-	span.LogFields(
-		olog.String("event", "download features"),
-	)
-	checksum := p.GetFeaturesChecksum()
-	if checksum != "" {
-		if _, _, err := downloadmanager.DownloadFile(p.GetFeaturesUrl(), p.GetFeaturesPath(), downloadmanager.MD5Sum(checksum)); err != nil {
-			return err
-		}
-	} else {
-		if _, _, err := downloadmanager.DownloadFile(p.GetFeaturesUrl(), p.GetFeaturesPath()); err != nil {
-			return err
-		}
+	p.masksLayer = masksLayer
+	postprocess, err := p.postprocessOptions()
+	if err != nil {
+		return err
+	}
+	p.postprocess = postprocess
+	if _, err := p.tileOptions(); err != nil {
+		return err
+	}
+	if _, err := p.augmentationOptions(); err != nil {
+		return err
 	}
 
 	return nil
 }
 
-func (p *SemanticSegmentationPredictor) loadPredictor(ctx context.Context) error {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "load_predictor")
-	defer span.Finish()
-
-	span.LogFields(
-		olog.String("event", "read features"),
-	)
-
-	var labels []string
-	f, err := os.Open(p.GetFeaturesPath())
+// postprocessOptions resolves the resizing and the exports of the masks.
+func (p *SemanticSegmentationPredictor) postprocessOptions(opts ...options.Option) (segmentationPostprocessOptions, error) {
+	export, err := segmentationExportOptionsFromManifest(p.Base)
 	if err != nil {
-		return errors.Wrapf(err, "cannot read %s", p.GetFeaturesPath())
+		return segmentationPostprocessOptions{}, err
 	}
-	defer f.Close()
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		line := scanner.Text()
-		labels = append(labels, line)
+	res := segmentationPostprocessOptions{
+		resizeMasks: getOutputBoolParameter(p.Base, "resize_masks", false),
+		export:      export,
 	}
-	p.labels = labels
+	res = res.withOverrides(p.Options.Context()).withOverrides(options.New(opts...).Context())
+	if err := res.export.validate(); err != nil {
+		return segmentationPostprocessOptions{}, err
+	}
+	return res, nil
+}
 
-	span.LogFields(
-		olog.String("event", "creating predictor"),
-	)
+// tileOptions resolves the tiling of the images.
+func (p *SemanticSegmentationPredictor) tileOptions(opts ...options.Option) (tileOptions, error) {
+	return imageTileOptions(p.Base, p.Options.Context(), options.New(opts...).Context())
+}
 
-	opts, err := p.GetPredictionOptions()
-	if err != nil {
-		return err
+// augmentationOptions resolves the test-time augmentation of the images.
+func (p *SemanticSegmentationPredictor) augmentationOptions(opts ...options.Option) (augmentationOptions, error) {
+	return imageAugmentationOptions(p.Base, p.Options.Context(), options.New(opts...).Context())
+}
+
+// segmentationOutputNames name the outputs of the torchvision segmentation models by their position.
+// These models return a dict, which the PyTorch binding does not read: they have to be scripted from a
+// wrapper returning the tuple (out, aux) of its values.
+var segmentationOutputNames = []string{"out", "aux"}
+
+// masksOutputIndex resolves the masks_layer parameter of the manifest, the output holding the scores
+// or the masks. It is either the index of the output or its name among the output_names parameter,
+// which defaults to the (out, aux) tuple of the torchvision models. The first output is used when it is not set.
+func masksOutputIndex(base common.Base) (int, error) {
+	layer := strings.TrimSpace(getOutputParameter(base, "masks_layer"))
+	if layer == "" {
+		return 0, nil
+	}
+	if idx, err := strconv.Atoi(layer); err == nil {
+		if idx < 0 {
+			return 0, errors.Errorf("model %v selects the negative masks output %v", base.Model.GetName(), idx)
+		}
+		return idx, nil
 	}
 
-	pred, err := gopytorch.New(
-		ctx,
-		options.WithOptions(opts),
-		options.Graph([]byte(p.GetGraphPath())),
-	)
+	names := segmentationOutputNames
+	var manifestNames []string
+	ok, err := getOutputListParameter(base, "output_names", &manifestNames)
 	if err != nil {
-		return err
+		return 0, err
 	}
-
-	p.predictor = pred
-
-	return nil
+	if ok {
+		names = manifestNames
+	}
+	for ii, name := range names {
+		if name == layer {
+			return ii, nil
+		}
+	}
+	return 0, errors.Errorf("model %v selects the masks output %v, which is not one of the outputs %v", base.Model.GetName(), layer, names)
 }
 
 // GetInputLayerName ...
@@ -240,97 +211,189 @@ func (p *SemanticSegmentationPredictor) GetOutputLayerName(reader io.Reader, lay
 	return name, nil
 }
 
-// Predict ...
-func (p *SemanticSegmentationPredictor) Predict(ctx context.Context, data interface{}, opts ...options.Option) error {
-
-	if data == nil {
-		return errors.New("input data nil")
+// prepare converts the data into the input tensors of the model.
+// The decoder remembers how the images were preprocessed, to map the masks back onto them.
+// With the test-time augmentation, the inputs hold the variants of every image, whose outputs the decoder merges.
+func (p *SemanticSegmentationPredictor) prepare(ctx context.Context, data interface{}, opts ...options.Option) ([]gotensor.Tensor, outputDecoder, error) {
+	postprocess, err := p.postprocessOptions(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	augmentation, err := p.augmentationOptions(opts...)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	gotensors, ok := data.([]gotensor.Tensor)
-	if !ok {
-		return errors.New("input data is not slice of dense tensors")
+	if !augmentation.enabled {
+		gotensors, sources, err := preprocessImageInputs(ctx, p.Model, data)
+		if err != nil {
+			return nil, nil, err
+		}
+		return gotensors, func(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+			return p.decodeFeatures(ctx, outputs, sources, nil, postprocess)
+		}, nil
 	}
 
-	return p.predictor.Predict(ctx, gotensors)
+	gotensors, sources, err := preprocessAugmentedInputs(ctx, p.Model, data, augmentation.variants)
+	if err != nil {
+		return nil, nil, err
+	}
+	augmented := &augmentedImages{geometries: imageGeometries(sources), variants: augmentation.variants}
+	return gotensors, func(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+		return p.decodeFeatures(ctx, outputs, sources, augmented, postprocess)
+	}, nil
 }
 
-// ReadPredictedFeatures ...
-func (p *SemanticSegmentationPredictor) ReadPredictedFeatures(ctx context.Context) ([]dlframework.Features, error) {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features")
-	defer span.Finish()
+// decodeOutputs decodes the outputs at the resolution of the model, the source images being unknown.
+func (p *SemanticSegmentationPredictor) decodeOutputs(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	return p.decodeFeatures(ctx, outputs, nil, nil, p.postprocess)
+}
 
-	outputs, err := p.predictor.ReadPredictionOutput(ctx)
+// decodeFeatures builds the masks from the outputs, and renders their exports. The masks are mapped back onto
+// the source images when they are resized and the images are known, the inputs given as tensors having none.
+// The outputs of the variants of the augmented images are merged first.
+func (p *SemanticSegmentationPredictor) decodeFeatures(ctx context.Context, outputs []gotensor.Tensor, sources []sourceImage,
+	augmented *augmentedImages, postprocess segmentationPostprocessOptions) ([]dlframework.Features, error) {
+	var geometries []imageGeometry
+	if postprocess.resizeMasks {
+		geometries = imageGeometries(sources)
+	}
+	masks, err := p.decodeClassMasks(outputs, geometries, augmented)
 	if err != nil {
-		return nil, errors.New("cannot get prediction output")
+		return nil, err
+	}
+	if sources != nil && len(postprocess.export.exports) != 0 {
+		if err := checkGeometries(imageGeometries(sources), len(masks)); err != nil {
+			return nil, err
+		}
 	}
 
-	labels, err := p.GetLabels()
-	if err != nil {
-		return nil, errors.New("cannot get the labels")
-	}
-
-	outputarray := outputs[0].Data().([]float32)
-	outputbatch := outputs[0].Shape()[0]
-	outputfeature := outputs[0].Shape()[1]
-	outputheight := outputs[0].Shape()[2]
-	outputwidth := outputs[0].Shape()[3]
-
-	// convert the output in order to make it compatible with CreateSemanticSegmentFeatures function call
-	masks := make([][][]int64, outputbatch)
-	for b := 0; b < outputbatch; b++ {
-		masks[b] = make([][]int64, outputheight)
-		for h := 0; h < outputheight; h++ {
-			masks[b][h] = make([]int64, outputwidth)
-			for w := 0; w < outputwidth; w++ {
-				idx := 0
-				cur := outputarray[b*outputfeature*outputheight*outputwidth+idx*outputheight*outputwidth+h*outputwidth+w]
-				for f := 1; f < outputfeature; f++ {
-					if outputarray[b*outputfeature*outputheight*outputwidth+f*outputheight*outputwidth+h*outputwidth+w] > cur {
-						idx = f
-						cur = outputarray[b*outputfeature*outputheight*outputwidth+idx*outputheight*outputwidth+h*outputwidth+w]
-					}
-				}
-				masks[b][h][w] = int64(idx)
+	features := make([]dlframework.Features, len(masks))
+	for b, mask := range masks {
+		features[b] = dlframework.Features{semanticSegmentFeature(mask.data, mask.height, mask.width)}
+		if len(postprocess.export.exports) == 0 {
+			continue
+		}
+		var src *sourceImage
+		if sources != nil {
+			src = &sourceImage{image: sources[b].image, geometry: sources[b].geometry}
+			if geometries != nil {
+				// the mask already is at the resolution of the source image
+				src.geometry = identityGeometry(mask.width, mask.height)
 			}
 		}
+		exports, err := semanticSegmentExports(mask, src, p.labels, postprocess.export)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot export the mask of image %v", b)
+		}
+		features[b] = append(features[b], exports...)
 	}
-
-	return p.CreateSemanticSegmentFeatures(ctx, masks, labels)
+	return features, nil
 }
 
-// ReadPredictedFeaturesAsMap ...
-func (p *SemanticSegmentationPredictor) ReadPredictedFeaturesAsMap(ctx context.Context) (map[string]interface{}, error) {
-	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features_as_map")
-	defer span.Finish()
+// decodeClassMasks returns the class mask of every image, resampled onto the source images when the geometries are given.
+// The scores of the variants of the augmented images are averaged, and their masks vote.
+func (p *SemanticSegmentationPredictor) decodeClassMasks(outputs []gotensor.Tensor, geometries []imageGeometry, augmented *augmentedImages) ([]classMask, error) {
+	if err := checkOutputCount(p.Model.GetName(), outputs, p.masksLayer+1); err != nil {
+		return nil, err
+	}
+	if isIntegerDtype(outputs[p.masksLayer].Dtype()) {
+		return p.decodeMasks(outputs, geometries, augmented)
+	}
 
-	outputs, err := p.predictor.ReadPredictionOutput(ctx)
-	if err != nil {
+	// the scores are NCHW, with one plane per class
+	if err := checkOutput(p.Base, outputs, p.masksLayer, anyDim, anyDim, anyDim); err != nil {
 		return nil, err
 	}
 
-	res := make(map[string]interface{})
-	res["outputs"] = outputs
-	res["labels"] = p.labels
+	scores, err := newOutputView(p.Base, p.masksLayer, outputs[p.masksLayer], 4)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the score output")
+	}
+	if augmented != nil {
+		if scores, err = mergeVariantScores(scores, augmented.geometries, augmented.variants); err != nil {
+			return nil, err
+		}
+	}
 
+	if geometries == nil {
+		masks := argmaxMasks(scores)
+		res := make([]classMask, len(masks))
+		for b, mask := range masks {
+			res[b] = classMask{data: mask, height: scores.shape[2], width: scores.shape[3]}
+		}
+		return res, nil
+	}
+	if err := checkGeometries(geometries, scores.shape[0]); err != nil {
+		return nil, err
+	}
+	res := make([]classMask, len(geometries))
+	for b, g := range geometries {
+		res[b] = classMask{data: resizedArgmaxMask(scores, b, g), height: g.srcHeight, width: g.srcWidth}
+	}
 	return res, nil
 }
 
+// decodeMasks reads the class masks of the models running the argmax themselves, of shape NHW or N1HW.
+// The masks are resampled onto the source images when the geometries are given.
+func (p *SemanticSegmentationPredictor) decodeMasks(outputs []gotensor.Tensor, geometries []imageGeometry, augmented *augmentedImages) ([]classMask, error) {
+	output := outputs[p.masksLayer]
+	defaults := []int{anyDim, anyDim}
+	if len(output.Shape()) == 4 {
+		defaults = []int{1, anyDim, anyDim}
+	}
+	if err := checkOutput(p.Base, outputs, p.masksLayer, defaults...); err != nil {
+		return nil, err
+	}
+	if err := checkOutputElementType(p.Base, p.masksLayer, output); err != nil {
+		return nil, err
+	}
+
+	// the masks are split along the batch in the row-major order of their backing array
+	if view, ok := output.(gotensor.View); ok && view.IsMaterializable() {
+		output = view.Materialize()
+	}
+	data, err := tensorToInt32s(output)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the masks output")
+	}
+
+	shape := output.Shape()
+	batch, height, width := shape[0], shape[len(shape)-2], shape[len(shape)-1]
+	if augmented != nil {
+		if data, err = mergeVariantMasks(data, height, width, augmented.geometries, augmented.variants); err != nil {
+			return nil, err
+		}
+		batch = len(augmented.geometries)
+	}
+	if geometries != nil {
+		if err := checkGeometries(geometries, batch); err != nil {
+			return nil, err
+		}
+	}
+	masks := make([]classMask, batch)
+	for b := range masks {
+		mask := classMask{data: data[b*height*width : (b+1)*height*width], height: height, width: width}
+		if geometries != nil {
+			mask = sourceMask(mask, geometries[b])
+		}
+		masks[b] = mask
+	}
+	return masks, nil
+}
+
 // Reset ...
 func (p *SemanticSegmentationPredictor) Reset(ctx context.Context) error {
-	return nil
+	return p.torchPredictor.Reset(ctx)
 }
 
 // Close ...
 func (p *SemanticSegmentationPredictor) Close() error {
-	if p.predictor != nil {
-		p.predictor.Close()
-	}
-	return nil
+	return p.torchPredictor.Close()
 }
 
 // Modality ...
-func (p SemanticSegmentationPredictor) Modality() (dlframework.Modality, error) {
+func (p *SemanticSegmentationPredictor) Modality() (dlframework.Modality, error) {
 	return dlframework.ImageSemanticSegmentationModality, nil
 }
 
@@ -2,13 +2,14 @@ package predictor
 
 import (
 	"context"
-	"os"
+	"image"
+	"io/ioutil"
 	"path/filepath"
 	"testing"
 
+	"github.com/c3sr/dlframework"
 	"github.com/c3sr/dlframework/framework/options"
-	raiimage "github.com/c3sr/image"
-	"github.com/c3sr/image/types"
+	common "github.com/c3sr/dlframework/framework/predictor"
 	nvidiasmi "github.com/c3sr/nvidia-smi"
 	py "github.com/c3sr/pytorch"
 	"github.com/stretchr/testify/assert"
@@ -37,53 +38,30 @@ func TestSemanticSegmentation(t *testing.T) {
 	assert.NotEmpty(t, predictor)
 	defer predictor.Close()
 
-	imgDir, _ := filepath.Abs("./_fixtures")
-	imgPath := filepath.Join(imgDir, "lane_control.jpg")
-	r, err := os.Open(imgPath)
-	if err != nil {
-		panic(err)
-	}
-
-	preprocessOpts, err := predictor.GetPreprocessOptions()
+	preprocessor, err := NewImagePreprocessor(*model)
 	assert.NoError(t, err)
-	mode := preprocessOpts.ColorMode
-
-	var imgOpts []raiimage.Option
-	if mode == types.RGBMode {
-		imgOpts = append(imgOpts, raiimage.Mode(types.RGBMode))
-	} else {
-		imgOpts = append(imgOpts, raiimage.Mode(types.BGRMode))
-	}
-
-	img, err := raiimage.Read(r, imgOpts...)
 	if err != nil {
-		panic(err)
+		return
 	}
 
-	height := img.Bounds().Dy()
-	width := img.Bounds().Dx()
-	channels := 3
-
-	input := make([]gotensor.Tensor, batchSize)
-	imgFloats, err := normalizeImageCHW(img, preprocessOpts.MeanImage, preprocessOpts.Scale)
+	imgDir, _ := filepath.Abs("./_fixtures")
+	imgPath := filepath.Join(imgDir, "lane_control.jpg")
+	imgBytes, err := ioutil.ReadFile(imgPath)
 	if err != nil {
 		panic(err)
 	}
 
-	for ii := 0; ii < batchSize; ii++ {
-		input[ii] = gotensor.New(
-			gotensor.WithShape(channels, height, width),
-			gotensor.WithBacking(imgFloats),
-		)
+	batch := make([][]byte, batchSize)
+	for ii := range batch {
+		batch[ii] = imgBytes
 	}
-
-	joined, err := gotensor.Concat(0, input[0], input[1:]...)
+	input, err := preprocessor.PreprocessBytes(ctx, batch)
+	assert.NoError(t, err)
 	if err != nil {
 		return
 	}
-	joined.Reshape(append([]int{len(input)}, input[0].Shape()...)...)
 
-	err = predictor.Predict(ctx, []gotensor.Tensor{joined})
+	err = predictor.Predict(ctx, []gotensor.Tensor{input})
 
 	assert.NoError(t, err)
 	if err != nil {
@@ -101,3 +79,205 @@ func TestSemanticSegmentation(t *testing.T) {
 
 	assert.Equal(t, int32(7), intMask[247039])
 }
+
+func segmentationBase(params map[string]string) common.Base {
+	parameters := map[string]*dlframework.ModelManifest_Type_Parameter{}
+	for key, value := range params {
+		parameters[key] = &dlframework.ModelManifest_Type_Parameter{Value: value}
+	}
+	return common.Base{
+		Model: dlframework.ModelManifest{
+			Name:   "fake",
+			Output: &dlframework.ModelManifest_Type{Type: "semanticsegment", Parameters: parameters},
+		},
+		Options: options.New(),
+	}
+}
+
+func TestMasksOutputIndex(t *testing.T) {
+	for _, tc := range []struct {
+		params   map[string]string
+		expected int
+	}{
+		{params: nil, expected: 0},
+		{params: map[string]string{"masks_layer": "1"}, expected: 1},
+		{params: map[string]string{"masks_layer": "out"}, expected: 0},
+		{params: map[string]string{"masks_layer": "aux"}, expected: 1},
+		{params: map[string]string{"masks_layer": "masks", "output_names": "[logits, masks]"}, expected: 1},
+	} {
+		idx, err := masksOutputIndex(segmentationBase(tc.params))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, idx, "%v", tc.params)
+	}
+
+	for _, params := range []map[string]string{
+		{"masks_layer": "SemanticPredictions"},
+		{"masks_layer": "-1"},
+		{"masks_layer": "aux", "output_names": "[out]"},
+	} {
+		_, err := masksOutputIndex(segmentationBase(params))
+		assert.Error(t, err, "%v", params)
+	}
+}
+
+func TestSemanticSegmentationSelectsOutput(t *testing.T) {
+	ctx := context.Background()
+	// the out and aux scores of a 2x1 image with 2 classes disagree on every pixel
+	out := gotensor.New(gotensor.WithShape(1, 2, 1, 2), gotensor.WithBacking([]float32{1, 0, 0, 1}))
+	aux := gotensor.New(gotensor.WithShape(1, 2, 1, 2), gotensor.WithBacking([]float32{0, 1, 1, 0}))
+
+	p := &SemanticSegmentationPredictor{}
+	p.Base = segmentationBase(map[string]string{"masks_layer": "aux"})
+	assert.NoError(t, p.loadModelFiles(ctx))
+	features, err := p.decodeOutputs(ctx, []gotensor.Tensor{out, aux})
+	assert.NoError(t, err)
+	if assert.Len(t, features, 1) {
+		assert.Equal(t, []int32{1, 0}, features[0][0].GetSemanticSegment().GetIntMask())
+	}
+
+	_, err = p.decodeOutputs(ctx, []gotensor.Tensor{out})
+	assert.Equal(t, &OutputCountError{Model: "fake", Expected: 2, Actual: 1}, err)
+}
+
+func TestSemanticSegmentationIntegerMasks(t *testing.T) {
+	ctx := context.Background()
+	p := &SemanticSegmentationPredictor{}
+	p.Base = segmentationBase(map[string]string{"element_type": "int64"})
+	assert.NoError(t, p.loadModelFiles(ctx))
+
+	masks := []int64{
+		0, 3, 7,
+		2, 2, 1,
+	}
+	for _, shape := range [][]int{{2, 1, 3}, {2, 1, 1, 3}} {
+		output := gotensor.New(gotensor.WithShape(shape...), gotensor.WithBacking(masks))
+		features, err := p.decodeOutputs(ctx, []gotensor.Tensor{output})
+		assert.NoError(t, err)
+		if assert.Len(t, features, 2) {
+			sseg := features[1][0].GetSemanticSegment()
+			assert.Equal(t, []int32{2, 2, 1}, sseg.GetIntMask())
+			assert.Equal(t, int32(1), sseg.GetHeight())
+			assert.Equal(t, int32(3), sseg.GetWidth())
+		}
+	}
+
+	_, err := p.decodeOutputs(ctx, []gotensor.Tensor{gotensor.New(gotensor.WithShape(1, 2, 1, 3), gotensor.WithBacking(masks))})
+	assert.IsType(t, &OutputShapeError{}, err)
+	_, err = p.decodeOutputs(ctx, []gotensor.Tensor{gotensor.New(gotensor.WithShape(2, 1, 3), gotensor.WithBacking([]int32{0, 3, 7, 2, 2, 1}))})
+	assert.Error(t, err)
+}
+
+func TestSemanticSegmentationResizeMasks(t *testing.T) {
+	ctx := context.Background()
+	p := &SemanticSegmentationPredictor{}
+	p.Base = segmentationBase(map[string]string{"resize_masks": "true"})
+	p.Model.Inputs = []*dlframework.ModelManifest_Type{{
+		Type: "image",
+		Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+			"dimensions":  {Value: "[3, 4, 4]"},
+			"layout":      {Value: "CHW"},
+			"resize_mode": {Value: "pad"},
+			"mean":        {Value: "[0, 0, 0]"},
+			"scale":       {Value: "255"},
+		},
+	}}
+	assert.NoError(t, p.loadModelFiles(ctx))
+
+	// the 4x2 image is padded by a row above and below, the score of the class 1 grows with the row
+	scores := make([]float32, 2*4*4)
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			scores[y*4+x] = 1.5
+			scores[16+y*4+x] = float32(y)
+		}
+	}
+	output := gotensor.New(gotensor.WithShape(1, 2, 4, 4), gotensor.WithBacking(scores))
+	imgs := []image.Image{image.NewRGBA(image.Rect(0, 0, 4, 2))}
+
+	inputs, decoder, err := p.prepare(ctx, imgs)
+	assert.NoError(t, err)
+	assert.Equal(t, gotensor.Shape{1, 3, 4, 4}, inputs[0].Shape())
+	features, err := decoder(ctx, []gotensor.Tensor{output})
+	assert.NoError(t, err)
+	if assert.Len(t, features, 1) {
+		sseg := features[0][0].GetSemanticSegment()
+		assert.Equal(t, []int32{2, 4}, []int32{sseg.GetHeight(), sseg.GetWidth()})
+		assert.Equal(t, []int32{0, 0, 0, 0, 1, 1, 1, 1}, sseg.GetIntMask())
+	}
+
+	// the per-request option keeps the resolution of the output
+	_, decoder, err = p.prepare(ctx, imgs, ResizeMasks(false))
+	assert.NoError(t, err)
+	features, err = decoder(ctx, []gotensor.Tensor{output})
+	assert.NoError(t, err)
+	if assert.Len(t, features, 1) {
+		sseg := features[0][0].GetSemanticSegment()
+		assert.Equal(t, []int32{4, 4}, []int32{sseg.GetHeight(), sseg.GetWidth()})
+	}
+
+	// the integer masks take the nearest class
+	p.Base.Model.Output.Parameters["element_type"] = &dlframework.ModelManifest_Type_Parameter{Value: "int64"}
+	masks := gotensor.New(gotensor.WithShape(1, 4, 4), gotensor.WithBacking([]int64{
+		9, 9, 9, 9,
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 9, 9, 9,
+	}))
+	_, decoder, err = p.prepare(ctx, imgs)
+	assert.NoError(t, err)
+	features, err = decoder(ctx, []gotensor.Tensor{masks})
+	assert.NoError(t, err)
+	if assert.Len(t, features, 1) {
+		assert.Equal(t, []int32{1, 2, 3, 4, 5, 6, 7, 8}, features[0][0].GetSemanticSegment().GetIntMask())
+	}
+
+	// the geometries must describe the whole batch
+	_, err = decoder(ctx, []gotensor.Tensor{gotensor.New(gotensor.WithShape(2, 4, 4), gotensor.Of(gotensor.Int64))})
+	assert.Error(t, err)
+
+	// without the source images, the masks keep the resolution of the output
+	features, err = p.decodeOutputs(ctx, []gotensor.Tensor{masks})
+	assert.NoError(t, err)
+	assert.Len(t, features[0][0].GetSemanticSegment().GetIntMask(), 16)
+}
+
+func TestSemanticSegmentationExports(t *testing.T) {
+	ctx := context.Background()
+	p := &SemanticSegmentationPredictor{}
+	p.Base = segmentationBase(map[string]string{"element_type": "int64", "exports": "[mask_png]"})
+	assert.NoError(t, p.loadModelFiles(ctx))
+	masks := gotensor.New(gotensor.WithShape(1, 1, 2), gotensor.WithBacking([]int64{0, 1}))
+
+	features, err := p.decodeOutputs(ctx, []gotensor.Tensor{masks})
+	assert.NoError(t, err)
+	if assert.Len(t, features, 1) && assert.Len(t, features[0], 2) {
+		assert.Equal(t, "mask_png", features[0][1].GetMetadata()["export"])
+		assert.NotEmpty(t, features[0][1].GetImage().GetData())
+	}
+
+	// the per-request exports replace the ones of the manifest
+	_, decoder, err := p.prepare(ctx, []gotensor.Tensor{masks}, SegmentationExports(ExportRLE, ExportPolygons))
+	assert.NoError(t, err)
+	features, err = decoder(ctx, []gotensor.Tensor{masks})
+	assert.NoError(t, err)
+	if assert.Len(t, features[0], 3) {
+		assert.Equal(t, "rle", features[0][1].GetMetadata()["export"])
+		assert.Equal(t, "polygons", features[0][2].GetMetadata()["export"])
+	}
+
+	_, decoder, err = p.prepare(ctx, []gotensor.Tensor{masks}, SegmentationExports())
+	assert.NoError(t, err)
+	features, err = decoder(ctx, []gotensor.Tensor{masks})
+	assert.NoError(t, err)
+	assert.Len(t, features[0], 1)
+
+	// the overlay needs the images, and the exports must be known
+	_, decoder, err = p.prepare(ctx, []gotensor.Tensor{masks}, SegmentationExports(ExportOverlayPNG))
+	assert.NoError(t, err)
+	_, err = decoder(ctx, []gotensor.Tensor{masks})
+	assert.Error(t, err)
+	_, _, err = p.prepare(ctx, []gotensor.Tensor{masks}, SegmentationExports("jpeg"))
+	assert.Error(t, err)
+	_, _, err = p.prepare(ctx, []gotensor.Tensor{masks}, OverlayAlpha(-1))
+	assert.Error(t, err)
+}
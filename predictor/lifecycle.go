@@ -0,0 +1,83 @@
+package predictor
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/c3sr/dlframework/framework/options"
+	gopytorch "github.com/c3sr/go-pytorch"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// PredictorState is the lifecycle state of a predictor.
+// A predictor is unloaded until its Load succeeds, loaded until it is closed, and closed for good afterwards.
+type PredictorState int32
+
+const (
+	// PredictorUnloaded is the state of a predictor that has not been loaded.
+	PredictorUnloaded PredictorState = iota
+	// PredictorLoaded is the state of a predictor holding a TorchScript module.
+	PredictorLoaded
+	// PredictorClosed is the state of a predictor whose TorchScript module was released.
+	PredictorClosed
+)
+
+// String ...
+func (s PredictorState) String() string {
+	switch s {
+	case PredictorUnloaded:
+		return "unloaded"
+	case PredictorLoaded:
+		return "loaded"
+	case PredictorClosed:
+		return "closed"
+	}
+	return fmt.Sprintf("PredictorState(%d)", int32(s))
+}
+
+// PredictorStateError is returned by the operations of a predictor that is not loaded.
+type PredictorStateError struct {
+	// Op is the operation that was refused, such as predict or read_predicted_features.
+	Op string
+	// State is the state of the predictor when the operation was called.
+	State PredictorState
+}
+
+// Error ...
+func (e *PredictorStateError) Error() string {
+	return fmt.Sprintf("cannot %v, the predictor is %v", e.Op, e.State)
+}
+
+// IsPredictorClosed reports whether err was returned by an operation on a closed predictor.
+func IsPredictorClosed(err error) bool {
+	var stateErr *PredictorStateError
+	return errors.As(err, &stateErr) && stateErr.State == PredictorClosed
+}
+
+// torchModule is the loaded TorchScript module run by a torchPredictor, a *gopytorch.Predictor.
+type torchModule interface {
+	Predict(ctx context.Context, inputs []gotensor.Tensor) error
+	ReadPredictionOutput(ctx context.Context) ([]gotensor.Tensor, error)
+	Close()
+}
+
+// newTorchModule loads the TorchScript module given by the graph option.
+// It is replaced in the tests, to run the predictors without libtorch.
+var newTorchModule = func(ctx context.Context, opts ...options.Option) (torchModule, error) {
+	pred, err := gopytorch.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return pred, nil
+}
+
+// openTorchModules counts the TorchScript modules loaded and not yet closed by the predictors of this package.
+var openTorchModules int64
+
+// OpenTorchModules returns the number of TorchScript modules loaded by the predictors of this package
+// and not yet released by their Close.
+func OpenTorchModules() int64 {
+	return atomic.LoadInt64(&openTorchModules)
+}
@@ -0,0 +1,139 @@
+package predictor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/options"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+// fakeTorchModule stands in for a libtorch module, counting the modules that are not closed.
+// It returns its inputs as outputs, and panics when two inferences run on it at once.
+type fakeTorchModule struct {
+	open    *int
+	busy    int32
+	outputs []gotensor.Tensor
+	closed  bool
+}
+
+func (m *fakeTorchModule) Predict(ctx context.Context, inputs []gotensor.Tensor) error {
+	if m.closed {
+		panic("predict on a closed module")
+	}
+	if !atomic.CompareAndSwapInt32(&m.busy, 0, 1) {
+		panic("concurrent inferences on a module")
+	}
+	m.outputs = inputs
+	return nil
+}
+
+func (m *fakeTorchModule) ReadPredictionOutput(ctx context.Context) ([]gotensor.Tensor, error) {
+	if m.closed {
+		panic("read on a closed module")
+	}
+	outputs := m.outputs
+	atomic.StoreInt32(&m.busy, 0)
+	return outputs, nil
+}
+
+func (m *fakeTorchModule) Close() {
+	if m.closed {
+		panic("module closed twice")
+	}
+	m.closed = true
+	*m.open--
+}
+
+// withFakeTorchModules replaces the libtorch modules by fake ones for the duration of the test,
+// and returns the number of fake modules that are not closed.
+func withFakeTorchModules(t *testing.T) *int {
+	open := new(int)
+	newModule := newTorchModule
+	newTorchModule = func(ctx context.Context, opts ...options.Option) (torchModule, error) {
+		*open++
+		return &fakeTorchModule{open: open}, nil
+	}
+	t.Cleanup(func() {
+		newTorchModule = newModule
+	})
+	return open
+}
+
+type fakeTorchPredictor struct {
+	torchPredictor
+}
+
+func (p *fakeTorchPredictor) prepare(ctx context.Context, data interface{}, opts ...options.Option) ([]gotensor.Tensor, outputDecoder, error) {
+	return data.([]gotensor.Tensor), p.decodeOutputs, nil
+}
+
+func (p *fakeTorchPredictor) decodeOutputs(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	return createRawFeatures(outputs)
+}
+
+func openFakeTorchPredictor(t *testing.T, opts ...options.Option) *fakeTorchPredictor {
+	base := common.Base{
+		Model:   dlframework.ModelManifest{Name: "fake"},
+		Options: options.New(opts...),
+	}
+	p := new(fakeTorchPredictor)
+	assert.NoError(t, p.open(context.Background(), base, p))
+	return p
+}
+
+func TestTorchPredictorLifecycle(t *testing.T) {
+	open := withFakeTorchModules(t)
+	ctx := context.Background()
+	input := []gotensor.Tensor{gotensor.New(gotensor.WithShape(1, 2), gotensor.WithBacking([]float32{1, 2}))}
+
+	p := new(fakeTorchPredictor)
+	assert.Equal(t, PredictorUnloaded, p.State())
+	err := p.Predict(ctx, input)
+	assert.Equal(t, &PredictorStateError{Op: "predict", State: PredictorUnloaded}, err)
+	assert.False(t, IsPredictorClosed(err))
+
+	p = openFakeTorchPredictor(t)
+	assert.Equal(t, PredictorLoaded, p.State())
+	assert.Equal(t, 1, *open)
+	assert.NoError(t, p.Predict(ctx, input))
+	features, err := p.ReadPredictedFeatures(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, features, 1)
+	assert.NoError(t, p.Reset(ctx))
+
+	assert.NoError(t, p.Close())
+	assert.Equal(t, PredictorClosed, p.State())
+	assert.Equal(t, 0, *open)
+
+	// closing twice is a no-op, and every other operation reports the closed predictor
+	assert.NoError(t, p.Close())
+	assert.True(t, IsPredictorClosed(p.Predict(ctx, input)))
+	_, err = p.ReadPredictedFeatures(ctx)
+	assert.True(t, IsPredictorClosed(err))
+	_, err = p.ReadPredictedFeaturesAsMap(ctx)
+	assert.True(t, IsPredictorClosed(err))
+	assert.True(t, IsPredictorClosed(p.Reset(ctx)))
+	err = p.open(ctx, common.Base{Options: options.New()}, p)
+	assert.Equal(t, &PredictorStateError{Op: "load", State: PredictorClosed}, err)
+	assert.Equal(t, 0, *open)
+}
+
+func TestTorchPredictorLoadCloseLeak(t *testing.T) {
+	open := withFakeTorchModules(t)
+	before := OpenTorchModules()
+
+	for ii := 0; ii < 100; ii++ {
+		p := openFakeTorchPredictor(t)
+		assert.Equal(t, before+1, OpenTorchModules())
+		assert.NoError(t, p.Close())
+		assert.NoError(t, p.Close())
+	}
+
+	assert.Equal(t, 0, *open)
+	assert.Equal(t, before, OpenTorchModules())
+}
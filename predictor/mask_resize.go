@@ -0,0 +1,112 @@
+package predictor
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// axisSample is the position of a source pixel on an axis of the output, between the output pixels lo and hi.
+type axisSample struct {
+	lo, hi int
+	frac   float32
+}
+
+// resizeAxis maps the centers of the source pixels of an axis onto the output of the model.
+// The source axis was scaled to resized pixels drawn at offset on an input axis of input pixels,
+// of which the output axis of output pixels is a rescaling. The samples outside the output are
+// clamped to its border, such as the pixels cropped off by the preprocessing.
+func resizeAxis(src, resized, offset, input, output int) []axisSample {
+	samples := make([]axisSample, src)
+	for s := range samples {
+		o := ((float64(s)+0.5)*float64(resized)/float64(src)+float64(offset))*float64(output)/float64(input) - 0.5
+		switch {
+		case o <= 0:
+			samples[s] = axisSample{lo: 0, hi: 0}
+		case o >= float64(output-1):
+			samples[s] = axisSample{lo: output - 1, hi: output - 1}
+		default:
+			lo := int(math.Floor(o))
+			samples[s] = axisSample{lo: lo, hi: lo + 1, frac: float32(o - float64(lo))}
+		}
+	}
+	return samples
+}
+
+// nearest returns the output pixel closest to the sample.
+func (s axisSample) nearest() int {
+	if s.frac >= 0.5 {
+		return s.hi
+	}
+	return s.lo
+}
+
+// identityGeometry is the geometry of an image fed to the model as is, of the resolution of its outputs.
+func identityGeometry(width, height int) imageGeometry {
+	return imageGeometry{
+		srcWidth: width, srcHeight: height,
+		width: width, height: height,
+		resizedWidth: width, resizedHeight: height,
+	}
+}
+
+// checkGeometries returns an error when the geometries do not describe every image of the batch.
+func checkGeometries(geometries []imageGeometry, batch int) error {
+	if len(geometries) != batch {
+		return errors.Errorf("the outputs hold %v images, but %v images were preprocessed", batch, len(geometries))
+	}
+	for ii, g := range geometries {
+		if g.srcWidth <= 0 || g.srcHeight <= 0 || g.width <= 0 || g.height <= 0 || g.resizedWidth <= 0 || g.resizedHeight <= 0 {
+			return errors.Errorf("cannot map the outputs back onto the image %v of %vx%v", ii, g.srcWidth, g.srcHeight)
+		}
+	}
+	return nil
+}
+
+// resizedArgmaxMask returns the class of the highest score of each pixel of the source image b,
+// the scores of the NCHW output being upsampled bilinearly onto the source image before the argmax.
+func resizedArgmaxMask(scores float32View, b int, g imageGeometry) []int32 {
+	classes, height, width := scores.shape[1], scores.shape[2], scores.shape[3]
+	sb, sc, sh, sw := scores.strides[0], scores.strides[1], scores.strides[2], scores.strides[3]
+
+	xs := resizeAxis(g.srcWidth, g.resizedWidth, g.offsetX, g.width, width)
+	ys := resizeAxis(g.srcHeight, g.resizedHeight, g.offsetY, g.height, height)
+
+	mask := make([]int32, g.srcHeight*g.srcWidth)
+	best := make([]float32, g.srcWidth)
+	for y, ry := range ys {
+		row := mask[y*g.srcWidth : (y+1)*g.srcWidth]
+		fy := ry.frac
+		for c := 0; c < classes; c++ {
+			top := b*sb + c*sc + ry.lo*sh
+			bottom := b*sb + c*sc + ry.hi*sh
+			for x, rx := range xs {
+				lo, hi := rx.lo*sw, rx.hi*sw
+				t := scores.data[top+lo] + (scores.data[top+hi]-scores.data[top+lo])*rx.frac
+				u := scores.data[bottom+lo] + (scores.data[bottom+hi]-scores.data[bottom+lo])*rx.frac
+				s := t + (u-t)*fy
+				if c == 0 || s > best[x] {
+					best[x] = s
+					row[x] = int32(c)
+				}
+			}
+		}
+	}
+	return mask
+}
+
+// resizeMask resamples the HW class mask of an image onto its source image, taking the nearest class.
+func resizeMask(mask []int32, height, width int, g imageGeometry) []int32 {
+	xs := resizeAxis(g.srcWidth, g.resizedWidth, g.offsetX, g.width, width)
+	ys := resizeAxis(g.srcHeight, g.resizedHeight, g.offsetY, g.height, height)
+
+	res := make([]int32, g.srcHeight*g.srcWidth)
+	for y, ry := range ys {
+		src := mask[ry.nearest()*width : (ry.nearest()+1)*width]
+		row := res[y*g.srcWidth : (y+1)*g.srcWidth]
+		for x, rx := range xs {
+			row[x] = src[rx.nearest()]
+		}
+	}
+	return res
+}
@@ -0,0 +1,80 @@
+package predictor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+func TestResizeAxis(t *testing.T) {
+	// the same resolution maps every pixel onto itself
+	for s, sample := range resizeAxis(4, 4, 0, 4, 4) {
+		assert.Equal(t, s, sample.nearest())
+		assert.Equal(t, float32(0), sample.frac)
+	}
+
+	// upsampling an output of half the resolution interpolates between its pixels
+	assert.Equal(t, []axisSample{
+		{lo: 0, hi: 0},
+		{lo: 0, hi: 1, frac: 0.25},
+		{lo: 0, hi: 1, frac: 0.75},
+		{lo: 1, hi: 1},
+	}, resizeAxis(4, 2, 0, 2, 2))
+
+	// the padding before the image is skipped, at the resolution of the output
+	assert.Equal(t, []axisSample{
+		{lo: 1, hi: 2},
+		{lo: 2, hi: 3},
+	}, resizeAxis(2, 2, 1, 4, 4))
+	assert.Equal(t, []axisSample{
+		{lo: 0, hi: 1, frac: 0.25},
+		{lo: 0, hi: 1, frac: 0.75},
+	}, resizeAxis(2, 2, 1, 4, 2))
+}
+
+func TestResizedArgmaxMask(t *testing.T) {
+	output := rangeTensor(2, 5, 6, 7)
+	view, err := newFloat32View(output, 4)
+	assert.NoError(t, err)
+
+	// the geometry of an image fed at the resolution of the output is the identity
+	g := imageGeometry{srcWidth: 7, srcHeight: 6, width: 7, height: 6, resizedWidth: 7, resizedHeight: 6}
+	masks := argmaxMasks(view)
+	for b := range masks {
+		assert.Equal(t, masks[b], resizedArgmaxMask(view, b, g))
+	}
+
+	// the scores of the 2 classes cross between the pixels of the output
+	scores := gotensor.New(gotensor.WithShape(1, 2, 1, 2), gotensor.WithBacking([]float32{1, 0, 0, 1}))
+	view, err = newFloat32View(scores, 4)
+	assert.NoError(t, err)
+	g = imageGeometry{srcWidth: 4, srcHeight: 3, width: 2, height: 1, resizedWidth: 2, resizedHeight: 1}
+	assert.Equal(t, []int32{
+		0, 0, 1, 1,
+		0, 0, 1, 1,
+		0, 0, 1, 1,
+	}, resizedArgmaxMask(view, 0, g))
+}
+
+func TestResizeMask(t *testing.T) {
+	mask := []int32{
+		1, 2,
+		3, 4,
+	}
+	g := imageGeometry{srcWidth: 4, srcHeight: 4, width: 2, height: 2, resizedWidth: 2, resizedHeight: 2}
+	assert.Equal(t, []int32{
+		1, 1, 2, 2,
+		1, 1, 2, 2,
+		3, 3, 4, 4,
+		3, 3, 4, 4,
+	}, resizeMask(mask, 2, 2, g))
+
+	// a center crop drops the borders of the source image, which take the classes of the border of the output
+	g = imageGeometry{srcWidth: 4, srcHeight: 1, width: 2, height: 1, resizedWidth: 4, resizedHeight: 1, offsetX: -1}
+	assert.Equal(t, []int32{1, 1, 2, 2}, resizeMask([]int32{1, 2}, 1, 2, g))
+
+	assert.Error(t, checkGeometries([]imageGeometry{g}, 2))
+	assert.Error(t, checkGeometries([]imageGeometry{{}}, 1))
+	assert.NoError(t, checkGeometries([]imageGeometry{g}, 1))
+}
@@ -0,0 +1,348 @@
+package predictor
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/c3sr/dlframework"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// the types of the inputs declared in the manifests
+const (
+	inputTypeImage    = "image"
+	inputTypeTensor   = "tensor"
+	inputTypeTokenIDs = "token_ids"
+	inputTypeScalar   = "scalar"
+	// inputTypeGeneral is the type of the inputs of the models loaded by the GeneralPredictor, which are tensor inputs
+	inputTypeGeneral = "general"
+)
+
+// ModelInput is the value of one of the inputs of a model, for every element of the batch.
+// It is built by ImageInput, TensorInput, TokenIDsInput or ScalarInput. The inputs of a request are passed
+// to Predict as a []ModelInput, in the order the manifest declares them, and are fed to the TorchScript
+// module as its positional arguments.
+type ModelInput struct {
+	kind     string
+	images   interface{}
+	tensor   gotensor.Tensor
+	tokenIDs [][]int64
+	scalars  []float64
+}
+
+// ImageInput is an image input, given as []image.Image, encoded images given as [][]byte, or file paths
+// and http(s) URLs given as []string. The images are preprocessed according to the parameters of the input.
+func ImageInput(images interface{}) ModelInput {
+	return ModelInput{kind: inputTypeImage, images: images}
+}
+
+// TensorInput is a tensor or a general input, whose first dimension is the batch.
+func TensorInput(t gotensor.Tensor) ModelInput {
+	return ModelInput{kind: inputTypeTensor, tensor: t}
+}
+
+// TokenIDsInput is an input of the int64 token ids of every element of the batch.
+func TokenIDsInput(ids [][]int64) ModelInput {
+	return ModelInput{kind: inputTypeTokenIDs, tokenIDs: ids}
+}
+
+// ScalarInput is an input of a single value for every element of the batch.
+func ScalarInput(values ...float64) ModelInput {
+	return ModelInput{kind: inputTypeScalar, scalars: values}
+}
+
+// inputSpec is an input of the model, as declared in the manifest.
+type inputSpec struct {
+	kind string
+	// dims are the dimensions of the tensor inputs without the batch dimension, -1 being any size.
+	// They are not checked when empty.
+	dims  []int
+	dtype gotensor.Dtype
+	// maxSeqLength is the length the token ids are padded to with padID, or 0 to keep their length
+	maxSeqLength int
+	padID        int64
+}
+
+// modelInputSpecs reads the inputs declared in the manifest. The image inputs take the parameters of the image
+// preprocessing, the tensor and general inputs their dimensions and element_type, which defaults to float32,
+// the token_ids inputs their max_seq_length and pad_id, and the scalar inputs their element_type.
+// The first input must be an image or a general input, since dlframework picks the predictor of a model from it.
+func modelInputSpecs(model dlframework.ModelManifest) ([]inputSpec, error) {
+	inputs := model.GetInputs()
+	if len(inputs) == 0 {
+		return nil, errors.Errorf("model %v declares no inputs", model.GetName())
+	}
+	if err := checkFirstInputType(model); err != nil {
+		return nil, err
+	}
+	specs := make([]inputSpec, len(inputs))
+	for ii, input := range inputs {
+		spec := inputSpec{kind: strings.ToLower(input.GetType()), dtype: gotensor.Float32}
+		if spec.kind == inputTypeGeneral {
+			spec.kind = inputTypeTensor
+		}
+		params := input.GetParameters()
+		param := func(name string) string {
+			if p, ok := params[name]; ok && p != nil {
+				return strings.TrimSpace(p.GetValue())
+			}
+			return ""
+		}
+
+		switch spec.kind {
+		case inputTypeImage:
+		case inputTypeTensor, inputTypeScalar:
+			if elementType := strings.ToLower(param("element_type")); elementType != "" {
+				dtype, ok := elementTypeDtypes[elementType]
+				if !ok || dtype == gotensor.Bool {
+					return nil, errors.Errorf("input %v of model %v declares the unsupported element_type %v", ii, model.GetName(), elementType)
+				}
+				spec.dtype = dtype
+			}
+			if spec.kind == inputTypeTensor {
+				if _, err := unmarshalListParameter(params, "dimensions", &spec.dims); err != nil {
+					return nil, err
+				}
+			}
+		case inputTypeTokenIDs:
+			spec.dtype = gotensor.Int64
+			var err error
+			if spec.maxSeqLength, err = intParameter(param("max_seq_length"), 0); err != nil {
+				return nil, errors.Wrapf(err, "input %v of model %v", ii, model.GetName())
+			}
+			padID, err := intParameter(param("pad_id"), 0)
+			if err != nil {
+				return nil, errors.Wrapf(err, "input %v of model %v", ii, model.GetName())
+			}
+			spec.padID = int64(padID)
+		default:
+			return nil, errors.Errorf("input %v of model %v has the unsupported type %v, expecting one of %v, %v, %v, %v or %v",
+				ii, model.GetName(), input.GetType(), inputTypeImage, inputTypeGeneral, inputTypeTensor, inputTypeTokenIDs, inputTypeScalar)
+		}
+		specs[ii] = spec
+	}
+	return specs, nil
+}
+
+// checkFirstInputType returns an error when the first input of the manifest is not an image or a general input.
+// The ModelManifest.Modality of dlframework, which picks the predictor of a model, panics on the other types.
+func checkFirstInputType(model dlframework.ModelManifest) error {
+	inputs := model.GetInputs()
+	if len(inputs) == 0 {
+		return errors.Errorf("model %v declares no inputs", model.GetName())
+	}
+	switch strings.ToLower(inputs[0].GetType()) {
+	case inputTypeImage, inputTypeGeneral:
+		return nil
+	}
+	return errors.Errorf("the first input of model %v has the type %v, expecting %v or %v",
+		model.GetName(), inputs[0].GetType(), inputTypeImage, inputTypeGeneral)
+}
+
+// intParameter parses the integer parameter str, or returns defaultValue when it is empty.
+func intParameter(str string, defaultValue int) (int, error) {
+	if str == "" {
+		return defaultValue, nil
+	}
+	val, err := strconv.Atoi(str)
+	if err != nil {
+		return 0, errors.Errorf("unable to get %v as an integer", str)
+	}
+	return val, nil
+}
+
+// checkImageModelInputs returns an error when the manifest of an image model declares unsupported inputs,
+// or no image input.
+func checkImageModelInputs(model dlframework.ModelManifest) error {
+	specs, err := modelInputSpecs(model)
+	if err != nil {
+		return err
+	}
+	for _, spec := range specs {
+		if spec.kind == inputTypeImage {
+			return nil
+		}
+	}
+	return errors.Errorf("model %v declares no image input", model.GetName())
+}
+
+// inputManifest returns the manifest of the model reduced to its input index, as read by the image preprocessing.
+func inputManifest(model dlframework.ModelManifest, index int) dlframework.ModelManifest {
+	model.Inputs = []*dlframework.ModelManifest_Type{model.GetInputs()[index]}
+	return model
+}
+
+// modelInputTensors validates the inputs of a request against the inputs declared in the manifest and converts
+// them into the positional input tensors of the model. All the inputs must hold the same batch of elements.
+// It also returns the source images of the first image input, which the outputs of the image models refer to.
+func modelInputTensors(ctx context.Context, model dlframework.ModelManifest, inputs []ModelInput) ([]gotensor.Tensor, []sourceImage, error) {
+	specs, err := modelInputSpecs(model)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(inputs) != len(specs) {
+		return nil, nil, errors.Errorf("model %v takes %v inputs, but got %v", model.GetName(), len(specs), len(inputs))
+	}
+
+	gotensors := make([]gotensor.Tensor, len(inputs))
+	var sources []sourceImage
+	for ii, spec := range specs {
+		input := inputs[ii]
+		if input.kind != spec.kind {
+			return nil, nil, errors.Errorf("input %v of model %v is a %v input, but got a %v input", ii, model.GetName(), spec.kind, input.kind)
+		}
+		var t gotensor.Tensor
+		switch spec.kind {
+		case inputTypeImage:
+			var imgs []sourceImage
+			t, imgs, err = imageInputTensor(ctx, inputManifest(model, ii), input.images)
+			if sources == nil {
+				sources = imgs
+			}
+		case inputTypeTensor:
+			t, err = spec.checkTensor(input.tensor)
+		case inputTypeTokenIDs:
+			t, err = spec.tokenIDsTensor(input.tokenIDs)
+		case inputTypeScalar:
+			t, err = spec.scalarTensor(input.scalars)
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid input %v of model %v", ii, model.GetName())
+		}
+		if batch := t.Shape()[0]; ii > 0 && batch != gotensors[0].Shape()[0] {
+			return nil, nil, errors.Errorf("input %v of model %v holds a batch of %v, but the input 0 holds a batch of %v",
+				ii, model.GetName(), batch, gotensors[0].Shape()[0])
+		}
+		gotensors[ii] = t
+	}
+	return gotensors, sources, nil
+}
+
+// imageInputTensor preprocesses the images of a single image input.
+func imageInputTensor(ctx context.Context, model dlframework.ModelManifest, data interface{}) (gotensor.Tensor, []sourceImage, error) {
+	switch data.(type) {
+	case []gotensor.Tensor, []ModelInput:
+		return nil, nil, errors.Errorf("expecting images, but got %T", data)
+	}
+	gotensors, sources, err := preprocessImageInputs(ctx, model, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gotensors[0], sources, nil
+}
+
+// checkTensor returns the tensor once its dtype and its dimensions are checked against the manifest.
+func (spec inputSpec) checkTensor(t gotensor.Tensor) (gotensor.Tensor, error) {
+	if t == nil {
+		return nil, errors.New("the tensor is nil")
+	}
+	shape := t.Shape()
+	if len(shape) == 0 || shape[0] == 0 {
+		return nil, errors.Errorf("expecting a batched tensor, but got the shape %v", shape)
+	}
+	if t.Dtype() != spec.dtype {
+		return nil, errors.Errorf("expecting %v elements, but got %v", spec.dtype, t.Dtype())
+	}
+	if len(spec.dims) == 0 {
+		return t, nil
+	}
+	expected := append([]int{anyDim}, spec.dims...)
+	if len(shape) != len(expected) {
+		return nil, errors.Errorf("expecting the shape %v, but got %v", formatDims(expected), formatDims(shape))
+	}
+	for ii, dim := range expected {
+		if dim != anyDim && shape[ii] != dim {
+			return nil, errors.Errorf("expecting the shape %v, but got %v", formatDims(expected), formatDims(shape))
+		}
+	}
+	return t, nil
+}
+
+// tokenIDsTensor returns the NxL int64 tensor of the token ids, padded to the max_seq_length when it is set.
+// The ids of every element of the batch must otherwise have the same length.
+func (spec inputSpec) tokenIDsTensor(ids [][]int64) (gotensor.Tensor, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("no token ids")
+	}
+	length := spec.maxSeqLength
+	if length == 0 {
+		length = len(ids[0])
+	}
+	if length == 0 {
+		return nil, errors.New("the token ids are empty")
+	}
+	data := make([]int64, 0, len(ids)*length)
+	for b, row := range ids {
+		if len(row) > length || (spec.maxSeqLength == 0 && len(row) != length) {
+			return nil, errors.Errorf("element %v has %v token ids, expecting %v", b, len(row), length)
+		}
+		data = append(data, row...)
+		for ii := len(row); ii < length; ii++ {
+			data = append(data, spec.padID)
+		}
+	}
+	return gotensor.New(gotensor.WithShape(len(ids), length), gotensor.WithBacking(data)), nil
+}
+
+// scalarTensor returns the tensor of shape N of the values, of the element_type of the input.
+// The values of the integer inputs must be integers.
+func (spec inputSpec) scalarTensor(values []float64) (gotensor.Tensor, error) {
+	if len(values) == 0 {
+		return nil, errors.New("no scalar values")
+	}
+	if spec.dtype != gotensor.Float32 && spec.dtype != gotensor.Float64 {
+		for ii, v := range values {
+			if v != math.Trunc(v) {
+				return nil, errors.Errorf("value %v of the %v scalars is not an integer", ii, spec.dtype)
+			}
+		}
+	}
+
+	var backing interface{}
+	switch spec.dtype {
+	case gotensor.Float32:
+		res := make([]float32, len(values))
+		for ii, v := range values {
+			res[ii] = float32(v)
+		}
+		backing = res
+	case gotensor.Float64:
+		backing = append([]float64(nil), values...)
+	case gotensor.Int64:
+		res := make([]int64, len(values))
+		for ii, v := range values {
+			res[ii] = int64(v)
+		}
+		backing = res
+	case gotensor.Int32:
+		res := make([]int32, len(values))
+		for ii, v := range values {
+			res[ii] = int32(v)
+		}
+		backing = res
+	case gotensor.Int16:
+		res := make([]int16, len(values))
+		for ii, v := range values {
+			res[ii] = int16(v)
+		}
+		backing = res
+	case gotensor.Int8:
+		res := make([]int8, len(values))
+		for ii, v := range values {
+			res[ii] = int8(v)
+		}
+		backing = res
+	case gotensor.Uint8:
+		res := make([]uint8, len(values))
+		for ii, v := range values {
+			res[ii] = uint8(v)
+		}
+		backing = res
+	default:
+		return nil, errors.Errorf("the %v scalars are not supported", spec.dtype)
+	}
+	return gotensor.New(gotensor.WithShape(len(values)), gotensor.WithBacking(backing)), nil
+}
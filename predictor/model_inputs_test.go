@@ -0,0 +1,255 @@
+package predictor
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/agent"
+	"github.com/c3sr/dlframework/framework/options"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	py "github.com/c3sr/pytorch"
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+	gotensor "gorgonia.org/tensor"
+)
+
+// multiInputModel returns the manifest of a model taking a 1x2 image, two float features, token ids padded
+// to 4 and an int64 scalar.
+func multiInputModel() dlframework.ModelManifest {
+	param := func(value string) *dlframework.ModelManifest_Type_Parameter {
+		return &dlframework.ModelManifest_Type_Parameter{Value: value}
+	}
+	model := dlframework.ModelManifest{Name: "fake", Inputs: augmentationInputs("[]", 1, 2)}
+	model.Inputs = append(model.Inputs,
+		&dlframework.ModelManifest_Type{Type: "tensor", Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+			"dimensions": param("[2]"),
+		}},
+		&dlframework.ModelManifest_Type{Type: "token_ids", Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+			"max_seq_length": param("4"),
+			"pad_id":         param("9"),
+		}},
+		&dlframework.ModelManifest_Type{Type: "scalar", Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+			"element_type": param("int64"),
+		}},
+	)
+	return model
+}
+
+func TestModelInputSpecs(t *testing.T) {
+	specs, err := modelInputSpecs(multiInputModel())
+	assert.NoError(t, err)
+	assert.Equal(t, []inputSpec{
+		{kind: inputTypeImage, dtype: gotensor.Float32},
+		{kind: inputTypeTensor, dims: []int{2}, dtype: gotensor.Float32},
+		{kind: inputTypeTokenIDs, dtype: gotensor.Int64, maxSeqLength: 4, padID: 9},
+		{kind: inputTypeScalar, dtype: gotensor.Int64},
+	}, specs)
+	assert.NoError(t, checkImageModelInputs(multiInputModel()))
+
+	model := multiInputModel()
+	model.Inputs[3].Parameters["element_type"].Value = "float16"
+	_, err = modelInputSpecs(model)
+	assert.Error(t, err)
+
+	model = multiInputModel()
+	model.Inputs[1].Type = "audio"
+	_, err = modelInputSpecs(model)
+	assert.Error(t, err)
+
+	// the general inputs are tensor inputs
+	model = multiInputModel()
+	model.Inputs[1].Type = "general"
+	specs, err = modelInputSpecs(model)
+	assert.NoError(t, err)
+	assert.Equal(t, inputSpec{kind: inputTypeTensor, dims: []int{2}, dtype: gotensor.Float32}, specs[1])
+
+	// dlframework picks the predictor from the first input, which must be an image or a general input
+	model = multiInputModel()
+	model.Inputs = model.Inputs[1:]
+	_, err = modelInputSpecs(model)
+	assert.Error(t, err)
+	assert.Error(t, checkImageModelInputs(model))
+	assert.Error(t, checkImageModelInputs(dlframework.ModelManifest{Name: "fake"}))
+}
+
+func TestModelInputTensors(t *testing.T) {
+	ctx := context.Background()
+	model := multiInputModel()
+	imgs := []image.Image{
+		pixelImage(color.RGBA{R: 1, A: 255}, color.RGBA{R: 2, A: 255}),
+		pixelImage(color.RGBA{R: 3, A: 255}, color.RGBA{R: 4, A: 255}),
+	}
+	features := gotensor.New(gotensor.WithShape(2, 2), gotensor.WithBacking([]float32{1, 2, 3, 4}))
+	inputs := []ModelInput{
+		ImageInput(imgs),
+		TensorInput(features),
+		TokenIDsInput([][]int64{{101, 7, 102}, {101, 102}}),
+		ScalarInput(3, 4),
+	}
+
+	gotensors, sources, err := modelInputTensors(ctx, model, inputs)
+	assert.NoError(t, err)
+	assert.Len(t, sources, 2)
+	if assert.Len(t, gotensors, 4) {
+		assert.Equal(t, gotensor.Shape{2, 3, 1, 2}, gotensors[0].Shape())
+		assert.Equal(t, features, gotensors[1])
+		assert.Equal(t, gotensor.Shape{2, 4}, gotensors[2].Shape())
+		assert.Equal(t, []int64{101, 7, 102, 9, 101, 102, 9, 9}, gotensors[2].Data())
+		assert.Equal(t, []int64{3, 4}, gotensors[3].Data())
+	}
+
+	// the inputs follow the manifest
+	_, _, err = modelInputTensors(ctx, model, inputs[:3])
+	assert.Error(t, err)
+	_, _, err = modelInputTensors(ctx, model, []ModelInput{inputs[0], inputs[1], inputs[3], inputs[2]})
+	assert.Error(t, err)
+
+	for _, invalid := range []struct {
+		index int
+		input ModelInput
+	}{
+		{index: 0, input: ImageInput([]gotensor.Tensor{features})},
+		{index: 1, input: TensorInput(gotensor.New(gotensor.WithShape(2, 3), gotensor.Of(gotensor.Float32)))},
+		{index: 1, input: TensorInput(gotensor.New(gotensor.WithShape(2, 2), gotensor.Of(gotensor.Float64)))},
+		{index: 1, input: TensorInput(gotensor.New(gotensor.WithShape(3, 2), gotensor.Of(gotensor.Float32)))},
+		{index: 1, input: TensorInput(nil)},
+		{index: 2, input: TokenIDsInput([][]int64{{1, 2, 3, 4, 5}, {1}})},
+		{index: 2, input: TokenIDsInput(nil)},
+		{index: 3, input: ScalarInput(3.5, 4)},
+		{index: 3, input: ScalarInput(3)},
+	} {
+		modified := append([]ModelInput(nil), inputs...)
+		modified[invalid.index] = invalid.input
+		_, _, err := modelInputTensors(ctx, model, modified)
+		assert.Error(t, err, "input %v", invalid.index)
+	}
+
+	// the token ids of the inputs without max_seq_length keep their common length
+	spec := inputSpec{kind: inputTypeTokenIDs, dtype: gotensor.Int64}
+	ids, err := spec.tokenIDsTensor([][]int64{{1, 2}, {3, 4}})
+	assert.NoError(t, err)
+	assert.Equal(t, gotensor.Shape{2, 2}, ids.Shape())
+	_, err = spec.tokenIDsTensor([][]int64{{1, 2}, {3}})
+	assert.Error(t, err)
+}
+
+func TestImageClassificationModelInputs(t *testing.T) {
+	labels := []string{"r0", "r1", "g0", "g1", "b0", "b1"}
+	p, _ := openFakeImageClassificationPredictor(t, labels)
+	p.Model.Inputs = multiInputModel().Inputs
+	ctx := context.Background()
+
+	imgs := []image.Image{pixelImage(color.RGBA{R: 10, G: 20, B: 90, A: 255}, color.RGBA{R: 40, G: 50, B: 60, A: 255})}
+	features, outputs, err := p.InferAndDecode(ctx, []ModelInput{
+		ImageInput(imgs),
+		TensorInput(gotensor.New(gotensor.WithShape(1, 2), gotensor.WithBacking([]float32{1, 2}))),
+		TokenIDsInput([][]int64{{101, 102}}),
+		ScalarInput(1),
+	})
+	assert.NoError(t, err)
+	// the fake model returns its positional inputs
+	assert.Len(t, outputs, 4)
+	if assert.Len(t, features, 1) {
+		assert.Equal(t, "b0", features[0][0].GetClassification().GetLabel())
+	}
+
+	// the models of several inputs do not take the images alone
+	_, _, err = p.InferAndDecode(ctx, imgs)
+	assert.Error(t, err)
+}
+
+// multiInputManifest is the manifest of a general model taking two float features, token ids padded to 4
+// and an int64 scalar, whose graph is the path given to Sprintf.
+const multiInputManifest = `
+name: Fake_Multi_Input
+framework:
+    name: PyTorch
+    version: 1.8.1
+version: 1.0
+inputs:
+    - type: general
+      parameters:
+          element_type: float32
+          dimensions: [2]
+    - type: token_ids
+      parameters:
+          max_seq_length: 4
+          pad_id: 9
+    - type: scalar
+      parameters:
+          element_type: int64
+output:
+    type: general
+model:
+    graph_path: %v
+    is_archive: false
+`
+
+func TestGeneralPredictorModelInputs(t *testing.T) {
+	withFakeTorchModules(t)
+	py.Register()
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "general")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	graph := filepath.Join(dir, "multi_input.pt")
+	assert.NoError(t, ioutil.WriteFile(graph, []byte("graph"), 0644))
+
+	var model dlframework.ModelManifest
+	assert.NoError(t, yaml.Unmarshal([]byte(fmt.Sprintf(multiInputManifest, graph)), &model))
+
+	// the agent picks the predictor of the modality of the model and loads it
+	modality, err := model.Modality()
+	assert.NoError(t, err)
+	predictors, err := agent.GetPredictors(py.FrameworkManifest)
+	assert.NoError(t, err)
+	var loader common.Predictor
+	for _, pred := range predictors {
+		if m, err := pred.Modality(); err == nil && m == modality {
+			loader = pred
+			break
+		}
+	}
+	if !assert.IsType(t, &GeneralPredictor{}, loader) {
+		return
+	}
+	predictor, err := loader.Load(ctx, model, options.BatchSize(2))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer predictor.Close()
+	p := predictor.(*GeneralPredictor)
+	defer os.RemoveAll(p.WorkDir)
+
+	features, outputs, err := p.InferAndDecode(ctx, []ModelInput{
+		TensorInput(gotensor.New(gotensor.WithShape(2, 2), gotensor.WithBacking([]float32{1, 2, 3, 4}))),
+		TokenIDsInput([][]int64{{101, 102}, {101, 7, 102}}),
+		ScalarInput(3, 4),
+	})
+	assert.NoError(t, err)
+	// the fake model returns its positional inputs
+	if assert.Len(t, outputs, 3) {
+		assert.Equal(t, []float32{1, 2, 3, 4}, outputs[0].Data())
+		assert.Equal(t, []int64{101, 102, 9, 9, 101, 7, 102, 9}, outputs[1].Data())
+		assert.Equal(t, []int64{3, 4}, outputs[2].Data())
+	}
+	if assert.Len(t, features, 2) {
+		assert.Len(t, features[0], 3)
+	}
+
+	_, _, err = p.InferAndDecode(ctx, []ModelInput{TokenIDsInput([][]int64{{101, 102}})})
+	assert.Error(t, err)
+
+	// the models whose first input is not an image or a general input are rejected rather than panicking
+	model.Inputs = model.Inputs[1:]
+	_, err = loader.Load(ctx, model)
+	assert.Error(t, err)
+}
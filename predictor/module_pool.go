@@ -0,0 +1,87 @@
+package predictor
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/c3sr/dlframework/framework/options"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// torchModulePool holds the replicas of the TorchScript module of a predictor.
+// A replica runs one inference at a time, from its Predict to its ReadPredictionOutput,
+// so that the outputs of concurrent inferences never mix.
+type torchModulePool struct {
+	modules []torchModule
+	idle    chan torchModule
+}
+
+// newTorchModulePool loads n replicas of the TorchScript module given by the options.
+// The replicas already loaded are closed when one of them fails to load.
+func newTorchModulePool(ctx context.Context, n int, opts ...options.Option) (*torchModulePool, error) {
+	if n < 1 {
+		return nil, errors.Errorf("the number of replicas must be positive, but got %v", n)
+	}
+
+	pool := &torchModulePool{
+		modules: make([]torchModule, 0, n),
+		idle:    make(chan torchModule, n),
+	}
+	for ii := 0; ii < n; ii++ {
+		module, err := newTorchModule(ctx, opts...)
+		if err != nil {
+			pool.close()
+			return nil, errors.Wrapf(err, "cannot load replica %v of the model", ii)
+		}
+		atomic.AddInt64(&openTorchModules, 1)
+		pool.modules = append(pool.modules, module)
+		pool.idle <- module
+	}
+
+	return pool, nil
+}
+
+// size returns the number of replicas.
+func (pool *torchModulePool) size() int {
+	return len(pool.modules)
+}
+
+// acquire waits for an idle replica, or for the context to be done.
+func (pool *torchModulePool) acquire(ctx context.Context) (torchModule, error) {
+	select {
+	case module := <-pool.idle:
+		return module, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// release hands the replica back to the pool.
+func (pool *torchModulePool) release(module torchModule) {
+	pool.idle <- module
+}
+
+// infer runs the inputs on an idle replica and returns the outputs of this inference.
+func (pool *torchModulePool) infer(ctx context.Context, inputs []gotensor.Tensor) ([]gotensor.Tensor, error) {
+	module, err := pool.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer pool.release(module)
+
+	if err := module.Predict(ctx, inputs); err != nil {
+		return nil, err
+	}
+
+	return module.ReadPredictionOutput(ctx)
+}
+
+// close closes all the replicas. No inference may be running.
+func (pool *torchModulePool) close() {
+	for _, module := range pool.modules {
+		module.Close()
+		atomic.AddInt64(&openTorchModules, -1)
+	}
+	pool.modules = nil
+}
@@ -0,0 +1,85 @@
+package predictor
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/c3sr/dlframework/framework/options"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+func TestTorchPredictorConcurrentInfer(t *testing.T) {
+	open := withFakeTorchModules(t)
+	ctx := context.Background()
+
+	p := openFakeTorchPredictor(t, Replicas(3))
+	assert.Equal(t, 3, p.modules.size())
+	assert.Equal(t, 3, *open)
+
+	var wg sync.WaitGroup
+	for ii := 0; ii < 64; ii++ {
+		wg.Add(1)
+		go func(ii int) {
+			defer wg.Done()
+			input := gotensor.New(gotensor.WithShape(1, 1), gotensor.WithBacking([]float32{float32(ii)}))
+			outputs, err := p.Infer(ctx, []gotensor.Tensor{input})
+			assert.NoError(t, err)
+			if assert.Len(t, outputs, 1) {
+				assert.Equal(t, []float32{float32(ii)}, outputs[0].Data())
+			}
+		}(ii)
+	}
+	wg.Wait()
+
+	assert.NoError(t, p.Close())
+	assert.Equal(t, 0, *open)
+	_, err := p.Infer(ctx, nil)
+	assert.True(t, IsPredictorClosed(err))
+}
+
+func TestTorchModulePoolLoadError(t *testing.T) {
+	open := withFakeTorchModules(t)
+	newFakeModule := newTorchModule
+	loaded := 0
+	newTorchModule = func(ctx context.Context, opts ...options.Option) (torchModule, error) {
+		if loaded == 2 {
+			return nil, errors.New("out of memory")
+		}
+		loaded++
+		return newFakeModule(ctx, opts...)
+	}
+	before := OpenTorchModules()
+
+	_, err := newTorchModulePool(context.Background(), 4)
+	assert.Error(t, err)
+	assert.Equal(t, 0, *open)
+	assert.Equal(t, before, OpenTorchModules())
+
+	_, err = newTorchModulePool(context.Background(), 0)
+	assert.Error(t, err)
+}
+
+func TestTorchModulePoolAcquire(t *testing.T) {
+	withFakeTorchModules(t)
+
+	pool, err := newTorchModulePool(context.Background(), 1)
+	assert.NoError(t, err)
+	defer pool.close()
+
+	module, err := pool.acquire(context.Background())
+	assert.NoError(t, err)
+
+	// the only replica is busy, the inference waits until the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = pool.infer(ctx, nil)
+	assert.Equal(t, context.Canceled, err)
+
+	pool.release(module)
+	module, err = pool.acquire(context.Background())
+	assert.NoError(t, err)
+	pool.release(module)
+}
@@ -0,0 +1,181 @@
+package predictor
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// detectionPostprocessOptions holds the score thresholding and
+// non-maximum suppression settings applied to the detection outputs.
+type detectionPostprocessOptions struct {
+	scoreThreshold float32
+	nmsThreshold   float32
+	maxDetections  int
+	softNMSSigma   float32
+	// backgroundIndex is the class dropped before the suppression, or -1
+	backgroundIndex int
+}
+
+// withOverrides returns the options overridden by the values set on the context
+// through ScoreThreshold, NMSThreshold, MaxDetections and SoftNMS.
+func (o detectionPostprocessOptions) withOverrides(ctx context.Context) detectionPostprocessOptions {
+	if ctx == nil {
+		return o
+	}
+	if val, ok := ctx.Value(scoreThresholdKey{}).(float32); ok {
+		o.scoreThreshold = val
+	}
+	if val, ok := ctx.Value(nmsThresholdKey{}).(float32); ok {
+		o.nmsThreshold = val
+	}
+	if val, ok := ctx.Value(maxDetectionsKey{}).(int); ok {
+		o.maxDetections = val
+	}
+	if val, ok := ctx.Value(softNMSSigmaKey{}).(float32); ok {
+		o.softNMSSigma = val
+	}
+	return o
+}
+
+type detection struct {
+	probability float32
+	class       float32
+	box         []float32
+}
+
+// postprocessDetections applies the score threshold, the per-class non-maximum suppression
+// and the top-k cap to the flattened detections of each image in the batch.
+// Without classes, the probabilities are the class-score matrix of the boxes, and each box is a candidate
+// of every class but the background one, as in the Detect stage of the SSD models.
+// boxIndex gives the position of the ymin, xmin, ymax and xmax coordinates within a box.
+func postprocessDetections(probabilities, classes, boxes []float32, batchSize int, boxIndex []int, opts detectionPostprocessOptions) ([][]float32, [][]float32, [][][]float32) {
+	numBoxes := len(boxes) / 4 / batchSize
+	numClasses := 1
+	if classes == nil && len(boxes) > 0 {
+		numClasses = len(probabilities) / (len(boxes) / 4)
+	}
+
+	batchProbabilities := make([][]float32, batchSize)
+	batchClasses := make([][]float32, batchSize)
+	batchBoxes := make([][][]float32, batchSize)
+	for b := 0; b < batchSize; b++ {
+		byClass := map[float32][]detection{}
+		for ii := b * numBoxes; ii < (b+1)*numBoxes; ii++ {
+			for jj := 0; jj < numClasses; jj++ {
+				probability, class := probabilities[ii*numClasses+jj], float32(jj)
+				if classes != nil {
+					class = classes[ii]
+				}
+				if opts.backgroundIndex >= 0 && int(class) == opts.backgroundIndex {
+					continue
+				}
+				if probability < opts.scoreThreshold {
+					continue
+				}
+				byClass[class] = append(byClass[class], detection{
+					probability: probability,
+					class:       class,
+					box:         boxes[ii*4 : (ii+1)*4],
+				})
+			}
+		}
+
+		var kept []detection
+		for _, dets := range byClass {
+			if opts.nmsThreshold > 0 || opts.softNMSSigma > 0 {
+				dets = nms(dets, boxIndex, opts)
+			}
+			kept = append(kept, dets...)
+		}
+		sort.Slice(kept, func(i, j int) bool {
+			if kept[i].probability == kept[j].probability {
+				return kept[i].class < kept[j].class
+			}
+			return kept[i].probability > kept[j].probability
+		})
+		if opts.maxDetections > 0 && len(kept) > opts.maxDetections {
+			kept = kept[:opts.maxDetections]
+		}
+
+		batchProbabilities[b] = make([]float32, len(kept))
+		batchClasses[b] = make([]float32, len(kept))
+		batchBoxes[b] = make([][]float32, len(kept))
+		for ii, det := range kept {
+			batchProbabilities[b][ii] = det.probability
+			batchClasses[b][ii] = det.class
+			batchBoxes[b][ii] = det.box
+		}
+	}
+
+	return batchProbabilities, batchClasses, batchBoxes
+}
+
+// nms suppresses the overlapping detections of a single class.
+// With a positive soft-NMS sigma the scores of the overlapping detections are decayed
+// by exp(-iou^2/sigma) instead, and the detections falling under the score threshold are dropped.
+func nms(dets []detection, boxIndex []int, opts detectionPostprocessOptions) []detection {
+	remaining := make([]detection, len(dets))
+	copy(remaining, dets)
+
+	var kept []detection
+	for len(remaining) > 0 {
+		best := 0
+		for ii := range remaining {
+			if remaining[ii].probability > remaining[best].probability {
+				best = ii
+			}
+		}
+		cur := remaining[best]
+		kept = append(kept, cur)
+		remaining = append(remaining[:best], remaining[best+1:]...)
+
+		next := remaining[:0]
+		for _, det := range remaining {
+			overlap := iou(cur.box, det.box, boxIndex)
+			if opts.softNMSSigma > 0 {
+				det.probability *= float32(math.Exp(-float64(overlap*overlap) / float64(opts.softNMSSigma)))
+				if det.probability < opts.scoreThreshold {
+					continue
+				}
+			} else if overlap > opts.nmsThreshold {
+				continue
+			}
+			next = append(next, det)
+		}
+		remaining = next
+	}
+
+	return kept
+}
+
+func iou(a, b []float32, boxIndex []int) float32 {
+	aYmin, aXmin, aYmax, aXmax := a[boxIndex[0]], a[boxIndex[1]], a[boxIndex[2]], a[boxIndex[3]]
+	bYmin, bXmin, bYmax, bXmax := b[boxIndex[0]], b[boxIndex[1]], b[boxIndex[2]], b[boxIndex[3]]
+
+	interWidth := min32(aXmax, bXmax) - max32(aXmin, bXmin)
+	interHeight := min32(aYmax, bYmax) - max32(aYmin, bYmin)
+	if interWidth <= 0 || interHeight <= 0 {
+		return 0
+	}
+	inter := interWidth * interHeight
+	union := (aXmax-aXmin)*(aYmax-aYmin) + (bXmax-bXmin)*(bYmax-bYmin) - inter
+	if union <= 0 {
+		return 0
+	}
+	return inter / union
+}
+
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
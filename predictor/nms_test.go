@@ -0,0 +1,103 @@
+package predictor
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/c3sr/dlframework/framework/options"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// boxes are laid out as xmin, ymin, xmax, ymax
+	xyxyBoxIndex   = []int{1, 0, 3, 2}
+	syntheticBoxes = []float32{
+		0.0, 0.0, 0.5, 0.5,
+		0.05, 0.05, 0.5, 0.5,
+		0.5, 0.5, 1.0, 1.0,
+		0.0, 0.0, 0.5, 0.5,
+		0.1, 0.1, 0.2, 0.2,
+	}
+	syntheticClasses       = []float32{1, 1, 1, 2, 0}
+	syntheticProbabilities = []float32{0.9, 0.8, 0.7, 0.6, 0.95}
+)
+
+func TestPostprocessDetectionsNMS(t *testing.T) {
+	opts := detectionPostprocessOptions{
+		nmsThreshold:    0.5,
+		backgroundIndex: 0,
+	}
+	probabilities, classes, boxes := postprocessDetections(syntheticProbabilities, syntheticClasses, syntheticBoxes, 1, xyxyBoxIndex, opts)
+
+	// the second box overlaps the first one of the same class, the overlapping box of class 2 is kept
+	// and the background detection is dropped
+	assert.Equal(t, [][]float32{{0.9, 0.7, 0.6}}, probabilities)
+	assert.Equal(t, [][]float32{{1, 1, 2}}, classes)
+	assert.Equal(t, []float32{0.5, 0.5, 1.0, 1.0}, boxes[0][1])
+}
+
+func TestPostprocessDetectionsScoreThresholdAndMaxDetections(t *testing.T) {
+	opts := detectionPostprocessOptions{
+		scoreThreshold:  0.65,
+		backgroundIndex: -1,
+	}
+	probabilities, classes, _ := postprocessDetections(syntheticProbabilities, syntheticClasses, syntheticBoxes, 1, xyxyBoxIndex, opts)
+	assert.Equal(t, [][]float32{{0.95, 0.9, 0.8, 0.7}}, probabilities)
+	assert.Equal(t, [][]float32{{0, 1, 1, 1}}, classes)
+
+	opts.maxDetections = 2
+	probabilities, _, _ = postprocessDetections(syntheticProbabilities, syntheticClasses, syntheticBoxes, 1, xyxyBoxIndex, opts)
+	assert.Equal(t, [][]float32{{0.95, 0.9}}, probabilities)
+}
+
+func TestPostprocessDetectionsSoftNMS(t *testing.T) {
+	opts := detectionPostprocessOptions{
+		softNMSSigma:    0.5,
+		backgroundIndex: 0,
+	}
+	probabilities, classes, _ := postprocessDetections(syntheticProbabilities, syntheticClasses, syntheticBoxes, 1, xyxyBoxIndex, opts)
+
+	// the overlapping box has an IoU of 0.81 with the best one, it is decayed rather than suppressed
+	assert.Len(t, probabilities[0], 4)
+	assert.Equal(t, []float32{1, 1, 2, 1}, classes[0])
+	assert.InDelta(t, 0.8*math.Exp(-0.81*0.81/0.5), probabilities[0][3], 0.001)
+}
+
+func TestPostprocessDetectionsBatch(t *testing.T) {
+	opts := detectionPostprocessOptions{
+		scoreThreshold:  0.75,
+		backgroundIndex: -1,
+	}
+	probabilities, _, boxes := postprocessDetections(
+		[]float32{0.9, 0.1, 0.2, 0.8},
+		[]float32{1, 1, 1, 1},
+		[]float32{
+			0, 0, 1, 1,
+			0, 0, 1, 1,
+			0, 0, 1, 1,
+			0, 0, 1, 1,
+		}, 2, xyxyBoxIndex, opts)
+	assert.Equal(t, [][]float32{{0.9}, {0.8}}, probabilities)
+	assert.Len(t, boxes, 2)
+}
+
+func TestDetectionPostprocessOverrides(t *testing.T) {
+	opts := detectionPostprocessOptions{
+		scoreThreshold: 0.5,
+		nmsThreshold:   0.45,
+		maxDetections:  100,
+	}
+	assert.Equal(t, opts, opts.withOverrides(context.Background()))
+
+	ctx := options.New(
+		ScoreThreshold(0.3),
+		MaxDetections(10),
+		SoftNMS(0.5),
+	).Context()
+	overridden := opts.withOverrides(ctx)
+	assert.Equal(t, float32(0.3), overridden.scoreThreshold)
+	assert.Equal(t, float32(0.45), overridden.nmsThreshold)
+	assert.Equal(t, 10, overridden.maxDetections)
+	assert.Equal(t, float32(0.5), overridden.softNMSSigma)
+}
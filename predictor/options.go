@@ -0,0 +1,159 @@
+package predictor
+
+import (
+	"context"
+	"time"
+
+	"github.com/c3sr/dlframework/framework/options"
+)
+
+type scoreThresholdKey struct{}
+type nmsThresholdKey struct{}
+type maxDetectionsKey struct{}
+type softNMSSigmaKey struct{}
+type temperatureKey struct{}
+type topKKey struct{}
+type replicasKey struct{}
+type maxBatchLatencyKey struct{}
+type resizeMasksKey struct{}
+type segmentationExportsKey struct{}
+type overlayAlphaKey struct{}
+type imageEncodingKey struct{}
+type jpegQualityKey struct{}
+type tilingKey struct{}
+type tileBatchSizeKey struct{}
+type testTimeAugmentationKey struct{}
+
+// withContextValue sets an option on the context of the options. The options of a predictor are resolved from
+// its manifest, then the withOverrides method of their type applies the values set on the context of the options
+// the predictor was loaded with, and then those set on the context of the per-request options.
+func withContextValue(key, val interface{}) options.Option {
+	return func(o *options.Options) {
+		ctx := o.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		o.SetContext(context.WithValue(ctx, key, val))
+	}
+}
+
+// ScoreThreshold drops the detections with a probability lower than threshold.
+// It overrides the score_threshold parameter of the model manifest.
+func ScoreThreshold(threshold float32) options.Option {
+	return withContextValue(scoreThresholdKey{}, threshold)
+}
+
+// NMSThreshold sets the IoU above which overlapping detections of the same class are suppressed.
+// A threshold of 0 disables the non-maximum suppression.
+// It overrides the nms_threshold parameter of the model manifest.
+func NMSThreshold(threshold float32) options.Option {
+	return withContextValue(nmsThresholdKey{}, threshold)
+}
+
+// MaxDetections caps the number of detections returned for each image.
+// A value of 0 returns all the detections.
+// It overrides the max_detections parameter of the model manifest.
+func MaxDetections(n int) options.Option {
+	return withContextValue(maxDetectionsKey{}, n)
+}
+
+// SoftNMS replaces the non-maximum suppression by a gaussian soft-NMS with the given sigma.
+// A sigma of 0 selects the hard non-maximum suppression.
+// It overrides the soft_nms_sigma parameter of the model manifest.
+func SoftNMS(sigma float32) options.Option {
+	return withContextValue(softNMSSigmaKey{}, sigma)
+}
+
+// Temperature divides the classification outputs by t before the probabilities transform.
+// It overrides the temperature parameter of the model manifest.
+func Temperature(t float32) options.Option {
+	return withContextValue(temperatureKey{}, t)
+}
+
+// TopK caps the number of classes returned for each image.
+// A value of 0 returns all the classes.
+// It overrides the top_k parameter of the model manifest.
+func TopK(k int) options.Option {
+	return withContextValue(topKKey{}, k)
+}
+
+// ResizeMasks maps the semantic segmentation masks back onto the source images when enabled,
+// instead of returning them at the resolution of the model output. The scores are upsampled
+// bilinearly before the argmax, and the masks of the models running the argmax are resampled
+// to the nearest class. It overrides the resize_masks parameter of the model manifest.
+func ResizeMasks(enabled bool) options.Option {
+	return withContextValue(resizeMasksKey{}, enabled)
+}
+
+// SegmentationExports selects the artifacts rendered from the segmentation masks, such as the colorized
+// masks or their COCO encodings. No artifact is rendered when exports is empty.
+// It overrides the exports parameter of the model manifest.
+func SegmentationExports(exports ...SegmentationExport) options.Option {
+	if exports == nil {
+		exports = []SegmentationExport{}
+	}
+	return withContextValue(segmentationExportsKey{}, exports)
+}
+
+// OverlayAlpha sets the opacity, between 0 and 1, of the colorized mask blended over the source image
+// by the ExportOverlayPNG export. It overrides the overlay_alpha parameter of the model manifest.
+func OverlayAlpha(alpha float32) options.Option {
+	return withContextValue(overlayAlphaKey{}, alpha)
+}
+
+// OutputEncoding selects how the images produced by the image enhancement models are returned,
+// as raw float pixels or as PNG or JPEG encoded images. It overrides the encoding parameter of the model manifest.
+func OutputEncoding(encoding ImageEncoding) options.Option {
+	return withContextValue(imageEncodingKey{}, encoding)
+}
+
+// JPEGQuality sets the quality, between 1 and 100, of the JPEG encoded images.
+// It overrides the jpeg_quality parameter of the model manifest.
+func JPEGQuality(quality int) options.Option {
+	return withContextValue(jpegQualityKey{}, quality)
+}
+
+// Tiling runs the image enhancement and semantic segmentation models on overlapping square tiles of size
+// pixels instead of whole images, and blends their outputs back with feathered seams over overlap pixels.
+// A size of 0 disables the tiling. It overrides the tile_size and tile_overlap parameters of the model manifest.
+// Only the models of a single image input are tiled: enabling the tiling of a model declaring several inputs is an error.
+func Tiling(size, overlap int) options.Option {
+	return withContextValue(tilingKey{}, [2]int{size, overlap})
+}
+
+// TileBatchSize sets the number of tiles run through the model at once.
+// It overrides the tile_batch_size parameter of the model manifest.
+func TileBatchSize(n int) options.Option {
+	return withContextValue(tileBatchSizeKey{}, n)
+}
+
+// TestTimeAugmentation runs the variants of every image declared by the augmentations parameter of the manifest,
+// such as its mirrored image, its crops or its rescaled images, and averages their outputs before decoding them.
+// It overrides the test_time_augmentation parameter of the model manifest.
+func TestTimeAugmentation(enabled bool) options.Option {
+	return withContextValue(testTimeAugmentationKey{}, enabled)
+}
+
+// Replicas sets the number of replicas of the TorchScript module loaded by the predictor.
+// Each replica runs one inference at a time, so up to n concurrent calls to Infer run in parallel.
+// It defaults to 1, and is read when the predictor is loaded.
+func Replicas(n int) options.Option {
+	return withContextValue(replicasKey{}, n)
+}
+
+// replicasFromOptions returns the number of replicas set through Replicas.
+func replicasFromOptions(opts *options.Options) int {
+	if opts == nil || opts.Context() == nil {
+		return 1
+	}
+	if val, ok := opts.Context().Value(replicasKey{}).(int); ok {
+		return val
+	}
+	return 1
+}
+
+// MaxBatchLatency sets how long a Batcher waits for more requests once the first request of a batch arrived.
+// It defaults to 5ms.
+func MaxBatchLatency(d time.Duration) options.Option {
+	return withContextValue(maxBatchLatencyKey{}, d)
+}
@@ -0,0 +1,128 @@
+package predictor
+
+import (
+	"fmt"
+	"strings"
+
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// anyDim matches an output dimension of any size.
+const anyDim = -1
+
+// OutputCountError is returned when a model returns fewer outputs than its predictor decodes.
+type OutputCountError struct {
+	// Model is the name of the model.
+	Model string
+	// Expected is the least number of outputs the predictor decodes.
+	Expected int
+	// Actual is the number of outputs the model returned.
+	Actual int
+}
+
+// Error ...
+func (e *OutputCountError) Error() string {
+	return fmt.Sprintf("model %v returned %v outputs, expecting at least %v", e.Model, e.Actual, e.Expected)
+}
+
+// OutputShapeError is returned when an output of a model does not have the rank or the dimensions its predictor decodes.
+type OutputShapeError struct {
+	// Model is the name of the model.
+	Model string
+	// Output is the index of the output.
+	Output int
+	// Expected is the expected shape, where anyDim matches a dimension of any size.
+	Expected []int
+	// Actual is the shape of the output.
+	Actual []int
+}
+
+// Error ...
+func (e *OutputShapeError) Error() string {
+	return fmt.Sprintf("output %v of model %v has the shape %v, expecting %v", e.Output, e.Model, formatDims(e.Actual), formatDims(e.Expected))
+}
+
+// formatDims formats a shape as (1, 3, *, *), a star standing for anyDim.
+func formatDims(dims []int) string {
+	strs := make([]string, len(dims))
+	for ii, dim := range dims {
+		if dim == anyDim {
+			strs[ii] = "*"
+		} else {
+			strs[ii] = fmt.Sprint(dim)
+		}
+	}
+	return "(" + strings.Join(strs, ", ") + ")"
+}
+
+// checkOutputCount returns an error when the model returned fewer than n outputs.
+func checkOutputCount(model string, outputs []gotensor.Tensor, n int) error {
+	if len(outputs) < n {
+		return &OutputCountError{Model: model, Expected: n, Actual: len(outputs)}
+	}
+	return nil
+}
+
+// checkOutputShape returns an error when the output does not have the expected rank and dimensions.
+func checkOutputShape(model string, index int, output gotensor.Tensor, expected []int) error {
+	shape := output.Shape()
+	err := &OutputShapeError{Model: model, Output: index, Expected: expected, Actual: []int(shape.Clone())}
+	if len(shape) != len(expected) {
+		return err
+	}
+	for ii, dim := range expected {
+		if dim != anyDim && shape[ii] != dim {
+			return err
+		}
+	}
+	return nil
+}
+
+// expectedOutputShape returns the shape expected for the output decoded by the predictor: a batch dimension
+// followed by the dimensions parameter of the manifest output, where -1 matches any size. The dimensions
+// default to the ones of the predictor, whose rank the manifest must keep.
+func expectedOutputShape(base common.Base, defaults []int) ([]int, error) {
+	var dims []int
+	ok, err := getOutputListParameter(base, "dimensions", &dims)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return append([]int{anyDim}, defaults...), nil
+	}
+	if len(dims) != len(defaults) {
+		return nil, errors.Errorf("model %v declares the output dimensions %v, expecting %v dimensions without the batch",
+			base.Model.GetName(), dims, len(defaults))
+	}
+	for ii, dim := range dims {
+		if dim < 1 && dim != anyDim {
+			return nil, errors.Errorf("model %v declares the invalid output dimension %v", base.Model.GetName(), dim)
+		}
+		if defaults[ii] != anyDim && dim != defaults[ii] {
+			return nil, errors.Errorf("model %v declares the output dimensions %v, but the predictor decodes %v",
+				base.Model.GetName(), dims, formatDims(defaults))
+		}
+	}
+	return append([]int{anyDim}, dims...), nil
+}
+
+// checkSingleOutput checks the first output of a single output model against the dimensions of the manifest,
+// or the default dimensions of its predictor, given without the batch dimension.
+func checkSingleOutput(base common.Base, outputs []gotensor.Tensor, defaults ...int) error {
+	return checkOutput(base, outputs, 0, defaults...)
+}
+
+// checkOutput checks the output of the given index against the dimensions of the manifest,
+// or the default dimensions of its predictor, given without the batch dimension.
+func checkOutput(base common.Base, outputs []gotensor.Tensor, index int, defaults ...int) error {
+	if err := checkOutputCount(base.Model.GetName(), outputs, index+1); err != nil {
+		return err
+	}
+	expected, err := expectedOutputShape(base, defaults)
+	if err != nil {
+		return err
+	}
+	return checkOutputShape(base.Model.GetName(), index, outputs[index], expected)
+}
@@ -0,0 +1,82 @@
+package predictor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/c3sr/dlframework"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+func outputDimensionsBase(dimensions string) common.Base {
+	params := map[string]*dlframework.ModelManifest_Type_Parameter{}
+	if dimensions != "" {
+		params["dimensions"] = &dlframework.ModelManifest_Type_Parameter{Value: dimensions}
+	}
+	return common.Base{
+		Model: dlframework.ModelManifest{
+			Name:   "fake",
+			Output: &dlframework.ModelManifest_Type{Parameters: params},
+		},
+	}
+}
+
+func TestCheckSingleOutput(t *testing.T) {
+	images := []gotensor.Tensor{gotensor.New(gotensor.WithShape(2, 3, 4, 5), gotensor.Of(gotensor.Float32))}
+
+	assert.NoError(t, checkSingleOutput(outputDimensionsBase(""), images, anyDim, anyDim, anyDim))
+	assert.NoError(t, checkSingleOutput(outputDimensionsBase("[3, -1, 5]"), images, anyDim, anyDim, anyDim))
+
+	err := checkSingleOutput(outputDimensionsBase("[1, -1, -1]"), images, anyDim, anyDim, anyDim)
+	assert.Equal(t, &OutputShapeError{Model: "fake", Output: 0, Expected: []int{anyDim, 1, anyDim, anyDim}, Actual: []int{2, 3, 4, 5}}, err)
+	assert.EqualError(t, err, "output 0 of model fake has the shape (2, 3, 4, 5), expecting (*, 1, *, *)")
+
+	err = checkSingleOutput(outputDimensionsBase(""), []gotensor.Tensor{gotensor.New(gotensor.WithShape(3, 4, 5), gotensor.Of(gotensor.Float32))}, anyDim, anyDim, anyDim)
+	assert.EqualError(t, err, "output 0 of model fake has the shape (3, 4, 5), expecting (*, *, *, *)")
+
+	err = checkSingleOutput(outputDimensionsBase(""), nil, anyDim, anyDim, anyDim)
+	assert.Equal(t, &OutputCountError{Model: "fake", Expected: 1, Actual: 0}, err)
+	assert.EqualError(t, err, "model fake returned 0 outputs, expecting at least 1")
+
+	// the manifest must keep the rank and the fixed dimensions of the predictor
+	assert.Error(t, checkSingleOutput(outputDimensionsBase("[3, -1]"), images, anyDim, anyDim, anyDim))
+	assert.Error(t, checkSingleOutput(outputDimensionsBase("[4, -1, -1]"), images, 3, anyDim, anyDim))
+	assert.Error(t, checkSingleOutput(outputDimensionsBase("[0, -1, -1]"), images, anyDim, anyDim, anyDim))
+	assert.Error(t, checkSingleOutput(outputDimensionsBase("three"), images, anyDim, anyDim, anyDim))
+}
+
+func TestImageEnhancementDecodeShapes(t *testing.T) {
+	p := &ImageEnhancementPredictor{}
+	p.Base = outputDimensionsBase("[3, -1, -1]")
+	p.Model.Inputs = []*dlframework.ModelManifest_Type{{
+		Type: "image",
+		Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+			"mean":  {Value: "[0, 0, 0]"},
+			"scale": {Value: "255"},
+		},
+	}}
+	ctx := context.Background()
+
+	features, err := p.decodeOutputs(ctx, []gotensor.Tensor{gotensor.New(gotensor.WithShape(1, 3, 2, 2), gotensor.Of(gotensor.Float32))})
+	assert.NoError(t, err)
+	assert.Len(t, features, 1)
+
+	_, err = p.decodeOutputs(ctx, []gotensor.Tensor{gotensor.New(gotensor.WithShape(1, 4, 2, 2), gotensor.Of(gotensor.Float32))})
+	assert.IsType(t, &OutputShapeError{}, err)
+	_, err = p.decodeOutputs(ctx, []gotensor.Tensor{gotensor.New(gotensor.WithShape(3, 2, 2), gotensor.Of(gotensor.Float32))})
+	assert.IsType(t, &OutputShapeError{}, err)
+	_, err = p.decodeOutputs(ctx, nil)
+	assert.IsType(t, &OutputCountError{}, err)
+}
+
+func TestTorchPredictorNoOutputs(t *testing.T) {
+	withFakeTorchModules(t)
+	p := openFakeTorchPredictor(t)
+	defer p.Close()
+
+	// the fake module returns its inputs, none here
+	_, err := p.Infer(context.Background(), []gotensor.Tensor{})
+	assert.Equal(t, &OutputCountError{Model: "fake", Expected: 1, Actual: 0}, err)
+}
@@ -0,0 +1,374 @@
+package predictor
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/c3sr/dlframework"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	raiimage "github.com/c3sr/image"
+	"github.com/c3sr/image/types"
+	"github.com/c3sr/tracer"
+	"github.com/oliamb/cutter"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// ResizeMode selects how an image is fit to the input dimensions of the model.
+type ResizeMode string
+
+const (
+	// ResizeStretch resizes the image to the input dimensions, ignoring its aspect ratio.
+	ResizeStretch ResizeMode = "stretch"
+	// ResizeKeepAspect scales the image to the min_dimension or max_dimension of the manifest
+	// and keeps its aspect ratio, the input then has the dimensions of the scaled image.
+	ResizeKeepAspect ResizeMode = "keep_aspect"
+	// ResizeCenterCrop scales the image so that it covers the input dimensions divided by the
+	// crop_ratio and crops it, from the center or the top left corner depending on the crop_method.
+	ResizeCenterCrop ResizeMode = "center_crop"
+	// ResizePad scales the image so that it fits the input dimensions and pads the borders
+	// with the pad_value.
+	ResizePad ResizeMode = "pad"
+)
+
+// ImagePreprocessor converts images into the batched input tensor of a model.
+type ImagePreprocessor struct {
+	common.PreprocessOptions
+	ResizeMode ResizeMode
+	// PadValue is the pixel value, before normalization, of the padded borders
+	PadValue float32
+}
+
+// NewImagePreprocessor returns the preprocessor described by the input parameters of the model manifest:
+// the dimensions, layout, color_mode, mean and scale, as well as the resize_mode and pad_value.
+// The resize_mode defaults to stretch when the dimensions are set and to keep_aspect otherwise,
+// unless keep_aspect_ratio is set, which selects pad when the dimensions are set.
+func NewImagePreprocessor(model dlframework.ModelManifest) (*ImagePreprocessor, error) {
+	if len(model.GetInputs()) == 0 {
+		return nil, errors.New("the model has no inputs")
+	}
+	p := common.ImagePredictor{
+		Base: common.Base{
+			Model: model,
+		},
+	}
+	opts, err := p.GetPreprocessOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	pre := &ImagePreprocessor{
+		PreprocessOptions: opts,
+		ResizeMode:        ResizeStretch,
+	}
+	keepAspectRatio := opts.KeepAspectRatio != nil && *opts.KeepAspectRatio
+	if opts.Dims == nil {
+		pre.ResizeMode = ResizeKeepAspect
+	} else if keepAspectRatio {
+		pre.ResizeMode = ResizePad
+	}
+
+	typeParameters := model.GetInputs()[0].GetParameters()
+	if mode, err := p.GetTypeParameter(typeParameters, "resize_mode"); err == nil && mode != "" {
+		pre.ResizeMode = ResizeMode(mode)
+		// only the stretch mode changes the aspect ratio of the images
+		if opts.KeepAspectRatio != nil && keepAspectRatio == (pre.ResizeMode == ResizeStretch) {
+			return nil, errors.Errorf("the resize_mode %v conflicts with keep_aspect_ratio %v", mode, keepAspectRatio)
+		}
+	}
+	if str, err := p.GetTypeParameter(typeParameters, "pad_value"); err == nil && str != "" {
+		val, err := strconv.ParseFloat(str, 32)
+		if err != nil {
+			return nil, errors.Errorf("unable to get pad_value %v as a float32", str)
+		}
+		pre.PadValue = float32(val)
+	}
+
+	if err := pre.validate(); err != nil {
+		return nil, err
+	}
+	return pre, nil
+}
+
+func (p *ImagePreprocessor) validate() error {
+	if p.ElementType != "" && p.ElementType != "float32" {
+		return errors.Errorf("element type %v is not supported", p.ElementType)
+	}
+	if p.Layout != raiimage.CHWLayout && p.Layout != raiimage.HWCLayout {
+		return errors.New("the layout must be either CHW or HWC")
+	}
+	if p.ColorMode != types.RGBMode && p.ColorMode != types.BGRMode {
+		return errors.New("the color_mode must be either RGB or BGR")
+	}
+	if len(p.MeanImage) != 1 && len(p.MeanImage) != 3 {
+		return errors.Errorf("expecting 1 or 3 mean values, but got %v", len(p.MeanImage))
+	}
+	if len(p.Scale) != 1 && len(p.Scale) != 3 {
+		return errors.Errorf("expecting 1 or 3 scale values, but got %v", len(p.Scale))
+	}
+	switch p.ResizeMode {
+	case ResizeKeepAspect:
+	case ResizeStretch, ResizeCenterCrop, ResizePad:
+		if len(p.Dims) != 3 {
+			return errors.Errorf("the %v resize mode requires the input dimensions", p.ResizeMode)
+		}
+	default:
+		return errors.Errorf("resize mode %v is not supported", p.ResizeMode)
+	}
+	return nil
+}
+
+// inputSize returns the height and width of the input dimensions.
+func (p *ImagePreprocessor) inputSize() (int, int) {
+	if p.Layout == raiimage.HWCLayout && p.Dims[2] <= 4 {
+		return p.Dims[0], p.Dims[1]
+	}
+	return p.Dims[1], p.Dims[2]
+}
+
+// geometry places an image of the source size onto the input.
+// The image is scaled to resizedWidth x resizedHeight and its top left corner is drawn at offsetX, offsetY
+// of an input of width x height. Negative offsets crop the image, positive ones pad it.
+func (p *ImagePreprocessor) geometry(srcWidth, srcHeight int) (width, height, resizedWidth, resizedHeight, offsetX, offsetY int) {
+	sw, sh := float64(srcWidth), float64(srcHeight)
+
+	if p.ResizeMode == ResizeKeepAspect {
+		scale := 1.0
+		switch {
+		case p.MinDimension != nil:
+			scale = float64(*p.MinDimension) / math.Min(sw, sh)
+		case p.MaxDimension != nil:
+			scale = float64(*p.MaxDimension) / math.Max(sw, sh)
+		case len(p.Dims) == 3:
+			inputHeight, inputWidth := p.inputSize()
+			scale = math.Min(float64(inputWidth)/sw, float64(inputHeight)/sh)
+		}
+		width = int(math.Round(sw * scale))
+		height = int(math.Round(sh * scale))
+		return width, height, width, height, 0, 0
+	}
+
+	height, width = p.inputSize()
+	if p.ResizeMode == ResizeStretch {
+		return width, height, width, height, 0, 0
+	}
+
+	var scale float64
+	if p.ResizeMode == ResizeCenterCrop {
+		cropRatio := float64(p.CropRatio)
+		if cropRatio <= 0 {
+			cropRatio = 1
+		}
+		scale = math.Max(float64(width)/sw, float64(height)/sh) / cropRatio
+	} else {
+		scale = math.Min(float64(width)/sw, float64(height)/sh)
+	}
+	resizedWidth = int(math.Round(sw * scale))
+	resizedHeight = int(math.Round(sh * scale))
+	if p.CropMethod != cutter.TopLeft {
+		offsetX = (width - resizedWidth) / 2
+		offsetY = (height - resizedHeight) / 2
+	}
+	return width, height, resizedWidth, resizedHeight, offsetX, offsetY
+}
+
+// imageGeometry records how an image of the source size was placed onto the input of the model,
+// so that the outputs can be mapped back onto the source image.
+type imageGeometry struct {
+	srcWidth, srcHeight         int
+	width, height               int
+	resizedWidth, resizedHeight int
+	offsetX, offsetY            int
+}
+
+// imageGeometry returns the placement of the image onto the input.
+func (p *ImagePreprocessor) imageGeometry(img image.Image) imageGeometry {
+	bounds := img.Bounds()
+	g := imageGeometry{srcWidth: bounds.Dx(), srcHeight: bounds.Dy()}
+	g.width, g.height, g.resizedWidth, g.resizedHeight, g.offsetX, g.offsetY = p.geometry(g.srcWidth, g.srcHeight)
+	return g
+}
+
+// PreprocessImage resizes and normalizes a single image.
+// It returns the image data in the layout and color mode of the model along with its shape, without the batch dimension.
+func (p *ImagePreprocessor) PreprocessImage(img image.Image) ([]float32, []int, error) {
+	src, srcWidth, srcHeight := rgbPixels(img)
+	if srcWidth == 0 || srcHeight == 0 {
+		return nil, nil, errors.New("cannot preprocess an empty image")
+	}
+	g := imageGeometry{srcWidth: srcWidth, srcHeight: srcHeight}
+	g.width, g.height, g.resizedWidth, g.resizedHeight, g.offsetX, g.offsetY = p.geometry(srcWidth, srcHeight)
+	return p.render(src, g, false)
+}
+
+// render draws the packed RGB pixels of a source image onto the input with the geometry, and normalizes them.
+// The input is mirrored horizontally when flip is set.
+func (p *ImagePreprocessor) render(src []uint8, g imageGeometry, flip bool) ([]float32, []int, error) {
+	srcWidth, srcHeight := g.srcWidth, g.srcHeight
+	width, height, resizedWidth, resizedHeight, offsetX, offsetY := g.width, g.height, g.resizedWidth, g.resizedHeight, g.offsetX, g.offsetY
+	if width <= 0 || height <= 0 || resizedWidth <= 0 || resizedHeight <= 0 {
+		return nil, nil, errors.Errorf("cannot resize an image of %vx%v", srcWidth, srcHeight)
+	}
+
+	channelIndex := [3]int{0, 1, 2}
+	if p.ColorMode == types.BGRMode {
+		channelIndex = [3]int{2, 1, 0}
+	}
+	var mean, scale [3]float32
+	for c := 0; c < 3; c++ {
+		mean[c] = p.MeanImage[c%len(p.MeanImage)]
+		scale[c] = p.Scale[c%len(p.Scale)]
+	}
+
+	out := make([]float32, 3*height*width)
+	index := func(c, y, x int) int {
+		if flip {
+			x = width - 1 - x
+		}
+		if p.Layout == raiimage.HWCLayout {
+			return (y*width+x)*3 + c
+		}
+		return (c*height+y)*width + x
+	}
+
+	xScale := float64(srcWidth) / float64(resizedWidth)
+	yScale := float64(srcHeight) / float64(resizedHeight)
+	for y := 0; y < height; y++ {
+		ry := y - offsetY
+		for x := 0; x < width; x++ {
+			rx := x - offsetX
+			if rx < 0 || ry < 0 || rx >= resizedWidth || ry >= resizedHeight {
+				for c := 0; c < 3; c++ {
+					out[index(c, y, x)] = (p.PadValue - mean[c]) / scale[c]
+				}
+				continue
+			}
+			rgb := bilinear(src, srcWidth, srcHeight, (float64(rx)+0.5)*xScale-0.5, (float64(ry)+0.5)*yScale-0.5)
+			for c := 0; c < 3; c++ {
+				out[index(c, y, x)] = (rgb[channelIndex[c]] - mean[c]) / scale[c]
+			}
+		}
+	}
+
+	if p.Layout == raiimage.HWCLayout {
+		return out, []int{height, width, 3}, nil
+	}
+	return out, []int{3, height, width}, nil
+}
+
+// Preprocess converts the images into a batched input tensor.
+// All the images must be preprocessed to the same shape.
+func (p *ImagePreprocessor) Preprocess(ctx context.Context, imgs []image.Image) (gotensor.Tensor, error) {
+	span, _ := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "preprocess")
+	defer span.Finish()
+
+	if len(imgs) == 0 {
+		return nil, errors.New("no images to preprocess")
+	}
+
+	var batch []float32
+	var shape []int
+	for ii, img := range imgs {
+		data, imgShape, err := p.PreprocessImage(img)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot preprocess image %v", ii)
+		}
+		if shape == nil {
+			shape = imgShape
+			batch = make([]float32, 0, len(imgs)*len(data))
+		} else if !gotensor.Shape(shape).Eq(gotensor.Shape(imgShape)) {
+			return nil, errors.Errorf("image %v has the shape %v, but the first image of the batch has the shape %v", ii, imgShape, shape)
+		}
+		batch = append(batch, data...)
+	}
+
+	return gotensor.New(
+		gotensor.WithShape(append([]int{len(imgs)}, shape...)...),
+		gotensor.WithBacking(batch),
+	), nil
+}
+
+// PreprocessBytes decodes the encoded images and converts them into a batched input tensor.
+func (p *ImagePreprocessor) PreprocessBytes(ctx context.Context, data [][]byte) (gotensor.Tensor, error) {
+	imgs, err := decodeImages(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return p.Preprocess(ctx, imgs)
+}
+
+// decodeImages decodes the encoded images as RGB images.
+func decodeImages(ctx context.Context, data [][]byte) ([]image.Image, error) {
+	imgs := make([]image.Image, len(data))
+	for ii, buf := range data {
+		img, err := raiimage.Read(bytes.NewReader(buf), raiimage.Mode(types.RGBMode), raiimage.Context(ctx))
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot decode image %v", ii)
+		}
+		imgs[ii] = img
+	}
+	return imgs, nil
+}
+
+// rgbPixels returns the pixels of the image as packed RGB values along with its width and height.
+func rgbPixels(img image.Image) ([]uint8, int, int) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	pix := make([]uint8, width*height*3)
+
+	switch in := img.(type) {
+	case *types.RGBImage:
+		for y := 0; y < height; y++ {
+			offset := in.PixOffset(bounds.Min.X, bounds.Min.Y+y)
+			copy(pix[y*width*3:(y+1)*width*3], in.Pix[offset:offset+width*3])
+		}
+	case *types.BGRImage:
+		for y := 0; y < height; y++ {
+			offset := in.PixOffset(bounds.Min.X, bounds.Min.Y+y)
+			row := in.Pix[offset : offset+width*3]
+			for x := 0; x < width; x++ {
+				pix[(y*width+x)*3+0] = row[x*3+2]
+				pix[(y*width+x)*3+1] = row[x*3+1]
+				pix[(y*width+x)*3+2] = row[x*3+0]
+			}
+		}
+	default:
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				c := color.RGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.RGBA)
+				pix[(y*width+x)*3+0] = c.R
+				pix[(y*width+x)*3+1] = c.G
+				pix[(y*width+x)*3+2] = c.B
+			}
+		}
+	}
+
+	return pix, width, height
+}
+
+// bilinear samples the packed RGB pixels at the source coordinates x, y.
+func bilinear(pix []uint8, width, height int, x, y float64) [3]float32 {
+	x = math.Max(0, math.Min(x, float64(width-1)))
+	y = math.Max(0, math.Min(y, float64(height-1)))
+	x0, y0 := int(x), int(y)
+	x1, y1 := x0+1, y0+1
+	if x1 >= width {
+		x1 = width - 1
+	}
+	if y1 >= height {
+		y1 = height - 1
+	}
+	dx, dy := float32(x-float64(x0)), float32(y-float64(y0))
+
+	var res [3]float32
+	for c := 0; c < 3; c++ {
+		top := float32(pix[(y0*width+x0)*3+c])*(1-dx) + float32(pix[(y0*width+x1)*3+c])*dx
+		bottom := float32(pix[(y1*width+x0)*3+c])*(1-dx) + float32(pix[(y1*width+x1)*3+c])*dx
+		res[c] = top*(1-dy) + bottom*dy
+	}
+	return res
+}
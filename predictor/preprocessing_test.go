@@ -0,0 +1,169 @@
+package predictor
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/c3sr/dlframework"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	raiimage "github.com/c3sr/image"
+	"github.com/c3sr/image/types"
+	"github.com/oliamb/cutter"
+	"github.com/stretchr/testify/assert"
+)
+
+func testImagePreprocessor(mode ResizeMode, dims []int) *ImagePreprocessor {
+	return &ImagePreprocessor{
+		PreprocessOptions: common.PreprocessOptions{
+			MeanImage:  []float32{0, 0, 0},
+			Scale:      []float32{1, 1, 1},
+			Dims:       dims,
+			ColorMode:  types.RGBMode,
+			Layout:     raiimage.CHWLayout,
+			CropMethod: cutter.Centered,
+			CropRatio:  1,
+		},
+		ResizeMode: mode,
+	}
+}
+
+func TestImagePreprocessorGeometry(t *testing.T) {
+	pre := testImagePreprocessor(ResizeStretch, []int{3, 224, 224})
+	width, height, resizedWidth, resizedHeight, offsetX, offsetY := pre.geometry(640, 480)
+	assert.Equal(t, []int{224, 224, 224, 224, 0, 0}, []int{width, height, resizedWidth, resizedHeight, offsetX, offsetY})
+
+	pre = testImagePreprocessor(ResizeCenterCrop, []int{3, 224, 224})
+	pre.CropRatio = 0.875
+	width, height, resizedWidth, resizedHeight, offsetX, offsetY = pre.geometry(640, 480)
+	assert.Equal(t, []int{224, 224, 341, 256, -58, -16}, []int{width, height, resizedWidth, resizedHeight, offsetX, offsetY})
+
+	pre.CropMethod = cutter.TopLeft
+	_, _, _, _, offsetX, offsetY = pre.geometry(640, 480)
+	assert.Equal(t, []int{0, 0}, []int{offsetX, offsetY})
+
+	pre = testImagePreprocessor(ResizePad, []int{3, 300, 300})
+	width, height, resizedWidth, resizedHeight, offsetX, offsetY = pre.geometry(640, 480)
+	assert.Equal(t, []int{300, 300, 300, 225, 0, 37}, []int{width, height, resizedWidth, resizedHeight, offsetX, offsetY})
+
+	pre = testImagePreprocessor(ResizeKeepAspect, nil)
+	width, height, resizedWidth, resizedHeight, offsetX, offsetY = pre.geometry(640, 480)
+	assert.Equal(t, []int{640, 480, 640, 480, 0, 0}, []int{width, height, resizedWidth, resizedHeight, offsetX, offsetY})
+
+	minDimension := 800
+	pre.MinDimension = &minDimension
+	width, height, _, _, _, _ = pre.geometry(640, 480)
+	assert.Equal(t, []int{1067, 800}, []int{width, height})
+}
+
+func TestNewImagePreprocessorResizeMode(t *testing.T) {
+	resizeMode := func(dimensions string, params map[string]string) (ResizeMode, error) {
+		parameters := map[string]*dlframework.ModelManifest_Type_Parameter{
+			"layout": {Value: "CHW"},
+			"mean":   {Value: "[0, 0, 0]"},
+			"scale":  {Value: "1"},
+		}
+		if dimensions != "" {
+			parameters["dimensions"] = &dlframework.ModelManifest_Type_Parameter{Value: dimensions}
+		}
+		for name, value := range params {
+			parameters[name] = &dlframework.ModelManifest_Type_Parameter{Value: value}
+		}
+		pre, err := NewImagePreprocessor(dlframework.ModelManifest{
+			Name:   "fake",
+			Inputs: []*dlframework.ModelManifest_Type{{Type: "image", Parameters: parameters}},
+		})
+		if err != nil {
+			return "", err
+		}
+		return pre.ResizeMode, nil
+	}
+
+	for _, tc := range []struct {
+		dimensions string
+		params     map[string]string
+		expected   ResizeMode
+	}{
+		{dimensions: "[3, 224, 224]", expected: ResizeStretch},
+		{expected: ResizeKeepAspect},
+		{dimensions: "[3, 224, 224]", params: map[string]string{"keep_aspect_ratio": "true"}, expected: ResizePad},
+		{dimensions: "[3, 224, 224]", params: map[string]string{"keep_aspect_ratio": "false"}, expected: ResizeStretch},
+		{params: map[string]string{"keep_aspect_ratio": "true"}, expected: ResizeKeepAspect},
+		{dimensions: "[3, 224, 224]", params: map[string]string{"resize_mode": "center_crop"}, expected: ResizeCenterCrop},
+		{dimensions: "[3, 224, 224]", params: map[string]string{"resize_mode": "center_crop", "keep_aspect_ratio": "true"}, expected: ResizeCenterCrop},
+		{dimensions: "[3, 224, 224]", params: map[string]string{"resize_mode": "stretch", "keep_aspect_ratio": "false"}, expected: ResizeStretch},
+	} {
+		mode, err := resizeMode(tc.dimensions, tc.params)
+		assert.NoError(t, err, "%v %v", tc.dimensions, tc.params)
+		assert.Equal(t, tc.expected, mode, "%v %v", tc.dimensions, tc.params)
+	}
+
+	// the resize modes that contradict keep_aspect_ratio are an error
+	_, err := resizeMode("[3, 224, 224]", map[string]string{"resize_mode": "stretch", "keep_aspect_ratio": "true"})
+	assert.Error(t, err)
+	_, err = resizeMode("[3, 224, 224]", map[string]string{"resize_mode": "pad", "keep_aspect_ratio": "false"})
+	assert.Error(t, err)
+}
+
+func TestImagePreprocessorValidate(t *testing.T) {
+	assert.NoError(t, testImagePreprocessor(ResizeKeepAspect, nil).validate())
+	assert.Error(t, testImagePreprocessor(ResizePad, nil).validate())
+	assert.Error(t, testImagePreprocessor(ResizeMode("fill"), []int{3, 224, 224}).validate())
+
+	pre := testImagePreprocessor(ResizeStretch, []int{3, 224, 224})
+	pre.Layout = raiimage.InvalidLayout
+	assert.Error(t, pre.validate())
+}
+
+func TestImagePreprocessorPreprocessImage(t *testing.T) {
+	img := types.NewRGBImage(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.RGBA{R: 10, G: 20, B: 30, A: 255})
+	img.Set(1, 0, color.RGBA{R: 40, G: 50, B: 60, A: 255})
+
+	pre := testImagePreprocessor(ResizeKeepAspect, nil)
+	pre.MeanImage = []float32{10, 20, 30}
+	pre.Scale = []float32{2}
+	data, shape, err := pre.PreprocessImage(img)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 1, 2}, shape)
+	assert.Equal(t, []float32{0, 15, 0, 15, 0, 15}, data)
+
+	pre.ColorMode = types.BGRMode
+	pre.Layout = raiimage.HWCLayout
+	data, shape, err = pre.PreprocessImage(img)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, shape)
+	assert.Equal(t, []float32{10, 0, -10, 25, 15, 5}, data)
+
+	// the padded rows take the pad value
+	pre = testImagePreprocessor(ResizePad, []int{3, 2, 2})
+	pre.PadValue = 255
+	data, shape, err = pre.PreprocessImage(img)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 2, 2}, shape)
+	assert.Equal(t, []float32{10, 40, 255, 255}, data[:4])
+}
+
+func TestImagePreprocessorGenericImages(t *testing.T) {
+	gray := image.NewGray(image.Rect(0, 0, 4, 4))
+	for ii := range gray.Pix {
+		gray.Pix[ii] = 100
+	}
+	rgb := types.NewRGBImage(image.Rect(0, 0, 4, 4))
+	for ii := range rgb.Pix {
+		rgb.Pix[ii] = 100
+	}
+
+	pre := testImagePreprocessor(ResizeStretch, []int{3, 2, 2})
+	input, err := pre.Preprocess(context.Background(), []image.Image{gray, rgb})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 2, 2}, []int(input.Shape()))
+	for _, v := range input.Data().([]float32) {
+		assert.Equal(t, float32(100), v)
+	}
+
+	pre = testImagePreprocessor(ResizeKeepAspect, nil)
+	_, err = pre.Preprocess(context.Background(), []image.Image{gray, types.NewRGBImage(image.Rect(0, 0, 2, 2))})
+	assert.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package predictor
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/feature"
+	"github.com/pkg/errors"
+)
+
+// classificationPostprocessOptions holds the transform applied to the classification outputs
+// and the number of classes returned for each image.
+type classificationPostprocessOptions struct {
+	// transform is one of softmax, log_softmax, sigmoid or none
+	transform   string
+	temperature float32
+	// topK is the number of classes returned, or 0 for all of them
+	topK int
+}
+
+// withOverrides returns the options overridden by the values set on the context
+// through Temperature and TopK.
+func (o classificationPostprocessOptions) withOverrides(ctx context.Context) classificationPostprocessOptions {
+	if ctx == nil {
+		return o
+	}
+	if val, ok := ctx.Value(temperatureKey{}).(float32); ok {
+		o.temperature = val
+	}
+	if val, ok := ctx.Value(topKKey{}).(int); ok {
+		o.topK = val
+	}
+	return o
+}
+
+// applyProbabilitiesTransform converts the raw outputs of a single image into probabilities.
+// The outputs are divided by the temperature before the transform.
+// softmax and log_softmax both produce a normalized distribution, as the softmax of log probabilities
+// is their exponential. sigmoid treats every class independently, for multi-label models.
+// none returns the outputs untouched.
+func applyProbabilitiesTransform(outputs []float32, transform string, temperature float32) ([]float32, error) {
+	if temperature <= 0 {
+		return nil, errors.Errorf("the temperature must be positive, but got %v", temperature)
+	}
+
+	res := make([]float32, len(outputs))
+	switch strings.ToLower(transform) {
+	case "", "none":
+		copy(res, outputs)
+	case "softmax", "log_softmax":
+		if len(outputs) == 0 {
+			return res, nil
+		}
+		maxVal := outputs[0]
+		for _, v := range outputs {
+			maxVal = max32(maxVal, v)
+		}
+		sum := float64(0)
+		for ii, v := range outputs {
+			e := math.Exp(float64(v-maxVal) / float64(temperature))
+			res[ii] = float32(e)
+			sum += e
+		}
+		for ii := range res {
+			res[ii] = float32(float64(res[ii]) / sum)
+		}
+	case "sigmoid":
+		for ii, v := range outputs {
+			res[ii] = float32(1 / (1 + math.Exp(-float64(v)/float64(temperature))))
+		}
+	default:
+		return nil, errors.Errorf("probabilities_transform %v is not supported", transform)
+	}
+
+	return res, nil
+}
+
+// createClassificationFeatures transforms the outputs of each image in the batch into classification features
+// sorted by decreasing probability and keeps the top-k of them.
+func createClassificationFeatures(outputs []float32, batchSize int, labels []string, opts classificationPostprocessOptions) ([]dlframework.Features, error) {
+	if batchSize <= 0 || len(outputs)%batchSize != 0 {
+		return nil, errors.Errorf("cannot split %v outputs into a batch of %v", len(outputs), batchSize)
+	}
+	numClasses := len(outputs) / batchSize
+	if numClasses > len(labels) {
+		return nil, errors.Errorf("the model returned %v classes, but only %v labels are available", numClasses, len(labels))
+	}
+
+	features := make([]dlframework.Features, batchSize)
+	for b := 0; b < batchSize; b++ {
+		probabilities, err := applyProbabilitiesTransform(outputs[b*numClasses:(b+1)*numClasses], opts.transform, opts.temperature)
+		if err != nil {
+			return nil, err
+		}
+		res := make(dlframework.Features, numClasses)
+		for ii, probability := range probabilities {
+			res[ii] = feature.New(
+				feature.ClassificationIndex(int32(ii)),
+				feature.ClassificationLabel(labels[ii]),
+				feature.Probability(probability),
+			)
+		}
+		sort.SliceStable(res, func(i, j int) bool {
+			return res[i].GetProbability() > res[j].GetProbability()
+		})
+		if opts.topK > 0 && len(res) > opts.topK {
+			res = res[:opts.topK]
+		}
+		features[b] = res
+	}
+
+	return features, nil
+}
@@ -0,0 +1,79 @@
+package predictor
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyProbabilitiesTransform(t *testing.T) {
+	logits := []float32{1, 2, 3}
+	e := []float64{math.Exp(1), math.Exp(2), math.Exp(3)}
+	sum := e[0] + e[1] + e[2]
+	softmax := []float32{float32(e[0] / sum), float32(e[1] / sum), float32(e[2] / sum)}
+
+	probabilities, err := applyProbabilitiesTransform(logits, "softmax", 1)
+	assert.NoError(t, err)
+	assert.InDeltaSlice(t, softmax, probabilities, 1e-6)
+
+	// large logits do not overflow
+	probabilities, err = applyProbabilitiesTransform([]float32{1000, 1000}, "softmax", 1)
+	assert.NoError(t, err)
+	assert.InDeltaSlice(t, []float32{0.5, 0.5}, probabilities, 1e-6)
+
+	logSoftmax := make([]float32, len(softmax))
+	for ii, v := range softmax {
+		logSoftmax[ii] = float32(math.Log(float64(v)))
+	}
+	probabilities, err = applyProbabilitiesTransform(logSoftmax, "log_softmax", 1)
+	assert.NoError(t, err)
+	assert.InDeltaSlice(t, softmax, probabilities, 1e-6)
+
+	probabilities, err = applyProbabilitiesTransform([]float32{0, 2}, "sigmoid", 2)
+	assert.NoError(t, err)
+	assert.InDeltaSlice(t, []float32{0.5, float32(1 / (1 + math.Exp(-1)))}, probabilities, 1e-6)
+
+	// a high temperature flattens the distribution
+	probabilities, err = applyProbabilitiesTransform(logits, "softmax", 1e6)
+	assert.NoError(t, err)
+	assert.InDeltaSlice(t, []float32{1.0 / 3, 1.0 / 3, 1.0 / 3}, probabilities, 1e-4)
+
+	probabilities, err = applyProbabilitiesTransform(logits, "none", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, logits, probabilities)
+
+	_, err = applyProbabilitiesTransform(logits, "softplus", 1)
+	assert.Error(t, err)
+	_, err = applyProbabilitiesTransform(logits, "softmax", 0)
+	assert.Error(t, err)
+}
+
+func TestCreateClassificationFeaturesTopK(t *testing.T) {
+	labels := []string{"a", "b", "c"}
+	outputs := []float32{
+		1, 3, 2,
+		3, 2, 1,
+	}
+	opts := classificationPostprocessOptions{
+		transform:   "softmax",
+		temperature: 1,
+		topK:        2,
+	}
+
+	features, err := createClassificationFeatures(outputs, 2, labels, opts)
+	assert.NoError(t, err)
+	assert.Len(t, features, 2)
+	assert.Len(t, features[0], 2)
+	assert.Equal(t, "b", features[0][0].GetClassification().GetLabel())
+	assert.Equal(t, "c", features[0][1].GetClassification().GetLabel())
+	assert.Equal(t, int32(0), features[1][0].GetClassification().GetIndex())
+	for _, f := range features[1] {
+		assert.True(t, f.GetProbability() >= 0 && f.GetProbability() <= 1)
+	}
+
+	_, err = createClassificationFeatures(outputs, 4, labels, opts)
+	assert.Error(t, err)
+	_, err = createClassificationFeatures(outputs, 1, labels, opts)
+	assert.Error(t, err)
+}
@@ -0,0 +1,274 @@
+package predictor
+
+import (
+	"context"
+
+	"github.com/c3sr/config"
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/agent"
+	"github.com/c3sr/dlframework/framework/feature"
+	"github.com/c3sr/dlframework/framework/options"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/c3sr/pytorch"
+	"github.com/c3sr/tracer"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// TextQuestionAnsweringModality is the modality of the QuestionAnsweringPredictor.
+// dlframework reports the general modality for these models, and the GeneralPredictor
+// hands them over to the QuestionAnsweringPredictor based on their question_answering output type.
+const TextQuestionAnsweringModality dlframework.Modality = "text_question_answering"
+
+// QuestionAnsweringPredictor runs BERT-style extractive question answering models.
+// It takes QuestionAnsweringInput values, tokenizes them into the input_ids, input_mask and segment_ids
+// inputs of the model and extracts the answer spans from the start and end logits.
+type QuestionAnsweringPredictor struct {
+	common.Base
+	torchPredictor
+	tokenizer *WordPieceTokenizer
+	squad     squadOptions
+}
+
+// NewQuestionAnsweringPredictor ...
+func NewQuestionAnsweringPredictor(model dlframework.ModelManifest, os ...options.Option) (common.Predictor, error) {
+	opts := options.New(os...)
+	ctx := opts.Context()
+
+	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "new_predictor")
+	defer span.Finish()
+
+	if _, ok := textPredictor(model).(*QuestionAnsweringPredictor); !ok {
+		return nil, errors.New("output type not supported")
+	}
+
+	predictor := new(QuestionAnsweringPredictor)
+
+	return predictor.Load(ctx, model, os...)
+}
+
+// Download ...
+func (p *QuestionAnsweringPredictor) Download(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) error {
+	base, err := newTorchBase(model, opts...)
+	if err != nil {
+		return err
+	}
+
+	qp := &QuestionAnsweringPredictor{
+		Base: base,
+	}
+
+	return downloadModel(ctx, qp.Base, qp.modelFiles())
+}
+
+// Load ...
+func (p *QuestionAnsweringPredictor) Load(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) (common.Predictor, error) {
+	base, err := newTorchBase(model, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	qp := &QuestionAnsweringPredictor{
+		Base: base,
+	}
+
+	if err = qp.load(ctx, qp.Base, qp); err != nil {
+		return nil, err
+	}
+
+	return qp, nil
+}
+
+// GetVocabUrl returns the url of the WordPiece vocabulary of the model.
+func (p *QuestionAnsweringPredictor) GetVocabUrl() string {
+	return getVocabUrl(p.Base)
+}
+
+// GetVocabPath returns the path the WordPiece vocabulary is downloaded to.
+func (p *QuestionAnsweringPredictor) GetVocabPath() string {
+	return getVocabPath(p.Base)
+}
+
+func (p *QuestionAnsweringPredictor) modelFiles() []modelFile {
+	return []modelFile{vocabFile(p.Base)}
+}
+
+func (p *QuestionAnsweringPredictor) loadModelFiles(ctx context.Context) error {
+	p.squad = squadOptions{
+		maxSeqLength:    getInputIntParameter(p.Base, "max_seq_length", 384),
+		docStride:       getInputIntParameter(p.Base, "doc_stride", 128),
+		maxQueryLength:  getInputIntParameter(p.Base, "max_query_length", 64),
+		doLowerCase:     getInputBoolParameter(p.Base, "do_lower_case", true),
+		nBestSize:       getOutputIntParameter(p.Base, "n_best_size", 20),
+		maxAnswerLength: getOutputIntParameter(p.Base, "max_answer_length", 30),
+	}
+	if err := p.squad.validate(); err != nil {
+		return errors.Wrap(err, "invalid question answering parameters")
+	}
+
+	tokenizer, err := NewWordPieceTokenizerFromFile(p.GetVocabPath(), p.squad.doLowerCase)
+	if err != nil {
+		return err
+	}
+	p.tokenizer = tokenizer
+
+	return nil
+}
+
+// inputTensors tokenizes the questions and contexts into the input_ids, input_mask and segment_ids tensors.
+// Each tensor holds one row of max_seq_length tokens for every doc-stride window of every input.
+func (p *QuestionAnsweringPredictor) inputTensors(inputs []QuestionAnsweringInput) ([]gotensor.Tensor, []*squadExample, error) {
+	if len(inputs) == 0 {
+		return nil, nil, errors.New("no question answering inputs")
+	}
+
+	examples := make([]*squadExample, len(inputs))
+	var inputIDs, inputMask, segmentIDs []int64
+	numWindows := 0
+	for ii, input := range inputs {
+		example, err := newSquadExample(p.tokenizer, input, p.squad)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "cannot tokenize input %v", ii)
+		}
+		for _, window := range example.windows {
+			inputIDs = append(inputIDs, window.inputIDs...)
+			inputMask = append(inputMask, window.inputMask...)
+			segmentIDs = append(segmentIDs, window.segmentIDs...)
+		}
+		numWindows += len(example.windows)
+		examples[ii] = example
+	}
+
+	tensors := []gotensor.Tensor{
+		gotensor.New(gotensor.WithShape(numWindows, p.squad.maxSeqLength), gotensor.WithBacking(inputIDs)),
+		gotensor.New(gotensor.WithShape(numWindows, p.squad.maxSeqLength), gotensor.WithBacking(inputMask)),
+		gotensor.New(gotensor.WithShape(numWindows, p.squad.maxSeqLength), gotensor.WithBacking(segmentIDs)),
+	}
+
+	return tensors, examples, nil
+}
+
+// prepare takes a []QuestionAnsweringInput, or the input tensors of the model.
+// The answers can only be extracted from the outputs of question answering inputs.
+func (p *QuestionAnsweringPredictor) prepare(ctx context.Context, data interface{}, opts ...options.Option) ([]gotensor.Tensor, outputDecoder, error) {
+	switch in := data.(type) {
+	case []gotensor.Tensor:
+		return in, p.decodeOutputs, nil
+	case []QuestionAnsweringInput:
+		gotensors, examples, err := p.inputTensors(in)
+		if err != nil {
+			return nil, nil, err
+		}
+		return gotensors, func(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+			return p.decodeFeatures(ctx, outputs, examples)
+		}, nil
+	}
+	return nil, nil, errors.Errorf("input data of type %T is not supported, expecting question answering inputs or tensors", data)
+}
+
+func (p *QuestionAnsweringPredictor) decodeOutputs(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	return p.decodeFeatures(ctx, outputs, nil)
+}
+
+// decodeFeatures returns the n-best answers of each input, as text features.
+func (p *QuestionAnsweringPredictor) decodeFeatures(ctx context.Context, outputs []gotensor.Tensor, examples []*squadExample) ([]dlframework.Features, error) {
+	if examples == nil {
+		return nil, errors.New("answers can only be extracted when predicting from question answering inputs")
+	}
+
+	startLogits, endLogits, err := splitQuestionAnsweringLogits(outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return createQuestionAnsweringFeatures(examples, startLogits, endLogits, p.squad)
+}
+
+// splitQuestionAnsweringLogits returns the start and end logits, given either as two outputs
+// or as a single output whose last dimension stacks them.
+func splitQuestionAnsweringLogits(outputs []gotensor.Tensor) ([]float32, []float32, error) {
+	switch len(outputs) {
+	case 2:
+		startLogits, err := tensorToFloat32s(outputs[0])
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "cannot read the start logits output")
+		}
+		endLogits, err := tensorToFloat32s(outputs[1])
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "cannot read the end logits output")
+		}
+		return startLogits, endLogits, nil
+	case 1:
+		shape := outputs[0].Shape()
+		if len(shape) == 0 || shape[len(shape)-1] != 2 {
+			return nil, nil, errors.Errorf("expecting the logits output to end with a dimension of 2, got shape %v", shape)
+		}
+		logits, err := tensorToFloat32s(outputs[0])
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "cannot read the logits output")
+		}
+		startLogits := make([]float32, len(logits)/2)
+		endLogits := make([]float32, len(logits)/2)
+		for ii := range startLogits {
+			startLogits[ii] = logits[2*ii]
+			endLogits[ii] = logits[2*ii+1]
+		}
+		return startLogits, endLogits, nil
+	}
+	return nil, nil, errors.Errorf("expecting the start and end logits outputs, got %v outputs", len(outputs))
+}
+
+// createQuestionAnsweringFeatures splits the flat logits of all the windows by example
+// and returns the answers of each example.
+func createQuestionAnsweringFeatures(examples []*squadExample, startLogits, endLogits []float32, opts squadOptions) ([]dlframework.Features, error) {
+	numWindows := 0
+	for _, example := range examples {
+		numWindows += len(example.windows)
+	}
+	if len(startLogits) != numWindows*opts.maxSeqLength || len(endLogits) != numWindows*opts.maxSeqLength {
+		return nil, errors.Errorf("expecting %v logits for %v windows of %v tokens, got %v start and %v end logits",
+			numWindows*opts.maxSeqLength, numWindows, opts.maxSeqLength, len(startLogits), len(endLogits))
+	}
+
+	features := make([]dlframework.Features, len(examples))
+	offset := 0
+	for ii, example := range examples {
+		starts := make([][]float32, len(example.windows))
+		ends := make([][]float32, len(example.windows))
+		for jj := range example.windows {
+			starts[jj] = startLogits[offset : offset+opts.maxSeqLength]
+			ends[jj] = endLogits[offset : offset+opts.maxSeqLength]
+			offset += opts.maxSeqLength
+		}
+		answers, err := example.answers(starts, ends, opts)
+		if err != nil {
+			return nil, err
+		}
+		answerFeatures := make([]*dlframework.Feature, len(answers))
+		for jj, answer := range answers {
+			answerFeatures[jj] = feature.New(
+				feature.Text(&dlframework.Text{Data: []byte(answer.text)}),
+				feature.Probability(answer.probability),
+			)
+		}
+		features[ii] = answerFeatures
+	}
+
+	return features, nil
+}
+
+// Modality ...
+func (p *QuestionAnsweringPredictor) Modality() (dlframework.Modality, error) {
+	return TextQuestionAnsweringModality, nil
+}
+
+func init() {
+	config.AfterInit(func() {
+		framework := pytorch.FrameworkManifest
+		agent.AddPredictor(framework, &QuestionAnsweringPredictor{
+			Base: common.Base{
+				Framework: framework,
+			},
+		})
+	})
+}
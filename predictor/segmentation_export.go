@@ -0,0 +1,451 @@
+package predictor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"sort"
+	"strings"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/feature"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/pkg/errors"
+)
+
+// SegmentationExport is an artifact rendered from the segmentation masks of an image.
+// The artifacts follow the segmentation features of the image, and their metadata holds
+// the name of their export under the "export" key.
+type SegmentationExport string
+
+const (
+	// ExportMaskPNG renders the class mask as a PNG image colorized with the PASCAL VOC palette.
+	ExportMaskPNG SegmentationExport = "mask_png"
+	// ExportOverlayPNG blends the colorized mask over the source image, as a PNG image.
+	// It requires the images rather than the input tensors.
+	ExportOverlayPNG SegmentationExport = "overlay_png"
+	// ExportRLE encodes the mask of every class, or of every instance, with the compressed run-length
+	// encoding of COCO. It is a text feature holding the JSON list of the segments.
+	ExportRLE SegmentationExport = "rle"
+	// ExportPolygons traces the outer contours of the mask of every class, or of every instance, as COCO polygons.
+	// It is a text feature holding the JSON list of the segments.
+	ExportPolygons SegmentationExport = "polygons"
+)
+
+// segmentationExportKey is the metadata key holding the export of a rendered feature.
+const segmentationExportKey = "export"
+
+// backgroundClass is the class left out of the overlays and of the segments of the semantic segmentation.
+const backgroundClass = 0
+
+// segmentationExportOptions holds the artifacts rendered from the segmentation masks.
+type segmentationExportOptions struct {
+	exports []SegmentationExport
+	// overlayAlpha is the opacity of the colorized mask over the source image
+	overlayAlpha float32
+	// maskThreshold binarizes the float masks of the instances
+	maskThreshold float32
+}
+
+// segmentationExportOptionsFromManifest reads the exports, overlay_alpha and mask_threshold output parameters.
+func segmentationExportOptionsFromManifest(base common.Base) (segmentationExportOptions, error) {
+	res := segmentationExportOptions{
+		overlayAlpha:  getOutputFloat32Parameter(base, "overlay_alpha", 0.5),
+		maskThreshold: getOutputFloat32Parameter(base, "mask_threshold", 0.5),
+	}
+	var names []string
+	if _, err := getOutputListParameter(base, "exports", &names); err != nil {
+		return res, err
+	}
+	for _, name := range names {
+		res.exports = append(res.exports, SegmentationExport(strings.ToLower(strings.TrimSpace(name))))
+	}
+	return res, nil
+}
+
+// withOverrides returns the options overridden by the values set on the context
+// through SegmentationExports and OverlayAlpha.
+func (o segmentationExportOptions) withOverrides(ctx context.Context) segmentationExportOptions {
+	if ctx == nil {
+		return o
+	}
+	if val, ok := ctx.Value(segmentationExportsKey{}).([]SegmentationExport); ok {
+		o.exports = val
+	}
+	if val, ok := ctx.Value(overlayAlphaKey{}).(float32); ok {
+		o.overlayAlpha = val
+	}
+	return o
+}
+
+func (o segmentationExportOptions) validate() error {
+	for _, export := range o.exports {
+		switch export {
+		case ExportMaskPNG, ExportOverlayPNG, ExportRLE, ExportPolygons:
+		default:
+			return errors.Errorf("segmentation export %v is not supported", export)
+		}
+	}
+	if o.overlayAlpha < 0 || o.overlayAlpha > 1 {
+		return errors.Errorf("the overlay alpha must be between 0 and 1, but got %v", o.overlayAlpha)
+	}
+	return nil
+}
+
+// classMask is the flat HW class mask of an image.
+type classMask struct {
+	data          []int32
+	height, width int
+}
+
+// instanceMask is the flat HW float mask of a detected instance.
+type instanceMask struct {
+	class int32
+	score float32
+	data  []float32
+}
+
+// cocoSegment is a segment of the COCO results format, whose segmentation is either a cocoRLE
+// or a list of polygons given as flat x, y coordinates.
+type cocoSegment struct {
+	CategoryID   int32       `json:"category_id"`
+	Label        string      `json:"label,omitempty"`
+	Score        float32     `json:"score,omitempty"`
+	Segmentation interface{} `json:"segmentation"`
+}
+
+// cocoRLE is the compressed run-length encoding of a binary mask used by COCO.
+type cocoRLE struct {
+	// Size is the height and the width of the mask
+	Size   [2]int `json:"size"`
+	Counts string `json:"counts"`
+}
+
+// semanticSegmentExports renders the exports of the class mask of an image, whose geometry maps the mask
+// onto the source image. The source image is nil when the model was given the input tensors.
+func semanticSegmentExports(mask classMask, src *sourceImage, labels []string, opts segmentationExportOptions) ([]*dlframework.Feature, error) {
+	var res []*dlframework.Feature
+	for _, export := range opts.exports {
+		var f *dlframework.Feature
+		var err error
+		switch export {
+		case ExportMaskPNG:
+			f, err = imageExportFeature(export, colorizeMask(mask))
+		case ExportOverlayPNG:
+			if src == nil {
+				return nil, errors.Errorf("the %v export requires the images rather than the input tensors", export)
+			}
+			f, err = imageExportFeature(export, overlayMask(src.image, sourceMask(mask, src.geometry), opts.overlayAlpha))
+		case ExportRLE, ExportPolygons:
+			f, err = segmentsExportFeature(export, classSegments(export, mask, labels))
+		default:
+			err = errors.Errorf("segmentation export %v is not supported", export)
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, f)
+	}
+	return res, nil
+}
+
+// instanceSegmentExports renders the exports of the masks of the instances detected in an image, of height x width.
+// The instances are painted by decreasing score, over the ones of lower scores.
+func instanceSegmentExports(instances []instanceMask, height, width int, src *sourceImage, labels []string,
+	opts segmentationExportOptions) ([]*dlframework.Feature, error) {
+	sorted := make([]instanceMask, len(instances))
+	copy(sorted, instances)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].score > sorted[j].score })
+
+	var res []*dlframework.Feature
+	for _, export := range opts.exports {
+		var f *dlframework.Feature
+		var err error
+		switch export {
+		case ExportMaskPNG:
+			f, err = imageExportFeature(export, colorizeMask(instanceClassMask(sorted, height, width, opts.maskThreshold)))
+		case ExportOverlayPNG:
+			if src == nil {
+				return nil, errors.Errorf("the %v export requires the images rather than the input tensors", export)
+			}
+			mask := sourceMask(instanceClassMask(sorted, height, width, opts.maskThreshold), src.geometry)
+			f, err = imageExportFeature(export, overlayMask(src.image, mask, opts.overlayAlpha))
+		case ExportRLE, ExportPolygons:
+			segments := make([]cocoSegment, len(sorted))
+			for ii, instance := range sorted {
+				binary := make([]bool, len(instance.data))
+				for jj, v := range instance.data {
+					binary[jj] = v >= opts.maskThreshold
+				}
+				segments[ii] = cocoSegment{
+					CategoryID:   instance.class,
+					Label:        labelOf(labels, instance.class),
+					Score:        instance.score,
+					Segmentation: binarySegmentation(export, binary, height, width),
+				}
+			}
+			f, err = segmentsExportFeature(export, segments)
+		default:
+			err = errors.Errorf("segmentation export %v is not supported", export)
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, f)
+	}
+	return res, nil
+}
+
+// instanceClassMask paints the class of the sorted instances onto a class mask, the first instance
+// covering a pixel winning it. The pixels of no instance are the background.
+func instanceClassMask(sorted []instanceMask, height, width int, threshold float32) classMask {
+	mask := classMask{data: make([]int32, height*width), height: height, width: width}
+	painted := make([]bool, height*width)
+	for _, instance := range sorted {
+		for ii, v := range instance.data {
+			if !painted[ii] && v >= threshold {
+				mask.data[ii] = instance.class
+				painted[ii] = true
+			}
+		}
+	}
+	return mask
+}
+
+// classSegments returns the segment of every class of the mask but the background, by increasing class.
+func classSegments(export SegmentationExport, mask classMask, labels []string) []cocoSegment {
+	present := map[int32]bool{}
+	for _, class := range mask.data {
+		present[class] = true
+	}
+	classes := make([]int32, 0, len(present))
+	for class := range present {
+		if class != backgroundClass {
+			classes = append(classes, class)
+		}
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i] < classes[j] })
+
+	segments := make([]cocoSegment, len(classes))
+	binary := make([]bool, len(mask.data))
+	for ii, class := range classes {
+		for jj, v := range mask.data {
+			binary[jj] = v == class
+		}
+		segments[ii] = cocoSegment{
+			CategoryID:   class,
+			Label:        labelOf(labels, class),
+			Segmentation: binarySegmentation(export, binary, mask.height, mask.width),
+		}
+	}
+	return segments
+}
+
+func binarySegmentation(export SegmentationExport, binary []bool, height, width int) interface{} {
+	if export == ExportRLE {
+		return encodeRLE(binary, height, width)
+	}
+	return maskPolygons(binary, height, width)
+}
+
+// labelOf returns the label of the class, or an empty string when the model has no such label.
+func labelOf(labels []string, class int32) string {
+	if class < 0 || int(class) >= len(labels) {
+		return ""
+	}
+	return labels[class]
+}
+
+// paletteColor returns the color of the class in the PASCAL VOC palette, the background being black.
+func paletteColor(class int32) color.RGBA {
+	var r, g, b uint8
+	c := uint32(class)
+	for shift := 7; shift >= 0 && c != 0; shift-- {
+		r |= uint8(c&1) << uint(shift)
+		g |= uint8(c>>1&1) << uint(shift)
+		b |= uint8(c>>2&1) << uint(shift)
+		c >>= 3
+	}
+	return color.RGBA{R: r, G: g, B: b, A: 255}
+}
+
+// colorizeMask paints the classes of the mask with their palette colors.
+func colorizeMask(mask classMask) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, mask.width, mask.height))
+	for ii, class := range mask.data {
+		c := paletteColor(class)
+		copy(img.Pix[ii*4:ii*4+4], []uint8{c.R, c.G, c.B, c.A})
+	}
+	return img
+}
+
+// sourceMask maps the class mask onto its source image.
+func sourceMask(mask classMask, g imageGeometry) classMask {
+	return classMask{data: resizeMask(mask.data, mask.height, mask.width, g), height: g.srcHeight, width: g.srcWidth}
+}
+
+// overlayMask blends the palette colors of the classes over the source image, leaving the background untouched.
+// The mask has the size of the image.
+func overlayMask(src image.Image, mask classMask, alpha float32) *image.RGBA {
+	pix, width, height := rgbPixels(src)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for ii := 0; ii < width*height; ii++ {
+		out := img.Pix[ii*4 : ii*4+4]
+		copy(out, pix[ii*3:ii*3+3])
+		out[3] = 255
+		if class := mask.data[ii]; class != backgroundClass {
+			c := paletteColor(class)
+			for jj, v := range []uint8{c.R, c.G, c.B} {
+				out[jj] = uint8(float32(out[jj])*(1-alpha) + float32(v)*alpha + 0.5)
+			}
+		}
+	}
+	return img
+}
+
+// encodeRLE encodes the binary mask with the compressed run-length encoding of COCO. The runs follow the
+// columns of the mask and alternate between the pixels outside and inside the mask, starting outside.
+func encodeRLE(binary []bool, height, width int) cocoRLE {
+	var counts []int64
+	var run int64
+	inside := false
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			if binary[y*width+x] != inside {
+				counts = append(counts, run)
+				run = 0
+				inside = !inside
+			}
+			run++
+		}
+	}
+	counts = append(counts, run)
+
+	// the counts are stored as the difference with the count two runs before, in 5 bit groups
+	var buf []byte
+	for ii, count := range counts {
+		x := count
+		if ii > 2 {
+			x -= counts[ii-2]
+		}
+		for more := true; more; {
+			c := byte(x & 0x1f)
+			x >>= 5
+			if c&0x10 != 0 {
+				more = x != -1
+			} else {
+				more = x != 0
+			}
+			if more {
+				c |= 0x20
+			}
+			buf = append(buf, c+48)
+		}
+	}
+	return cocoRLE{Size: [2]int{height, width}, Counts: string(buf)}
+}
+
+// contourSteps are the moves along the pixel edges, clockwise from east, with the pixels at the right
+// and at the left of the edge leaving the corner x, y.
+var contourSteps = [4]struct {
+	dx, dy                       int
+	rightX, rightY, leftX, leftY int
+}{
+	{dx: 1, dy: 0, rightX: 0, rightY: 0, leftX: 0, leftY: -1},
+	{dx: 0, dy: 1, rightX: -1, rightY: 0, leftX: 0, leftY: 0},
+	{dx: -1, dy: 0, rightX: -1, rightY: -1, leftX: -1, leftY: 0},
+	{dx: 0, dy: -1, rightX: 0, rightY: -1, leftX: -1, leftY: -1},
+}
+
+// maskPolygons traces the outer contour of every 4-connected component of the binary mask. The polygons
+// run clockwise along the pixel edges, as flat x, y coordinates of their corners.
+func maskPolygons(binary []bool, height, width int) [][]float32 {
+	components := make([]int32, len(binary))
+	var polygons [][]float32
+	var stack []int
+	for start, in := range binary {
+		if !in || components[start] != 0 {
+			continue
+		}
+		label := int32(len(polygons) + 1)
+		components[start] = label
+		stack = append(stack[:0], start)
+		for len(stack) != 0 {
+			ii := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			x, y := ii%width, ii/width
+			for _, n := range [4][2]int{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}} {
+				if n[0] < 0 || n[1] < 0 || n[0] >= width || n[1] >= height {
+					continue
+				}
+				if jj := n[1]*width + n[0]; binary[jj] && components[jj] == 0 {
+					components[jj] = label
+					stack = append(stack, jj)
+				}
+			}
+		}
+		// the first pixel of a component in raster order is its top left one
+		polygons = append(polygons, traceContour(components, label, height, width, start%width, start/width))
+	}
+	return polygons
+}
+
+// traceContour follows the edges of the component from the top left corner of its first pixel,
+// keeping the component at the right, and returns the corners where the contour turns.
+func traceContour(components []int32, label int32, height, width, x0, y0 int) []float32 {
+	inside := func(x, y int) bool {
+		return x >= 0 && y >= 0 && x < width && y < height && components[y*width+x] == label
+	}
+	boundary := func(x, y, dir int) bool {
+		s := contourSteps[dir]
+		return inside(x+s.rightX, y+s.rightY) && !inside(x+s.leftX, y+s.leftY)
+	}
+
+	var polygon []float32
+	x, y, dir := x0, y0, 0
+	for {
+		// turn right rather than go straight or left, so that diagonal pixels stay apart
+		next := dir
+		for _, turn := range []int{1, 0, 3, 2} {
+			if d := (dir + turn) % 4; boundary(x, y, d) {
+				next = d
+				break
+			}
+		}
+		if next != dir || len(polygon) == 0 {
+			polygon = append(polygon, float32(x), float32(y))
+		}
+		dir = next
+		x, y = x+contourSteps[dir].dx, y+contourSteps[dir].dy
+		if x == x0 && y == y0 {
+			return polygon
+		}
+	}
+}
+
+func imageExportFeature(export SegmentationExport, img image.Image) (*dlframework.Feature, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, errors.Wrapf(err, "cannot encode the %v export", export)
+	}
+	return feature.New(
+		feature.ImageType(),
+		feature.ImageData(buf.Bytes()),
+		feature.AppendMetadata(segmentationExportKey, string(export)),
+	), nil
+}
+
+func segmentsExportFeature(export SegmentationExport, segments []cocoSegment) (*dlframework.Feature, error) {
+	data, err := json.Marshal(segments)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot encode the %v export", export)
+	}
+	return feature.New(
+		feature.TextType(),
+		feature.TextData(data),
+		feature.AppendMetadata(segmentationExportKey, string(export)),
+	), nil
+}
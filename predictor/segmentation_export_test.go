@@ -0,0 +1,214 @@
+package predictor
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/c3sr/dlframework"
+	"github.com/stretchr/testify/assert"
+)
+
+// decodeRLE decodes the compressed COCO run-length encoding the way pycocotools does.
+func decodeRLE(rle cocoRLE) []bool {
+	var counts []int64
+	s := []byte(rle.Counts)
+	for p := 0; p < len(s); {
+		var x int64
+		k := uint(0)
+		more := true
+		for more {
+			c := int64(s[p]) - 48
+			x |= (c & 0x1f) << (5 * k)
+			more = c&0x20 != 0
+			p++
+			k++
+			if !more && c&0x10 != 0 {
+				x |= -1 << (5 * k)
+			}
+		}
+		if len(counts) > 2 {
+			x += counts[len(counts)-2]
+		}
+		counts = append(counts, x)
+	}
+
+	height, width := rle.Size[0], rle.Size[1]
+	binary := make([]bool, height*width)
+	ii, inside := 0, false
+	for _, count := range counts {
+		for ; count > 0; count-- {
+			binary[(ii%height)*width+ii/height] = inside
+			ii++
+		}
+		inside = !inside
+	}
+	return binary
+}
+
+func TestPaletteColor(t *testing.T) {
+	assert.Equal(t, color.RGBA{A: 255}, paletteColor(0))
+	assert.Equal(t, color.RGBA{R: 128, A: 255}, paletteColor(1))
+	assert.Equal(t, color.RGBA{G: 128, A: 255}, paletteColor(2))
+	assert.Equal(t, color.RGBA{R: 128, G: 128, A: 255}, paletteColor(3))
+	// the person of PASCAL VOC
+	assert.Equal(t, color.RGBA{R: 192, G: 128, B: 128, A: 255}, paletteColor(15))
+}
+
+func TestEncodeRLE(t *testing.T) {
+	// the runs follow the columns
+	assert.Equal(t, cocoRLE{Size: [2]int{2, 2}, Counts: "13"}, encodeRLE([]bool{false, true, true, true}, 2, 2))
+	assert.Equal(t, "T3", encodeRLE(make([]bool, 100), 10, 10).Counts)
+	assert.Equal(t, "11100", encodeRLE([]bool{false, true, false, true, false}, 5, 1).Counts)
+	assert.Equal(t, "131N", encodeRLE([]bool{false, true, true, true, false, true}, 6, 1).Counts)
+
+	rng := rand.New(rand.NewSource(1))
+	binary := make([]bool, 37*53)
+	for ii := range binary {
+		// long runs, to exercise the differences with the previous runs
+		binary[ii] = rng.Intn(20) == 0 != (ii > 0 && binary[ii-1])
+	}
+	assert.Equal(t, binary, decodeRLE(encodeRLE(binary, 37, 53)))
+}
+
+func TestMaskPolygons(t *testing.T) {
+	assert.Equal(t, [][]float32{{0, 0, 1, 0, 1, 1, 0, 1}}, maskPolygons([]bool{true}, 1, 1))
+
+	assert.Equal(t, [][]float32{{1, 0, 3, 0, 3, 2, 1, 2}}, maskPolygons([]bool{
+		false, true, true, false,
+		false, true, true, false,
+	}, 2, 4))
+
+	// an L and the diagonal pixels, which are not 4-connected
+	assert.Equal(t, [][]float32{
+		{0, 0, 1, 0, 1, 1, 2, 1, 2, 2, 0, 2},
+		{3, 0, 4, 0, 4, 1, 3, 1},
+		{2, 2, 3, 2, 3, 3, 2, 3},
+	}, maskPolygons([]bool{
+		true, false, false, true,
+		true, true, false, false,
+		false, false, true, false,
+	}, 3, 4))
+
+	// a ring only has its outer contour
+	assert.Equal(t, [][]float32{{0, 0, 3, 0, 3, 3, 0, 3}}, maskPolygons([]bool{
+		true, true, true,
+		true, false, true,
+		true, true, true,
+	}, 3, 3))
+}
+
+func exportsByName(t *testing.T, features []*dlframework.Feature) map[string]*dlframework.Feature {
+	res := map[string]*dlframework.Feature{}
+	for _, f := range features {
+		res[f.GetMetadata()[segmentationExportKey]] = f
+	}
+	return res
+}
+
+func decodePNG(t *testing.T, f *dlframework.Feature) image.Image {
+	img, err := png.Decode(bytes.NewReader(f.GetImage().GetData()))
+	assert.NoError(t, err)
+	return img
+}
+
+func TestSemanticSegmentExports(t *testing.T) {
+	mask := classMask{data: []int32{0, 1, 2, 1}, height: 2, width: 2}
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for ii := range src.Pix {
+		src.Pix[ii] = 100
+	}
+	opts := segmentationExportOptions{
+		exports:      []SegmentationExport{ExportMaskPNG, ExportOverlayPNG, ExportRLE, ExportPolygons},
+		overlayAlpha: 0.5,
+	}
+
+	features, err := semanticSegmentExports(mask, &sourceImage{image: src, geometry: identityGeometry(2, 2)}, []string{"background", "plane", "bike"}, opts)
+	assert.NoError(t, err)
+	exports := exportsByName(t, features)
+	assert.Len(t, exports, 4)
+
+	colorized := decodePNG(t, exports["mask_png"])
+	assert.Equal(t, color.RGBA{A: 255}, color.RGBAModel.Convert(colorized.At(0, 0)))
+	assert.Equal(t, color.RGBA{R: 128, A: 255}, color.RGBAModel.Convert(colorized.At(1, 0)))
+	assert.Equal(t, color.RGBA{G: 128, A: 255}, color.RGBAModel.Convert(colorized.At(0, 1)))
+
+	// the background keeps the source pixels
+	overlay := decodePNG(t, exports["overlay_png"])
+	assert.Equal(t, color.RGBA{R: 100, G: 100, B: 100, A: 255}, color.RGBAModel.Convert(overlay.At(0, 0)))
+	assert.Equal(t, color.RGBA{R: 114, G: 50, B: 50, A: 255}, color.RGBAModel.Convert(overlay.At(1, 0)))
+
+	var rles []struct {
+		CategoryID   int32   `json:"category_id"`
+		Label        string  `json:"label"`
+		Segmentation cocoRLE `json:"segmentation"`
+	}
+	assert.NoError(t, json.Unmarshal(exports["rle"].GetText().GetData(), &rles))
+	if assert.Len(t, rles, 2) {
+		assert.Equal(t, int32(1), rles[0].CategoryID)
+		assert.Equal(t, "plane", rles[0].Label)
+		assert.Equal(t, []bool{false, true, false, true}, decodeRLE(rles[0].Segmentation))
+		assert.Equal(t, "bike", rles[1].Label)
+	}
+
+	var polygons []struct {
+		CategoryID   int32       `json:"category_id"`
+		Segmentation [][]float32 `json:"segmentation"`
+	}
+	assert.NoError(t, json.Unmarshal(exports["polygons"].GetText().GetData(), &polygons))
+	if assert.Len(t, polygons, 2) {
+		assert.Equal(t, [][]float32{{1, 0, 2, 0, 2, 2, 1, 2}}, polygons[0].Segmentation)
+		assert.Equal(t, [][]float32{{0, 1, 1, 1, 1, 2, 0, 2}}, polygons[1].Segmentation)
+	}
+
+	// the overlay requires the source image
+	_, err = semanticSegmentExports(mask, nil, nil, opts)
+	assert.Error(t, err)
+	features, err = semanticSegmentExports(mask, nil, nil, segmentationExportOptions{exports: []SegmentationExport{ExportRLE}})
+	assert.NoError(t, err)
+	assert.Len(t, features, 1)
+
+	assert.Error(t, segmentationExportOptions{exports: []SegmentationExport{"jpeg"}}.validate())
+	assert.Error(t, segmentationExportOptions{overlayAlpha: 2}.validate())
+	assert.NoError(t, opts.validate())
+}
+
+func TestInstanceSegmentExports(t *testing.T) {
+	// the instances overlap on the second pixel, which the instance of the highest score wins
+	instances := []instanceMask{
+		{class: 3, score: 0.5, data: []float32{0.9, 0.9, 0, 0}},
+		{class: 1, score: 0.8, data: []float32{0, 0.6, 0.7, 0.1}},
+	}
+	opts := segmentationExportOptions{
+		exports:       []SegmentationExport{ExportMaskPNG, ExportRLE},
+		maskThreshold: 0.5,
+	}
+
+	features, err := instanceSegmentExports(instances, 1, 4, nil, []string{"background", "person", "bicycle", "car"}, opts)
+	assert.NoError(t, err)
+	exports := exportsByName(t, features)
+
+	colorized := decodePNG(t, exports["mask_png"])
+	for x, class := range []int32{3, 1, 1, 0} {
+		assert.Equal(t, paletteColor(class), color.RGBAModel.Convert(colorized.At(x, 0)), "pixel %v", x)
+	}
+
+	var rles []struct {
+		CategoryID   int32   `json:"category_id"`
+		Label        string  `json:"label"`
+		Score        float32 `json:"score"`
+		Segmentation cocoRLE `json:"segmentation"`
+	}
+	assert.NoError(t, json.Unmarshal(exports["rle"].GetText().GetData(), &rles))
+	if assert.Len(t, rles, 2) {
+		assert.Equal(t, "person", rles[0].Label)
+		assert.Equal(t, float32(0.8), rles[0].Score)
+		assert.Equal(t, []bool{false, true, true, false}, decodeRLE(rles[0].Segmentation))
+		assert.Equal(t, "car", rles[1].Label)
+		assert.Equal(t, []bool{true, true, false, false}, decodeRLE(rles[1].Segmentation))
+	}
+}
@@ -0,0 +1,369 @@
+package predictor
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// QuestionAnsweringInput is a question about a context passage, as taken by the QuestionAnsweringPredictor.
+type QuestionAnsweringInput struct {
+	Question string
+	Context  string
+}
+
+// squadOptions are the SQuAD feature and answer-span extraction parameters.
+type squadOptions struct {
+	maxSeqLength    int
+	docStride       int
+	maxQueryLength  int
+	nBestSize       int
+	maxAnswerLength int
+	doLowerCase     bool
+}
+
+func (o squadOptions) validate() error {
+	if o.maxSeqLength <= 0 || o.docStride <= 0 || o.maxQueryLength <= 0 {
+		return errors.New("max_seq_length, doc_stride and max_query_length must be positive")
+	}
+	// the -3 accounts for [CLS], [SEP] and [SEP]
+	if o.maxSeqLength-o.maxQueryLength-3 <= 0 {
+		return errors.Errorf("max_seq_length %v leaves no room for the context after a query of %v tokens", o.maxSeqLength, o.maxQueryLength)
+	}
+	if o.nBestSize <= 0 || o.maxAnswerLength <= 0 {
+		return errors.New("n_best_size and max_answer_length must be positive")
+	}
+	return nil
+}
+
+// squadWindow is one doc-stride window over the context, packed with the question into a model input.
+type squadWindow struct {
+	tokens            []string
+	tokenToOrigIndex  map[int]int
+	tokenIsMaxContext map[int]bool
+	inputIDs          []int64
+	inputMask         []int64
+	segmentIDs        []int64
+}
+
+// squadExample is a question and its whitespace-split context, along with its model windows.
+type squadExample struct {
+	docTokens []string
+	windows   []squadWindow
+}
+
+// squadAnswer is a candidate answer span.
+type squadAnswer struct {
+	text        string
+	startLogit  float32
+	endLogit    float32
+	probability float32
+}
+
+type squadDocSpan struct {
+	start  int
+	length int
+}
+
+// newSquadExample tokenizes the question and context and splits the context into windows of
+// maxSeqLength tokens that overlap by docStride tokens, following the BERT SQuAD reference.
+func newSquadExample(tokenizer *WordPieceTokenizer, input QuestionAnsweringInput, opts squadOptions) (*squadExample, error) {
+	queryTokens := tokenizer.Tokenize(input.Question)
+	if len(queryTokens) > opts.maxQueryLength {
+		queryTokens = queryTokens[:opts.maxQueryLength]
+	}
+
+	docTokens := strings.FieldsFunc(input.Context, isSquadWhitespace)
+	if len(docTokens) == 0 {
+		return nil, errors.New("the context is empty")
+	}
+
+	var tokToOrigIndex []int
+	var allDocTokens []string
+	for ii, token := range docTokens {
+		for _, subToken := range tokenizer.Tokenize(token) {
+			tokToOrigIndex = append(tokToOrigIndex, ii)
+			allDocTokens = append(allDocTokens, subToken)
+		}
+	}
+
+	maxTokensForDoc := opts.maxSeqLength - len(queryTokens) - 3
+	var docSpans []squadDocSpan
+	for startOffset := 0; startOffset < len(allDocTokens); {
+		length := len(allDocTokens) - startOffset
+		if length > maxTokensForDoc {
+			length = maxTokensForDoc
+		}
+		docSpans = append(docSpans, squadDocSpan{start: startOffset, length: length})
+		if startOffset+length == len(allDocTokens) {
+			break
+		}
+		if length < opts.docStride {
+			startOffset += length
+		} else {
+			startOffset += opts.docStride
+		}
+	}
+
+	windows := make([]squadWindow, len(docSpans))
+	for spanIndex, span := range docSpans {
+		tokens := make([]string, 0, opts.maxSeqLength)
+		segmentIDs := make([]int64, 0, opts.maxSeqLength)
+		tokenToOrigIndex := make(map[int]int, span.length)
+		tokenIsMaxContext := make(map[int]bool, span.length)
+
+		tokens = append(tokens, bertClassifyToken)
+		segmentIDs = append(segmentIDs, 0)
+		for _, token := range queryTokens {
+			tokens = append(tokens, token)
+			segmentIDs = append(segmentIDs, 0)
+		}
+		tokens = append(tokens, bertSeparatorToken)
+		segmentIDs = append(segmentIDs, 0)
+
+		for ii := 0; ii < span.length; ii++ {
+			splitTokenIndex := span.start + ii
+			tokenToOrigIndex[len(tokens)] = tokToOrigIndex[splitTokenIndex]
+			tokenIsMaxContext[len(tokens)] = isMaxContext(docSpans, spanIndex, splitTokenIndex)
+			tokens = append(tokens, allDocTokens[splitTokenIndex])
+			segmentIDs = append(segmentIDs, 1)
+		}
+		tokens = append(tokens, bertSeparatorToken)
+		segmentIDs = append(segmentIDs, 1)
+
+		inputIDs := tokenizer.ConvertTokensToIDs(tokens)
+		inputMask := make([]int64, len(inputIDs), opts.maxSeqLength)
+		for ii := range inputMask {
+			inputMask[ii] = 1
+		}
+		for len(inputIDs) < opts.maxSeqLength {
+			inputIDs = append(inputIDs, 0)
+			inputMask = append(inputMask, 0)
+			segmentIDs = append(segmentIDs, 0)
+		}
+
+		windows[spanIndex] = squadWindow{
+			tokens:            tokens,
+			tokenToOrigIndex:  tokenToOrigIndex,
+			tokenIsMaxContext: tokenIsMaxContext,
+			inputIDs:          inputIDs,
+			inputMask:         inputMask,
+			segmentIDs:        segmentIDs,
+		}
+	}
+
+	return &squadExample{
+		docTokens: docTokens,
+		windows:   windows,
+	}, nil
+}
+
+// isMaxContext reports whether the window has the most context around the token,
+// the context being the minimum of the tokens on its left and on its right.
+func isMaxContext(docSpans []squadDocSpan, spanIndex, position int) bool {
+	bestScore := 0.0
+	bestSpanIndex := -1
+	for ii, span := range docSpans {
+		end := span.start + span.length - 1
+		if position < span.start || position > end {
+			continue
+		}
+		left := position - span.start
+		right := end - position
+		score := math.Min(float64(left), float64(right)) + 0.01*float64(span.length)
+		if bestSpanIndex == -1 || score > bestScore {
+			bestScore = score
+			bestSpanIndex = ii
+		}
+	}
+	return spanIndex == bestSpanIndex
+}
+
+type squadPrelimAnswer struct {
+	window     int
+	start      int
+	end        int
+	startLogit float32
+	endLogit   float32
+}
+
+// answers extracts the n-best answer spans from the start and end logits of each window.
+// The probabilities are the softmax of the span scores over the n-best answers.
+func (e *squadExample) answers(startLogits, endLogits [][]float32, opts squadOptions) ([]squadAnswer, error) {
+	if len(startLogits) != len(e.windows) || len(endLogits) != len(e.windows) {
+		return nil, errors.Errorf("expecting logits for %v windows, got %v start and %v end logits", len(e.windows), len(startLogits), len(endLogits))
+	}
+
+	var prelim []squadPrelimAnswer
+	for ii, window := range e.windows {
+		startIndexes := topIndexes(startLogits[ii], opts.nBestSize)
+		endIndexes := topIndexes(endLogits[ii], opts.nBestSize)
+		for _, start := range startIndexes {
+			for _, end := range endIndexes {
+				if start >= len(window.tokens) || end >= len(window.tokens) {
+					continue
+				}
+				if _, ok := window.tokenToOrigIndex[start]; !ok {
+					continue
+				}
+				if _, ok := window.tokenToOrigIndex[end]; !ok {
+					continue
+				}
+				if !window.tokenIsMaxContext[start] {
+					continue
+				}
+				if end < start || end-start+1 > opts.maxAnswerLength {
+					continue
+				}
+				prelim = append(prelim, squadPrelimAnswer{
+					window:     ii,
+					start:      start,
+					end:        end,
+					startLogit: startLogits[ii][start],
+					endLogit:   endLogits[ii][end],
+				})
+			}
+		}
+	}
+	sort.SliceStable(prelim, func(i, j int) bool {
+		return prelim[i].startLogit+prelim[i].endLogit > prelim[j].startLogit+prelim[j].endLogit
+	})
+
+	var res []squadAnswer
+	seen := make(map[string]bool)
+	for _, pred := range prelim {
+		if len(res) >= opts.nBestSize {
+			break
+		}
+		window := e.windows[pred.window]
+		tokText := strings.Join(window.tokens[pred.start:pred.end+1], " ")
+		tokText = strings.Replace(tokText, " "+wordPiecePrefix, "", -1)
+		tokText = strings.Replace(tokText, wordPiecePrefix, "", -1)
+		tokText = strings.Join(strings.Fields(tokText), " ")
+
+		origStart := window.tokenToOrigIndex[pred.start]
+		origEnd := window.tokenToOrigIndex[pred.end]
+		origText := strings.Join(e.docTokens[origStart:origEnd+1], " ")
+
+		text := finalAnswerText(tokText, origText, opts.doLowerCase)
+		if seen[text] {
+			continue
+		}
+		seen[text] = true
+		res = append(res, squadAnswer{
+			text:       text,
+			startLogit: pred.startLogit,
+			endLogit:   pred.endLogit,
+		})
+	}
+	if len(res) == 0 {
+		res = append(res, squadAnswer{text: "empty"})
+	}
+
+	maxScore := res[0].startLogit + res[0].endLogit
+	var sum float64
+	for _, answer := range res {
+		sum += math.Exp(float64(answer.startLogit + answer.endLogit - maxScore))
+	}
+	for ii, answer := range res {
+		res[ii].probability = float32(math.Exp(float64(answer.startLogit+answer.endLogit-maxScore)) / sum)
+	}
+
+	return res, nil
+}
+
+// finalAnswerText projects the normalized predicted text back onto the original context text,
+// so that the answer keeps the casing and punctuation of the context. The original text is returned
+// when the projection fails.
+func finalAnswerText(predText, origText string, doLowerCase bool) string {
+	tokText := []rune(strings.Join(basicTokenize(origText, doLowerCase), " "))
+	pred := []rune(predText)
+	orig := []rune(origText)
+
+	startPosition := runeIndex(tokText, pred)
+	if startPosition == -1 {
+		return origText
+	}
+	endPosition := startPosition + len(pred) - 1
+
+	origNSText, origNSToS := stripSpaces(orig)
+	tokNSText, tokNSToS := stripSpaces(tokText)
+	if len(origNSText) != len(tokNSText) {
+		return origText
+	}
+	tokSToNS := make(map[int]int, len(tokNSToS))
+	for ns, s := range tokNSToS {
+		tokSToNS[s] = ns
+	}
+
+	project := func(position int) (int, bool) {
+		ns, ok := tokSToNS[position]
+		if !ok {
+			return 0, false
+		}
+		if ns >= len(origNSToS) {
+			return 0, false
+		}
+		return origNSToS[ns], true
+	}
+	origStart, ok := project(startPosition)
+	if !ok {
+		return origText
+	}
+	origEnd, ok := project(endPosition)
+	if !ok {
+		return origText
+	}
+	return string(orig[origStart : origEnd+1])
+}
+
+// stripSpaces removes the spaces of the text and returns the index of each remaining rune in the text.
+func stripSpaces(text []rune) ([]rune, []int) {
+	var ns []rune
+	var nsToS []int
+	for ii, r := range text {
+		if r == ' ' {
+			continue
+		}
+		nsToS = append(nsToS, ii)
+		ns = append(ns, r)
+	}
+	return ns, nsToS
+}
+
+func runeIndex(s, substr []rune) int {
+	for ii := 0; ii+len(substr) <= len(s); ii++ {
+		match := true
+		for jj, r := range substr {
+			if s[ii+jj] != r {
+				match = false
+				break
+			}
+		}
+		if match {
+			return ii
+		}
+	}
+	return -1
+}
+
+// topIndexes returns the indexes of the n largest values, largest first.
+func topIndexes(values []float32, n int) []int {
+	indexes := make([]int, len(values))
+	for ii := range indexes {
+		indexes[ii] = ii
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return values[indexes[i]] > values[indexes[j]]
+	})
+	if len(indexes) > n {
+		indexes = indexes[:n]
+	}
+	return indexes
+}
+
+func isSquadWhitespace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\r' || r == '\n' || r == 0x202F
+}
@@ -0,0 +1,101 @@
+package predictor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSquadOptions() squadOptions {
+	return squadOptions{
+		maxSeqLength:    12,
+		docStride:       3,
+		maxQueryLength:  4,
+		nBestSize:       5,
+		maxAnswerLength: 4,
+		doLowerCase:     true,
+	}
+}
+
+func TestNewSquadExample(t *testing.T) {
+	tokenizer := testWordPieceTokenizer(t, true)
+	opts := testSquadOptions()
+	assert.NoError(t, opts.validate())
+
+	example, err := newSquadExample(tokenizer, QuestionAnsweringInput{
+		Question: "Who was the leader?",
+		Context:  "The leader was John Smith (1895-1943).",
+	}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"The", "leader", "was", "John", "Smith", "(1895-1943)."}, example.docTokens)
+
+	// 11 context tokens in windows of 12 - 4 - 3 = 5 tokens with a stride of 3
+	assert.Len(t, example.windows, 3)
+	window := example.windows[0]
+	assert.Equal(t, []string{"[CLS]", "who", "was", "the", "leader", "[SEP]", "the", "leader", "was", "john", "smith", "[SEP]"}, window.tokens)
+	assert.Equal(t, []int64{2, 18, 27, 4, 28, 3, 4, 28, 27, 19, 20, 3}, window.inputIDs)
+	assert.Equal(t, []int64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, window.inputMask)
+	assert.Equal(t, []int64{0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1}, window.segmentIDs)
+	assert.Equal(t, map[int]int{6: 0, 7: 1, 8: 2, 9: 3, 10: 4}, window.tokenToOrigIndex)
+
+	// "smith" has more context in the second window
+	assert.False(t, window.tokenIsMaxContext[10])
+	assert.True(t, example.windows[1].tokenIsMaxContext[7])
+	assert.Equal(t, []string{"1895", "-", "1943", ")", "."}, example.windows[2].tokens[6:11])
+
+	// short windows are padded
+	opts.maxSeqLength = 20
+	example, err = newSquadExample(tokenizer, QuestionAnsweringInput{Question: "Who?", Context: "John Smith"}, opts)
+	assert.NoError(t, err)
+	assert.Len(t, example.windows, 1)
+	assert.Equal(t, []int64{2, 18, 14, 3, 19, 20, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, example.windows[0].inputIDs)
+	assert.Equal(t, []int64{1, 1, 1, 1, 1, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, example.windows[0].inputMask)
+
+	_, err = newSquadExample(tokenizer, QuestionAnsweringInput{Question: "Who?", Context: " "}, opts)
+	assert.Error(t, err)
+
+	opts.maxQueryLength = 17
+	assert.Error(t, opts.validate())
+}
+
+func TestSquadExampleAnswers(t *testing.T) {
+	tokenizer := testWordPieceTokenizer(t, true)
+	opts := testSquadOptions()
+	opts.maxSeqLength = 20
+	example, err := newSquadExample(tokenizer, QuestionAnsweringInput{
+		Question: "When was John Smith born?",
+		Context:  "The leader was John Smith (1895-1943).",
+	}, opts)
+	assert.NoError(t, err)
+	assert.Len(t, example.windows, 1)
+	assert.Equal(t, "1895", example.windows[0].tokens[12])
+
+	startLogits := make([]float32, opts.maxSeqLength)
+	endLogits := make([]float32, opts.maxSeqLength)
+	startLogits[12] = 5
+	endLogits[12] = 5
+	startLogits[9] = 2
+	endLogits[10] = 2
+	// a span in the question is never an answer
+	startLogits[1] = 10
+	endLogits[1] = 10
+	answers, err := example.answers([][]float32{startLogits}, [][]float32{endLogits}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "1895", answers[0].text)
+	assert.True(t, answers[0].probability > answers[1].probability)
+
+	var sum float32
+	for _, answer := range answers {
+		sum += answer.probability
+	}
+	assert.InDelta(t, 1, sum, 1e-5)
+
+	_, err = example.answers(nil, nil, opts)
+	assert.Error(t, err)
+}
+
+func TestFinalAnswerText(t *testing.T) {
+	assert.Equal(t, "Steve Smith", finalAnswerText("steve smith", "Steve Smith's", true))
+	assert.Equal(t, "1895", finalAnswerText("1895", "(1895-1943).", true))
+	assert.Equal(t, "(1895-1943).", finalAnswerText("1896", "(1895-1943).", true))
+}
@@ -0,0 +1,191 @@
+package predictor
+
+import (
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/feature"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// float32View is a read-only view over the backing array of a float32 tensor.
+// The elements are addressed through the strides of the tensor, so that the decoders
+// read the outputs of the model in place instead of copying them into nested slices.
+type float32View struct {
+	data    []float32
+	shape   []int
+	strides []int
+}
+
+// newFloat32View returns the view of a tensor of the given rank. The float32 tensors are viewed in place,
+// the tensors of the other dtypes are converted first, keeping the layout of their backing array.
+func newFloat32View(t gotensor.Tensor, rank int) (float32View, error) {
+	data, err := tensorToFloat32s(t)
+	if err != nil {
+		return float32View{}, err
+	}
+	return viewFloat32s(t, data, rank)
+}
+
+// newOutputView returns the view of the output of a single output model,
+// checked against the element type of the manifest.
+func newOutputView(base common.Base, index int, output gotensor.Tensor, rank int) (float32View, error) {
+	data, err := outputToFloat32s(base, index, output)
+	if err != nil {
+		return float32View{}, err
+	}
+	return viewFloat32s(output, data, rank)
+}
+
+// viewFloat32s returns the view of the data of the tensor, its backing array converted to float32.
+func viewFloat32s(t gotensor.Tensor, data []float32, rank int) (float32View, error) {
+	shape := t.Shape()
+	if len(shape) != rank {
+		return float32View{}, errors.Errorf("expecting a tensor of rank %v, but got the shape %v", rank, shape)
+	}
+
+	strides := t.Strides()
+	if len(strides) != len(shape) {
+		strides = rowMajorStrides(shape)
+	}
+
+	// the last element must lie in the backing array for the unchecked accesses of the decoders
+	last := 0
+	for ii, dim := range shape {
+		if dim == 0 {
+			return float32View{}, errors.Errorf("cannot view the empty tensor of shape %v", shape)
+		}
+		last += (dim - 1) * strides[ii]
+	}
+	if last >= len(data) {
+		return float32View{}, errors.Errorf("the strides %v of the tensor overflow its %v elements", strides, len(data))
+	}
+
+	return float32View{
+		data:    data,
+		shape:   []int(shape),
+		strides: strides,
+	}, nil
+}
+
+// rowMajorStrides returns the strides of a contiguous tensor of the given shape.
+func rowMajorStrides(shape []int) []int {
+	strides := make([]int, len(shape))
+	stride := 1
+	for ii := len(shape) - 1; ii >= 0; ii-- {
+		strides[ii] = stride
+		stride *= shape[ii]
+	}
+	return strides
+}
+
+// denormalizeImages interleaves the planes of a NCHW output into the HWC pixels of each image, scaling then
+// shifting each channel by its mean. The pixels are clamped to the [0, 255] range of the images when clamp is set.
+func denormalizeImages(images float32View, scale, mean []float32, clamp bool) [][]float32 {
+	batch, channels, height, width := images.shape[0], images.shape[1], images.shape[2], images.shape[3]
+	sb, sc, sh, sw := images.strides[0], images.strides[1], images.strides[2], images.strides[3]
+
+	res := make([][]float32, batch)
+	for b := 0; b < batch; b++ {
+		pixels := make([]float32, height*width*channels)
+		for c := 0; c < channels; c++ {
+			s, m := scale[c], mean[c]
+			plane := b*sb + c*sc
+			dst := c
+			for h := 0; h < height; h++ {
+				src := plane + h*sh
+				for w := 0; w < width; w++ {
+					pixels[dst] = images.data[src]*s + m
+					src += sw
+					dst += channels
+				}
+			}
+		}
+		if clamp {
+			for ii, v := range pixels {
+				// NaN fails both comparisons and goes to 0
+				if !(v >= 0) {
+					pixels[ii] = 0
+				} else if v > 255 {
+					pixels[ii] = 255
+				}
+			}
+		}
+		res[b] = pixels
+	}
+	return res
+}
+
+// rawImageFeatures builds the raw image features from the HWC pixels of the images.
+func rawImageFeatures(images [][]float32, width, height, channels int) []dlframework.Features {
+	features := make([]dlframework.Features, len(images))
+	for b, pixels := range images {
+		features[b] = dlframework.Features{
+			feature.New(
+				feature.RawImageType(),
+				feature.RawImageWidth(width),
+				feature.RawImageHeight(height),
+				feature.RawImageChannels(channels),
+				feature.RawImageData(pixels),
+			),
+		}
+	}
+	return features
+}
+
+// argmaxMasks returns the class of the highest score of each pixel of a NCHW score output,
+// as one flat HW mask per image of the batch.
+func argmaxMasks(scores float32View) [][]int32 {
+	batch, classes, height, width := scores.shape[0], scores.shape[1], scores.shape[2], scores.shape[3]
+	sb, sc, sh, sw := scores.strides[0], scores.strides[1], scores.strides[2], scores.strides[3]
+
+	masks := make([][]int32, batch)
+	best := make([]float32, width)
+	for b := 0; b < batch; b++ {
+		mask := make([]int32, height*width)
+		for h := 0; h < height; h++ {
+			row := mask[h*width : (h+1)*width]
+			// walk the rows of the score planes one after the other, so that the reads follow
+			// the backing array while the best scores of the row stay in the cache
+			src := b*sb + h*sh
+			for w := range row {
+				best[w] = scores.data[src]
+				row[w] = 0
+				src += sw
+			}
+			for c := 1; c < classes; c++ {
+				src := b*sb + c*sc + h*sh
+				for w, score := range best {
+					if s := scores.data[src]; s > score {
+						best[w] = s
+						row[w] = int32(c)
+					}
+					src += sw
+				}
+			}
+		}
+		masks[b] = mask
+	}
+
+	return masks
+}
+
+// createSemanticSegmentFeatures builds the semantic segmentation features from flat HW masks.
+func createSemanticSegmentFeatures(masks [][]int32, height, width int) []dlframework.Features {
+	features := make([]dlframework.Features, len(masks))
+	for ii, mask := range masks {
+		features[ii] = dlframework.Features{semanticSegmentFeature(mask, height, width)}
+	}
+	return features
+}
+
+// semanticSegmentFeature builds the semantic segmentation feature of a flat HW mask.
+func semanticSegmentFeature(mask []int32, height, width int) *dlframework.Feature {
+	return feature.New(
+		feature.SemanticSegmentType(),
+		feature.SemanticSegmentHeight(int32(height)),
+		feature.SemanticSegmentWidth(int32(width)),
+		feature.SemanticSegmentIntMask(mask),
+		feature.Probability(1.0),
+	)
+}
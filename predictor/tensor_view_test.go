@@ -0,0 +1,110 @@
+package predictor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+func rangeTensor(shape ...int) *gotensor.Dense {
+	size := 1
+	for _, dim := range shape {
+		size *= dim
+	}
+	data := make([]float32, size)
+	for ii := range data {
+		// scatter the values so that the argmax differs from pixel to pixel
+		data[ii] = float32((ii * 7919) % 1013)
+	}
+	return gotensor.New(gotensor.WithShape(shape...), gotensor.WithBacking(data))
+}
+
+// nestedArgmaxMasks computes the masks through nested slices, the way the semantic segmentation predictor used to.
+func nestedArgmaxMasks(t gotensor.Tensor) [][]int32 {
+	data := t.Data().([]float32)
+	shape := t.Shape()
+	batch, classes, height, width := shape[0], shape[1], shape[2], shape[3]
+
+	masks := make([][][]int64, batch)
+	for b := 0; b < batch; b++ {
+		masks[b] = make([][]int64, height)
+		for h := 0; h < height; h++ {
+			masks[b][h] = make([]int64, width)
+			for w := 0; w < width; w++ {
+				idx := 0
+				cur := data[((b*classes)*height+h)*width+w]
+				for c := 1; c < classes; c++ {
+					if v := data[((b*classes+c)*height+h)*width+w]; v > cur {
+						idx, cur = c, v
+					}
+				}
+				masks[b][h][w] = int64(idx)
+			}
+		}
+	}
+
+	flat := make([][]int32, batch)
+	for b, mask := range masks {
+		flat[b] = make([]int32, 0, height*width)
+		for _, row := range mask {
+			for _, v := range row {
+				flat[b] = append(flat[b], int32(v))
+			}
+		}
+	}
+	return flat
+}
+
+func TestNewFloat32View(t *testing.T) {
+	view, err := newFloat32View(rangeTensor(2, 3, 4, 5), 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 4, 5}, view.shape)
+	assert.Equal(t, []int{60, 20, 5, 1}, view.strides)
+
+	_, err = newFloat32View(rangeTensor(2, 3, 4), 4)
+	assert.Error(t, err)
+	_, err = newFloat32View(gotensor.New(gotensor.WithShape(1, 2), gotensor.WithBacking([]uint16{1, 2})), 2)
+	assert.Error(t, err)
+
+	assert.Equal(t, []int{12, 4, 1}, rowMajorStrides([]int{2, 3, 4}))
+}
+
+func TestArgmaxMasks(t *testing.T) {
+	output := rangeTensor(2, 21, 7, 9)
+
+	view, err := newFloat32View(output, 4)
+	assert.NoError(t, err)
+	masks := argmaxMasks(view)
+	assert.Equal(t, nestedArgmaxMasks(output), masks)
+
+	features := createSemanticSegmentFeatures(masks, 7, 9)
+	if assert.Len(t, features, 2) {
+		sseg := features[1][0].GetSemanticSegment()
+		assert.Equal(t, int32(7), sseg.GetHeight())
+		assert.Equal(t, int32(9), sseg.GetWidth())
+		assert.Equal(t, masks[1], sseg.GetIntMask())
+	}
+}
+
+func BenchmarkArgmaxMasksNested(b *testing.B) {
+	output := rangeTensor(1, 21, 1000, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for ii := 0; ii < b.N; ii++ {
+		nestedArgmaxMasks(output)
+	}
+}
+
+func BenchmarkArgmaxMasksView(b *testing.B) {
+	output := rangeTensor(1, 21, 1000, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for ii := 0; ii < b.N; ii++ {
+		view, err := newFloat32View(output, 4)
+		if err != nil {
+			b.Fatal(err)
+		}
+		argmaxMasks(view)
+	}
+}
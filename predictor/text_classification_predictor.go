@@ -0,0 +1,170 @@
+package predictor
+
+import (
+	"context"
+
+	"github.com/c3sr/config"
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/agent"
+	"github.com/c3sr/dlframework/framework/options"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/c3sr/pytorch"
+	"github.com/c3sr/tracer"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// TextClassificationModality is the modality of the TextClassificationPredictor.
+// dlframework reports the general modality for these models, and the GeneralPredictor
+// hands them over to the TextClassificationPredictor based on their text_classification output type.
+const TextClassificationModality dlframework.Modality = "text_classification"
+
+// TextClassificationPredictor runs BERT-style sequence classification models.
+// It takes a []string, tokenizes every text with the WordPiece vocabulary of the model
+// and returns the classes of each text.
+type TextClassificationPredictor struct {
+	common.Base
+	torchPredictor
+	tokenizer   *WordPieceTokenizer
+	encoder     textEncoderOptions
+	postprocess classificationPostprocessOptions
+}
+
+// NewTextClassificationPredictor ...
+func NewTextClassificationPredictor(model dlframework.ModelManifest, os ...options.Option) (common.Predictor, error) {
+	opts := options.New(os...)
+	ctx := opts.Context()
+
+	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "new_predictor")
+	defer span.Finish()
+
+	if _, ok := textPredictor(model).(*TextClassificationPredictor); !ok {
+		return nil, errors.New("output type not supported")
+	}
+
+	predictor := new(TextClassificationPredictor)
+
+	return predictor.Load(ctx, model, os...)
+}
+
+// Download ...
+func (p *TextClassificationPredictor) Download(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) error {
+	base, err := newTorchBase(model, opts...)
+	if err != nil {
+		return err
+	}
+
+	tp := &TextClassificationPredictor{
+		Base: base,
+	}
+
+	return downloadModel(ctx, tp.Base, tp.modelFiles())
+}
+
+// Load ...
+func (p *TextClassificationPredictor) Load(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) (common.Predictor, error) {
+	base, err := newTorchBase(model, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := &TextClassificationPredictor{
+		Base: base,
+	}
+
+	if err = tp.load(ctx, tp.Base, tp); err != nil {
+		return nil, err
+	}
+
+	return tp, nil
+}
+
+func (p *TextClassificationPredictor) modelFiles() []modelFile {
+	return []modelFile{vocabFile(p.Base), featuresFile(p.Base)}
+}
+
+func (p *TextClassificationPredictor) loadModelFiles(ctx context.Context) error {
+	encoder, err := textEncoderOptionsFromManifest(p.Base)
+	if err != nil {
+		return errors.Wrap(err, "invalid text parameters")
+	}
+	p.encoder = encoder
+
+	tokenizer, err := NewWordPieceTokenizerFromFile(getVocabPath(p.Base), p.encoder.doLowerCase)
+	if err != nil {
+		return err
+	}
+	p.tokenizer = tokenizer
+
+	p.postprocess = p.postprocessOptions()
+
+	return nil
+}
+
+// postprocessOptions resolves the probabilities transform and the top-k of a request.
+func (p *TextClassificationPredictor) postprocessOptions(opts ...options.Option) classificationPostprocessOptions {
+	res := classificationPostprocessOptions{
+		transform:   getOutputParameter(p.Base, "probabilities_transform"),
+		temperature: getOutputFloat32Parameter(p.Base, "temperature", 1),
+		topK:        getOutputIntParameter(p.Base, "top_k", 0),
+	}
+	return res.withOverrides(p.Options.Context()).withOverrides(options.New(opts...).Context())
+}
+
+// prepare takes a []string, or the input tensors of the model.
+func (p *TextClassificationPredictor) prepare(ctx context.Context, data interface{}, opts ...options.Option) ([]gotensor.Tensor, outputDecoder, error) {
+	postprocess := p.postprocessOptions(opts...)
+
+	var gotensors []gotensor.Tensor
+	switch in := data.(type) {
+	case []gotensor.Tensor:
+		gotensors = in
+	case []string:
+		var err error
+		gotensors, _, err = textInputTensors(p.tokenizer, in, p.encoder)
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, errors.Errorf("input data of type %T is not supported, expecting texts or tensors", data)
+	}
+
+	return gotensors, func(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+		return p.decodeFeatures(ctx, outputs, postprocess)
+	}, nil
+}
+
+// decodeOutputs decodes the outputs with the postprocessing options of the manifest and of the Load.
+func (p *TextClassificationPredictor) decodeOutputs(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	return p.decodeFeatures(ctx, outputs, p.postprocess)
+}
+
+func (p *TextClassificationPredictor) decodeFeatures(ctx context.Context, outputs []gotensor.Tensor, postprocess classificationPostprocessOptions) ([]dlframework.Features, error) {
+	logits, err := outputToFloat32s(p.Base, 0, outputs[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the logits output")
+	}
+
+	shape := outputs[0].Shape()
+	if len(shape) == 0 {
+		return nil, errors.New("the logits output is a scalar")
+	}
+
+	return createClassificationFeatures(logits, shape[0], p.labels, postprocess)
+}
+
+// Modality ...
+func (p *TextClassificationPredictor) Modality() (dlframework.Modality, error) {
+	return TextClassificationModality, nil
+}
+
+func init() {
+	config.AfterInit(func() {
+		framework := pytorch.FrameworkManifest
+		agent.AddPredictor(framework, &TextClassificationPredictor{
+			Base: common.Base{
+				Framework: framework,
+			},
+		})
+	})
+}
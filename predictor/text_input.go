@@ -0,0 +1,141 @@
+package predictor
+
+import (
+	"path/filepath"
+	"strings"
+
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+const (
+	textInputIDs   = "input_ids"
+	textInputMask  = "input_mask"
+	textSegmentIDs = "segment_ids"
+)
+
+// textEncoderOptions are the parameters used to turn text into the inputs of a BERT-style model.
+type textEncoderOptions struct {
+	maxSeqLength int
+	doLowerCase  bool
+	// inputs lists the tensors the model takes, in order, among input_ids, input_mask and segment_ids
+	inputs []string
+}
+
+// textEncoderOptionsFromManifest reads the max_seq_length, do_lower_case and text_inputs input parameters.
+func textEncoderOptionsFromManifest(p common.Base) (textEncoderOptions, error) {
+	opts := textEncoderOptions{
+		maxSeqLength: getInputIntParameter(p, "max_seq_length", 128),
+		doLowerCase:  getInputBoolParameter(p, "do_lower_case", true),
+		inputs:       []string{textInputIDs, textInputMask, textSegmentIDs},
+	}
+	var inputs []string
+	ok, err := getInputListParameter(p, "text_inputs", &inputs)
+	if err != nil {
+		return opts, err
+	}
+	if ok {
+		opts.inputs = inputs
+	}
+	if opts.maxSeqLength < 3 {
+		return opts, errors.Errorf("max_seq_length %v is too short", opts.maxSeqLength)
+	}
+	for _, input := range opts.inputs {
+		switch input {
+		case textInputIDs, textInputMask, textSegmentIDs:
+		default:
+			return opts, errors.Errorf("text input %v is not supported, expecting one of %v, %v or %v", input, textInputIDs, textInputMask, textSegmentIDs)
+		}
+	}
+	return opts, nil
+}
+
+// textEncoding is a text tokenized into the [CLS] tokens [SEP] input of a BERT-style model.
+type textEncoding struct {
+	words []string
+	// wordIndexes holds, for each token, the index of the word it was split from,
+	// or -1 for the special and padding tokens
+	wordIndexes []int
+	inputIDs    []int64
+	inputMask   []int64
+	segmentIDs  []int64
+}
+
+// encodeText splits the text on whitespace, tokenizes every word and packs the tokens
+// into maxSeqLength ids. Tokens past the sequence length are dropped.
+func encodeText(tokenizer *WordPieceTokenizer, text string, maxSeqLength int) textEncoding {
+	words := strings.Fields(text)
+	tokens := []string{bertClassifyToken}
+	wordIndexes := []int{-1}
+	for ii, word := range words {
+		for _, token := range tokenizer.Tokenize(word) {
+			if len(tokens) >= maxSeqLength-1 {
+				break
+			}
+			tokens = append(tokens, token)
+			wordIndexes = append(wordIndexes, ii)
+		}
+	}
+	tokens = append(tokens, bertSeparatorToken)
+	wordIndexes = append(wordIndexes, -1)
+
+	inputIDs := tokenizer.ConvertTokensToIDs(tokens)
+	inputMask := make([]int64, maxSeqLength)
+	for ii := range tokens {
+		inputMask[ii] = 1
+	}
+	for len(inputIDs) < maxSeqLength {
+		inputIDs = append(inputIDs, 0)
+		wordIndexes = append(wordIndexes, -1)
+	}
+
+	return textEncoding{
+		words:       words,
+		wordIndexes: wordIndexes,
+		inputIDs:    inputIDs,
+		inputMask:   inputMask,
+		segmentIDs:  make([]int64, maxSeqLength),
+	}
+}
+
+// textInputTensors encodes the texts into the input tensors listed in the options,
+// each of shape [len(texts), maxSeqLength].
+func textInputTensors(tokenizer *WordPieceTokenizer, texts []string, opts textEncoderOptions) ([]gotensor.Tensor, []textEncoding, error) {
+	if len(texts) == 0 {
+		return nil, nil, errors.New("no text inputs")
+	}
+
+	encodings := make([]textEncoding, len(texts))
+	for ii, text := range texts {
+		encodings[ii] = encodeText(tokenizer, text, opts.maxSeqLength)
+	}
+
+	tensors := make([]gotensor.Tensor, len(opts.inputs))
+	for ii, input := range opts.inputs {
+		data := make([]int64, 0, len(texts)*opts.maxSeqLength)
+		for _, encoding := range encodings {
+			switch input {
+			case textInputIDs:
+				data = append(data, encoding.inputIDs...)
+			case textInputMask:
+				data = append(data, encoding.inputMask...)
+			case textSegmentIDs:
+				data = append(data, encoding.segmentIDs...)
+			}
+		}
+		tensors[ii] = gotensor.New(gotensor.WithShape(len(texts), opts.maxSeqLength), gotensor.WithBacking(data))
+	}
+
+	return tensors, encodings, nil
+}
+
+// getVocabUrl returns the url of the WordPiece vocabulary, given by the vocab_url input parameter.
+func getVocabUrl(p common.Base) string {
+	return getInputParameter(p, "vocab_url")
+}
+
+// getVocabPath returns the path the WordPiece vocabulary is downloaded to.
+func getVocabPath(p common.Base) string {
+	return filepath.Join(p.WorkDir, p.Model.GetName()+".vocab")
+}
@@ -0,0 +1,72 @@
+package predictor
+
+import (
+	"testing"
+
+	"github.com/c3sr/dlframework"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeText(t *testing.T) {
+	tokenizer := testWordPieceTokenizer(t, true)
+
+	encoding := encodeText(tokenizer, "The man went running", 8)
+	assert.Equal(t, []string{"The", "man", "went", "running"}, encoding.words)
+	assert.Equal(t, []int64{2, 4, 5, 6, 15, 16, 3, 0}, encoding.inputIDs)
+	assert.Equal(t, []int64{1, 1, 1, 1, 1, 1, 1, 0}, encoding.inputMask)
+	assert.Equal(t, []int64{0, 0, 0, 0, 0, 0, 0, 0}, encoding.segmentIDs)
+	assert.Equal(t, []int{-1, 0, 1, 2, 3, 3, -1, -1}, encoding.wordIndexes)
+
+	// the tokens past the sequence length are dropped
+	encoding = encodeText(tokenizer, "The man went running", 5)
+	assert.Equal(t, []int64{2, 4, 5, 6, 3}, encoding.inputIDs)
+	assert.Equal(t, []int{-1, 0, 1, 2, -1}, encoding.wordIndexes)
+}
+
+func TestTextInputTensors(t *testing.T) {
+	tokenizer := testWordPieceTokenizer(t, true)
+	opts := textEncoderOptions{
+		maxSeqLength: 4,
+		inputs:       []string{textInputMask, textInputIDs},
+	}
+
+	tensors, encodings, err := textInputTensors(tokenizer, []string{"the man", "store"}, opts)
+	assert.NoError(t, err)
+	assert.Len(t, encodings, 2)
+	assert.Len(t, tensors, 2)
+	assert.Equal(t, []int{2, 4}, []int(tensors[0].Shape()))
+	assert.Equal(t, []int64{1, 1, 1, 1, 1, 1, 1, 0}, tensors[0].Data())
+	assert.Equal(t, []int64{2, 4, 5, 3, 2, 8, 3, 0}, tensors[1].Data())
+
+	_, _, err = textInputTensors(tokenizer, nil, opts)
+	assert.Error(t, err)
+}
+
+func TestTextEncoderOptionsFromManifest(t *testing.T) {
+	base := common.Base{
+		Model: dlframework.ModelManifest{
+			Inputs: []*dlframework.ModelManifest_Type{
+				{
+					Type: "general",
+					Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+						"max_seq_length": {Value: "64"},
+						"do_lower_case":  {Value: "false"},
+						"text_inputs":    {Value: "[input_ids, input_mask]"},
+					},
+				},
+			},
+		},
+	}
+	opts, err := textEncoderOptionsFromManifest(base)
+	assert.NoError(t, err)
+	assert.Equal(t, textEncoderOptions{
+		maxSeqLength: 64,
+		doLowerCase:  false,
+		inputs:       []string{textInputIDs, textInputMask},
+	}, opts)
+
+	base.Model.Inputs[0].Parameters["text_inputs"] = &dlframework.ModelManifest_Type_Parameter{Value: "[token_type_ids]"}
+	_, err = textEncoderOptionsFromManifest(base)
+	assert.Error(t, err)
+}
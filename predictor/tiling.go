@@ -0,0 +1,366 @@
+package predictor
+
+import (
+	"context"
+
+	common "github.com/c3sr/dlframework/framework/predictor"
+	raiimage "github.com/c3sr/image"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// tileOptions holds how the images of a request are split into tiles, so that the model runs on
+// large images with the memory of a tile. The tiling is disabled when the size is 0.
+type tileOptions struct {
+	// size is the height and width of the square tiles, in input pixels
+	size int
+	// overlap is the number of input pixels shared by neighboring tiles, over which their outputs are blended
+	overlap int
+	// batchSize is the number of tiles run through the model at once
+	batchSize int
+}
+
+// imageTileOptions resolves the tiling of the image inputs from the tile_size, tile_overlap and tile_batch_size
+// input parameters of the manifest, overridden by the contexts in order.
+// The tiles are cut from the CHW input of the models of a single image input, the tiling of the other models is an error.
+func imageTileOptions(base common.Base, ctxs ...context.Context) (tileOptions, error) {
+	res := tileOptions{
+		size:      getInputIntParameter(base, "tile_size", 0),
+		overlap:   getInputIntParameter(base, "tile_overlap", 0),
+		batchSize: getInputIntParameter(base, "tile_batch_size", 1),
+	}
+	for _, ctx := range ctxs {
+		res = res.withOverrides(ctx)
+	}
+	if !res.enabled() {
+		return res, nil
+	}
+	if err := res.validate(); err != nil {
+		return tileOptions{}, err
+	}
+	if n := len(base.Model.GetInputs()); n > 1 {
+		return tileOptions{}, errors.Errorf("model %v cannot be tiled, it takes %v inputs", base.Model.GetName(), n)
+	}
+	layout := common.ImagePredictor{Base: base}.GetLayout(raiimage.HWCLayout)
+	if layout != raiimage.CHWLayout {
+		return tileOptions{}, errors.Errorf("model %v cannot be tiled, its inputs are not in the CHW layout", base.Model.GetName())
+	}
+	return res, nil
+}
+
+// withOverrides returns the options overridden by the values set on the context through Tiling and TileBatchSize.
+func (o tileOptions) withOverrides(ctx context.Context) tileOptions {
+	if ctx == nil {
+		return o
+	}
+	if val, ok := ctx.Value(tilingKey{}).([2]int); ok {
+		o.size, o.overlap = val[0], val[1]
+	}
+	if val, ok := ctx.Value(tileBatchSizeKey{}).(int); ok {
+		o.batchSize = val
+	}
+	return o
+}
+
+func (o tileOptions) enabled() bool {
+	return o.size > 0
+}
+
+func (o tileOptions) validate() error {
+	if o.overlap < 0 || o.overlap >= o.size {
+		return errors.Errorf("the tile overlap must be between 0 and the tile size %v, but got %v", o.size, o.overlap)
+	}
+	if o.batchSize < 1 {
+		return errors.Errorf("the tile batch size must be positive, but got %v", o.batchSize)
+	}
+	return nil
+}
+
+// tileStarts returns the offsets of the tiles along an axis of the given size. The tiles step by their size
+// minus the overlap, and the last one is moved back to end with the axis, overlapping its neighbor further.
+func tileStarts(size, tile, overlap int) []int {
+	if size <= tile {
+		return []int{0}
+	}
+	var starts []int
+	for start := 0; ; start += tile - overlap {
+		if start+tile >= size {
+			return append(starts, size-tile)
+		}
+		starts = append(starts, start)
+	}
+}
+
+// featherWeights returns the blending weights of the pixels along an axis of a tile of the given length.
+// The weights ramp up linearly over the overlap at the start of the tile when it has a neighbor before it,
+// and ramp down at its end when it has a neighbor after it, so that the seams between the tiles fade.
+func featherWeights(length, overlap int, rampStart, rampEnd bool) []float32 {
+	weights := make([]float32, length)
+	for ii := range weights {
+		w := float32(1)
+		if rampStart && ii < overlap {
+			w = (float32(ii) + 0.5) / float32(overlap)
+		}
+		if rampEnd && length-1-ii < overlap {
+			if end := (float32(length-1-ii) + 0.5) / float32(overlap); end < w {
+				w = end
+			}
+		}
+		weights[ii] = w
+	}
+	return weights
+}
+
+// tile is the top left corner of a tile of the image n of the batch.
+type tile struct {
+	n, y, x int
+}
+
+// inferTiled runs the model on the NCHW image input tile by tile, batchSize tiles at a time, and blends the
+// outputs of the tiles back into the outputs of the whole images. The outputs must be NCHW images whose size
+// is an integer multiple of the size of the tiles, such as the upscaled images of the enhancement models or
+// the scores of the segmentation models. The float outputs are averaged with feathered weights over the overlaps,
+// while the integer outputs, such as class masks, take the value of the tile weighing the most at each pixel.
+// The inputs that fit in a single tile are run as is.
+func inferTiled(ctx context.Context, infer func(context.Context, []gotensor.Tensor) ([]gotensor.Tensor, error),
+	inputs []gotensor.Tensor, opts tileOptions) ([]gotensor.Tensor, error) {
+	if len(inputs) != 1 {
+		return nil, errors.Errorf("cannot tile %v inputs, expecting a single image input", len(inputs))
+	}
+	shape := inputs[0].Shape()
+	if len(shape) != 4 {
+		return nil, errors.Errorf("cannot tile the input of shape %v, expecting NCHW images", shape)
+	}
+	batch, channels, height, width := shape[0], shape[1], shape[2], shape[3]
+	if height <= opts.size && width <= opts.size {
+		return infer(ctx, inputs)
+	}
+	if inputs[0].Dtype() != gotensor.Float32 {
+		return nil, errors.Errorf("cannot tile the input of %v elements, expecting float32 images", inputs[0].Dtype())
+	}
+	images, err := newFloat32View(inputs[0], 4)
+	if err != nil {
+		return nil, err
+	}
+
+	ys, xs := tileStarts(height, opts.size, opts.overlap), tileStarts(width, opts.size, opts.overlap)
+	tileHeight, tileWidth := minInt(opts.size, height), minInt(opts.size, width)
+	var tiles []tile
+	for n := 0; n < batch; n++ {
+		for _, y := range ys {
+			for _, x := range xs {
+				tiles = append(tiles, tile{n: n, y: y, x: x})
+			}
+		}
+	}
+
+	blender := &tileBlender{batch: batch, height: height, width: width, tileHeight: tileHeight, tileWidth: tileWidth, overlap: opts.overlap}
+	sb, sc, sh, sw := images.strides[0], images.strides[1], images.strides[2], images.strides[3]
+	for start := 0; start < len(tiles); start += opts.batchSize {
+		chunk := tiles[start:minInt(start+opts.batchSize, len(tiles))]
+		data := make([]float32, 0, len(chunk)*channels*tileHeight*tileWidth)
+		for _, t := range chunk {
+			for c := 0; c < channels; c++ {
+				for y := 0; y < tileHeight; y++ {
+					src := t.n*sb + c*sc + (t.y+y)*sh + t.x*sw
+					for x := 0; x < tileWidth; x++ {
+						data = append(data, images.data[src])
+						src += sw
+					}
+				}
+			}
+		}
+
+		outputs, err := infer(ctx, []gotensor.Tensor{
+			gotensor.New(gotensor.WithShape(len(chunk), channels, tileHeight, tileWidth), gotensor.WithBacking(data)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := blender.add(chunk, outputs); err != nil {
+			return nil, err
+		}
+	}
+
+	return blender.outputs()
+}
+
+// tileBlender accumulates the outputs of the tiles into the outputs of the whole images.
+type tileBlender struct {
+	batch, height, width  int
+	tileHeight, tileWidth int
+	overlap               int
+	blended               []*blendedOutput
+}
+
+// blendedOutput is an output of the whole images being blended.
+type blendedOutput struct {
+	dtype    gotensor.Dtype
+	channels int
+	// scaleY and scaleX are the ratios of the size of the output to the size of the input
+	scaleY, scaleX int
+	// values holds the weighted sums of the float outputs, or the values of the heaviest tile of the integer outputs
+	values []float32
+	// weights holds the sum of the weights of each pixel, or the weight of the heaviest tile of the integer outputs
+	weights []float32
+}
+
+func (b *tileBlender) add(tiles []tile, outputs []gotensor.Tensor) error {
+	if b.blended == nil {
+		for ii, output := range outputs {
+			shape := output.Shape()
+			if len(shape) != 4 || shape[2]%b.tileHeight != 0 || shape[3]%b.tileWidth != 0 {
+				return errors.Errorf("cannot blend the output %v of shape %v, expecting NCHW images scaling the tiles of %vx%v",
+					ii, shape, b.tileHeight, b.tileWidth)
+			}
+			if !(isIntegerDtype(output.Dtype()) || output.Dtype() == gotensor.Float32 || output.Dtype() == gotensor.Float64) {
+				return errors.Errorf("cannot blend the output %v of %v elements", ii, output.Dtype())
+			}
+			out := &blendedOutput{
+				dtype:    output.Dtype(),
+				channels: shape[1],
+				scaleY:   shape[2] / b.tileHeight,
+				scaleX:   shape[3] / b.tileWidth,
+			}
+			pixels := b.batch * b.height * out.scaleY * b.width * out.scaleX
+			out.values = make([]float32, pixels*out.channels)
+			out.weights = make([]float32, pixels)
+			b.blended = append(b.blended, out)
+		}
+	}
+	if len(outputs) != len(b.blended) {
+		return errors.Errorf("the model returned %v outputs for a tile, but %v for the first one", len(outputs), len(b.blended))
+	}
+
+	for ii, output := range outputs {
+		out := b.blended[ii]
+		expected := []int{len(tiles), out.channels, b.tileHeight * out.scaleY, b.tileWidth * out.scaleX}
+		if !output.Shape().Eq(gotensor.Shape(expected)) || output.Dtype() != out.dtype {
+			return errors.Errorf("the output %v of a tile has the shape %v and the dtype %v, expecting %v and %v",
+				ii, output.Shape(), output.Dtype(), expected, out.dtype)
+		}
+		view, err := newFloat32View(output, 4)
+		if err != nil {
+			return errors.Wrapf(err, "cannot read the output %v of a tile", ii)
+		}
+		for jj, t := range tiles {
+			b.blendTile(out, view, jj, t)
+		}
+	}
+	return nil
+}
+
+// blendTile adds the output of the tile at index of the batch of tiles.
+func (b *tileBlender) blendTile(out *blendedOutput, view float32View, index int, t tile) {
+	outHeight, outWidth := b.height*out.scaleY, b.width*out.scaleX
+	tileHeight, tileWidth := view.shape[2], view.shape[3]
+	y0, x0 := t.y*out.scaleY, t.x*out.scaleX
+	wy := featherWeights(tileHeight, b.overlap*out.scaleY, t.y > 0, t.y+b.tileHeight < b.height)
+	wx := featherWeights(tileWidth, b.overlap*out.scaleX, t.x > 0, t.x+b.tileWidth < b.width)
+	integer := isIntegerDtype(out.dtype)
+
+	sb, sc, sh, sw := view.strides[0], view.strides[1], view.strides[2], view.strides[3]
+	plane := outHeight * outWidth
+	for y := 0; y < tileHeight; y++ {
+		for x := 0; x < tileWidth; x++ {
+			w := wy[y] * wx[x]
+			pixel := (t.n*outHeight+y0+y)*outWidth + x0 + x
+			src := index*sb + y*sh + x*sw
+			dst := t.n*out.channels*plane + (y0+y)*outWidth + x0 + x
+			if integer {
+				if w <= out.weights[pixel] {
+					continue
+				}
+				out.weights[pixel] = w
+				for c := 0; c < out.channels; c++ {
+					out.values[dst+c*plane] = view.data[src+c*sc]
+				}
+				continue
+			}
+			out.weights[pixel] += w
+			for c := 0; c < out.channels; c++ {
+				out.values[dst+c*plane] += w * view.data[src+c*sc]
+			}
+		}
+	}
+}
+
+// outputs returns the blended outputs of the whole images, with the dtypes of the outputs of the tiles.
+func (b *tileBlender) outputs() ([]gotensor.Tensor, error) {
+	res := make([]gotensor.Tensor, len(b.blended))
+	for ii, out := range b.blended {
+		outHeight, outWidth := b.height*out.scaleY, b.width*out.scaleX
+		plane := outHeight * outWidth
+		if !isIntegerDtype(out.dtype) {
+			for n := 0; n < b.batch; n++ {
+				for c := 0; c < out.channels; c++ {
+					values := out.values[(n*out.channels+c)*plane : (n*out.channels+c+1)*plane]
+					weights := out.weights[n*plane : (n+1)*plane]
+					for jj, w := range weights {
+						values[jj] /= w
+					}
+				}
+			}
+		}
+
+		shape := gotensor.WithShape(b.batch, out.channels, outHeight, outWidth)
+		backing, err := float32sToDtype(out.values, out.dtype)
+		if err != nil {
+			return nil, err
+		}
+		res[ii] = gotensor.New(shape, gotensor.WithBacking(backing))
+	}
+	return res, nil
+}
+
+// float32sToDtype converts the values to the backing array of a tensor of the dtype.
+func float32sToDtype(values []float32, dtype gotensor.Dtype) (interface{}, error) {
+	switch dtype {
+	case gotensor.Float32:
+		return values, nil
+	case gotensor.Float64:
+		res := make([]float64, len(values))
+		for ii, v := range values {
+			res[ii] = float64(v)
+		}
+		return res, nil
+	case gotensor.Int64:
+		res := make([]int64, len(values))
+		for ii, v := range values {
+			res[ii] = int64(v)
+		}
+		return res, nil
+	case gotensor.Int32:
+		res := make([]int32, len(values))
+		for ii, v := range values {
+			res[ii] = int32(v)
+		}
+		return res, nil
+	case gotensor.Int16:
+		res := make([]int16, len(values))
+		for ii, v := range values {
+			res[ii] = int16(v)
+		}
+		return res, nil
+	case gotensor.Int8:
+		res := make([]int8, len(values))
+		for ii, v := range values {
+			res[ii] = int8(v)
+		}
+		return res, nil
+	case gotensor.Uint8:
+		res := make([]uint8, len(values))
+		for ii, v := range values {
+			res[ii] = uint8(v)
+		}
+		return res, nil
+	}
+	return nil, errors.Errorf("unsupported tensor data type %v", dtype)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,207 @@
+package predictor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/options"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+func TestTileStarts(t *testing.T) {
+	assert.Equal(t, []int{0}, tileStarts(3, 4, 1))
+	assert.Equal(t, []int{0}, tileStarts(4, 4, 1))
+	assert.Equal(t, []int{0, 3, 6}, tileStarts(10, 4, 1))
+	// the last tile ends with the axis
+	assert.Equal(t, []int{0, 3, 5}, tileStarts(9, 4, 1))
+	assert.Equal(t, []int{0, 4}, tileStarts(8, 4, 0))
+}
+
+func TestFeatherWeights(t *testing.T) {
+	assert.Equal(t, []float32{1, 1, 1, 1}, featherWeights(4, 2, false, false))
+	assert.Equal(t, []float32{0.25, 0.75, 1, 1}, featherWeights(4, 2, true, false))
+	assert.Equal(t, []float32{1, 1, 0.75, 0.25}, featherWeights(4, 2, false, true))
+	assert.Equal(t, []float32{0.5, 1, 0.5}, featherWeights(3, 1, true, true))
+	assert.Equal(t, []float32{1, 1}, featherWeights(2, 0, true, true))
+}
+
+func TestImageTileOptions(t *testing.T) {
+	base := common.Base{Model: dlframework.ModelManifest{
+		Name: "fake",
+		Inputs: []*dlframework.ModelManifest_Type{{
+			Type: "image",
+			Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+				"layout":       {Value: "CHW"},
+				"tile_size":    {Value: "256"},
+				"tile_overlap": {Value: "32"},
+			},
+		}},
+	}}
+
+	opts, err := imageTileOptions(base)
+	assert.NoError(t, err)
+	assert.Equal(t, tileOptions{size: 256, overlap: 32, batchSize: 1}, opts)
+
+	opts, err = imageTileOptions(base, options.New(Tiling(64, 8), TileBatchSize(4)).Context())
+	assert.NoError(t, err)
+	assert.Equal(t, tileOptions{size: 64, overlap: 8, batchSize: 4}, opts)
+
+	opts, err = imageTileOptions(base, options.New(Tiling(0, 0)).Context())
+	assert.NoError(t, err)
+	assert.False(t, opts.enabled())
+
+	_, err = imageTileOptions(base, options.New(Tiling(64, 64)).Context())
+	assert.Error(t, err)
+	_, err = imageTileOptions(base, options.New(TileBatchSize(0)).Context())
+	assert.Error(t, err)
+
+	multiInput := base
+	multiInput.Model.Inputs = append(multiInput.Model.Inputs[:1:1], &dlframework.ModelManifest_Type{Type: "tensor"})
+	_, err = imageTileOptions(multiInput)
+	assert.Error(t, err)
+
+	base.Model.Inputs[0].Parameters["layout"] = &dlframework.ModelManifest_Type_Parameter{Value: "HWC"}
+	_, err = imageTileOptions(base)
+	assert.Error(t, err)
+}
+
+// rampImages returns NCHW images whose pixels all differ.
+func rampImages(batch, channels, height, width int) gotensor.Tensor {
+	data := make([]float32, batch*channels*height*width)
+	for ii := range data {
+		data[ii] = float32(ii)
+	}
+	return gotensor.New(gotensor.WithShape(batch, channels, height, width), gotensor.WithBacking(data))
+}
+
+func TestInferTiled(t *testing.T) {
+	ctx := context.Background()
+	input := rampImages(2, 3, 7, 9)
+	opts := tileOptions{size: 4, overlap: 2, batchSize: 4}
+
+	// the tiles of the identity model blend back into the input
+	var batches []gotensor.Shape
+	identity := func(ctx context.Context, inputs []gotensor.Tensor) ([]gotensor.Tensor, error) {
+		batches = append(batches, inputs[0].Shape())
+		return inputs, nil
+	}
+	outputs, err := inferTiled(ctx, identity, []gotensor.Tensor{input}, opts)
+	assert.NoError(t, err)
+	if assert.Len(t, outputs, 1) {
+		assert.Equal(t, []int{2, 3, 7, 9}, []int(outputs[0].Shape()))
+		assert.InDeltaSlice(t, input.Data(), outputs[0].Data(), 1e-3)
+	}
+	// 3 rows of 4 tiles for each image, 4 tiles at a time
+	assert.Len(t, batches, 6)
+	for _, shape := range batches {
+		assert.Equal(t, gotensor.Shape{4, 3, 4, 4}, shape)
+	}
+
+	// the upscaled tiles blend into the upscaled images
+	upscale := func(ctx context.Context, inputs []gotensor.Tensor) ([]gotensor.Tensor, error) {
+		shape := inputs[0].Shape()
+		src := inputs[0].Data().([]float32)
+		data := make([]float32, 0, len(src)*4)
+		for plane := 0; plane < shape[0]*shape[1]; plane++ {
+			for y := 0; y < shape[2]*2; y++ {
+				for x := 0; x < shape[3]*2; x++ {
+					data = append(data, src[(plane*shape[2]+y/2)*shape[3]+x/2])
+				}
+			}
+		}
+		return []gotensor.Tensor{gotensor.New(gotensor.WithShape(shape[0], shape[1], shape[2]*2, shape[3]*2), gotensor.WithBacking(data))}, nil
+	}
+	expected, err := upscale(ctx, []gotensor.Tensor{input})
+	assert.NoError(t, err)
+	outputs, err = inferTiled(ctx, upscale, []gotensor.Tensor{input}, opts)
+	assert.NoError(t, err)
+	if assert.Len(t, outputs, 1) {
+		assert.Equal(t, []int{2, 3, 14, 18}, []int(outputs[0].Shape()))
+		assert.InDeltaSlice(t, expected[0].Data(), outputs[0].Data(), 1e-3)
+	}
+
+	// the integer masks take the values of the tiles, with their dtype
+	masks := func(ctx context.Context, inputs []gotensor.Tensor) ([]gotensor.Tensor, error) {
+		src := inputs[0].Data().([]float32)
+		data := make([]int64, len(src))
+		for ii, v := range src {
+			data[ii] = int64(v)
+		}
+		return []gotensor.Tensor{gotensor.New(gotensor.WithShape(inputs[0].Shape()...), gotensor.WithBacking(data))}, nil
+	}
+	outputs, err = inferTiled(ctx, masks, []gotensor.Tensor{input}, opts)
+	assert.NoError(t, err)
+	if assert.Len(t, outputs, 1) {
+		expected, _ := masks(ctx, []gotensor.Tensor{input})
+		assert.Equal(t, expected[0].Data(), outputs[0].Data())
+	}
+
+	// the inputs fitting in a tile are run as is
+	batches = nil
+	small := rampImages(1, 3, 4, 3)
+	outputs, err = inferTiled(ctx, identity, []gotensor.Tensor{small}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []gotensor.Tensor{small}, outputs)
+	assert.Equal(t, []gotensor.Shape{{1, 3, 4, 3}}, batches)
+
+	// the outputs that do not scale the tiles cannot be blended
+	pooled := func(ctx context.Context, inputs []gotensor.Tensor) ([]gotensor.Tensor, error) {
+		return []gotensor.Tensor{gotensor.New(gotensor.WithShape(inputs[0].Shape()[0], 3, 2, 2), gotensor.Of(gotensor.Float32))}, nil
+	}
+	_, err = inferTiled(ctx, pooled, []gotensor.Tensor{input}, opts)
+	assert.Error(t, err)
+	_, err = inferTiled(ctx, identity, []gotensor.Tensor{input, input}, opts)
+	assert.Error(t, err)
+	_, err = inferTiled(ctx, identity, []gotensor.Tensor{gotensor.New(gotensor.WithShape(3, 8, 8), gotensor.Of(gotensor.Float32))}, opts)
+	assert.Error(t, err)
+}
+
+func TestImageEnhancementTiling(t *testing.T) {
+	withFakeTorchModules(t)
+	inferences := new(int32)
+	newFakeModule := newTorchModule
+	newTorchModule = func(ctx context.Context, opts ...options.Option) (torchModule, error) {
+		module, err := newFakeModule(ctx, opts...)
+		return countingTorchModule{torchModule: module, inferences: inferences}, err
+	}
+
+	ctx := context.Background()
+	p := &ImageEnhancementPredictor{}
+	p.Base = outputDimensionsBase("")
+	p.Options = options.New(TileBatchSize(2))
+	p.Model.Inputs = []*dlframework.ModelManifest_Type{{
+		Type: "image",
+		Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+			"layout":       {Value: "CHW"},
+			"mean":         {Value: "[0, 0, 0]"},
+			"scale":        {Value: "1"},
+			"tile_size":    {Value: "4"},
+			"tile_overlap": {Value: "1"},
+		},
+	}}
+	assert.NoError(t, p.open(ctx, p.Base, p))
+
+	// the 2x2 tiles of the image run in 2 inferences, and blend back into the output of the whole image
+	input := rampImages(1, 3, 6, 5)
+	features, outputs, err := p.InferAndDecode(ctx, []gotensor.Tensor{input})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(inferences))
+	if assert.Len(t, outputs, 1) {
+		assert.InDeltaSlice(t, input.Data(), outputs[0].Data(), 1e-3)
+	}
+	assert.Len(t, features, 1)
+
+	// the tiling is disabled per request
+	_, _, err = p.InferAndDecode(ctx, []gotensor.Tensor{input}, Tiling(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(inferences))
+
+	_, _, err = p.InferAndDecode(ctx, []gotensor.Tensor{input}, Tiling(4, 4))
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(inferences))
+	assert.NoError(t, p.Close())
+}
@@ -0,0 +1,211 @@
+package predictor
+
+import (
+	"context"
+
+	"github.com/c3sr/config"
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/agent"
+	"github.com/c3sr/dlframework/framework/feature"
+	"github.com/c3sr/dlframework/framework/options"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/c3sr/pytorch"
+	"github.com/c3sr/tracer"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// TokenClassificationModality is the modality of the TokenClassificationPredictor.
+// dlframework reports the general modality for these models, and the GeneralPredictor
+// hands them over to the TokenClassificationPredictor based on their token_classification output type.
+const TokenClassificationModality dlframework.Modality = "text_token_classification"
+
+// TokenClassificationPredictor runs BERT-style token classification models, such as named entity recognition.
+// It takes a []string, splits every text into whitespace separated words and labels each word
+// with the class predicted for its first WordPiece token.
+type TokenClassificationPredictor struct {
+	common.Base
+	torchPredictor
+	tokenizer   *WordPieceTokenizer
+	encoder     textEncoderOptions
+	postprocess classificationPostprocessOptions
+}
+
+// NewTokenClassificationPredictor ...
+func NewTokenClassificationPredictor(model dlframework.ModelManifest, os ...options.Option) (common.Predictor, error) {
+	opts := options.New(os...)
+	ctx := opts.Context()
+
+	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "new_predictor")
+	defer span.Finish()
+
+	if _, ok := textPredictor(model).(*TokenClassificationPredictor); !ok {
+		return nil, errors.New("output type not supported")
+	}
+
+	predictor := new(TokenClassificationPredictor)
+
+	return predictor.Load(ctx, model, os...)
+}
+
+// Download ...
+func (p *TokenClassificationPredictor) Download(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) error {
+	base, err := newTorchBase(model, opts...)
+	if err != nil {
+		return err
+	}
+
+	tp := &TokenClassificationPredictor{
+		Base: base,
+	}
+
+	return downloadModel(ctx, tp.Base, tp.modelFiles())
+}
+
+// Load ...
+func (p *TokenClassificationPredictor) Load(ctx context.Context, model dlframework.ModelManifest, opts ...options.Option) (common.Predictor, error) {
+	base, err := newTorchBase(model, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := &TokenClassificationPredictor{
+		Base: base,
+	}
+
+	if err = tp.load(ctx, tp.Base, tp); err != nil {
+		return nil, err
+	}
+
+	return tp, nil
+}
+
+func (p *TokenClassificationPredictor) modelFiles() []modelFile {
+	return []modelFile{vocabFile(p.Base), featuresFile(p.Base)}
+}
+
+func (p *TokenClassificationPredictor) loadModelFiles(ctx context.Context) error {
+	encoder, err := textEncoderOptionsFromManifest(p.Base)
+	if err != nil {
+		return errors.Wrap(err, "invalid text parameters")
+	}
+	p.encoder = encoder
+
+	tokenizer, err := NewWordPieceTokenizerFromFile(getVocabPath(p.Base), p.encoder.doLowerCase)
+	if err != nil {
+		return err
+	}
+	p.tokenizer = tokenizer
+
+	p.postprocess = p.postprocessOptions()
+
+	return nil
+}
+
+// postprocessOptions resolves the probabilities transform and the temperature of the token scores.
+func (p *TokenClassificationPredictor) postprocessOptions(opts ...options.Option) classificationPostprocessOptions {
+	res := classificationPostprocessOptions{
+		transform:   getOutputParameter(p.Base, "probabilities_transform"),
+		temperature: getOutputFloat32Parameter(p.Base, "temperature", 1),
+	}
+	return res.withOverrides(p.Options.Context()).withOverrides(options.New(opts...).Context())
+}
+
+// prepare takes a []string, or the input tensors of the model.
+// The words can only be labeled from the outputs of texts.
+func (p *TokenClassificationPredictor) prepare(ctx context.Context, data interface{}, opts ...options.Option) ([]gotensor.Tensor, outputDecoder, error) {
+	postprocess := p.postprocessOptions(opts...)
+
+	switch in := data.(type) {
+	case []gotensor.Tensor:
+		return in, p.decodeOutputs, nil
+	case []string:
+		gotensors, encodings, err := textInputTensors(p.tokenizer, in, p.encoder)
+		if err != nil {
+			return nil, nil, err
+		}
+		return gotensors, func(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+			return p.decodeFeatures(ctx, outputs, encodings, postprocess)
+		}, nil
+	}
+	return nil, nil, errors.Errorf("input data of type %T is not supported, expecting texts or tensors", data)
+}
+
+func (p *TokenClassificationPredictor) decodeOutputs(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error) {
+	return p.decodeFeatures(ctx, outputs, nil, p.postprocess)
+}
+
+// decodeFeatures returns a classification feature for every word of each text,
+// in the order of the words. The word and its index are set in the metadata of the feature.
+func (p *TokenClassificationPredictor) decodeFeatures(ctx context.Context, outputs []gotensor.Tensor, encodings []textEncoding, postprocess classificationPostprocessOptions) ([]dlframework.Features, error) {
+	if encodings == nil {
+		return nil, errors.New("word labels can only be read when predicting from texts")
+	}
+
+	logits, err := outputToFloat32s(p.Base, 0, outputs[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the logits output")
+	}
+
+	return createTokenClassificationFeatures(logits, encodings, p.encoder.maxSeqLength, p.labels, postprocess)
+}
+
+// createTokenClassificationFeatures labels every word of the encoded texts from the [batch, maxSeqLength, classes] logits,
+// using the logits of the first token of the word. The words truncated from the encoding are not labeled.
+func createTokenClassificationFeatures(logits []float32, encodings []textEncoding, maxSeqLength int, labels []string, opts classificationPostprocessOptions) ([]dlframework.Features, error) {
+	numTokens := len(encodings) * maxSeqLength
+	if numTokens == 0 || len(logits) == 0 || len(logits)%numTokens != 0 {
+		return nil, errors.Errorf("cannot split %v logits into %v texts of %v tokens", len(logits), len(encodings), maxSeqLength)
+	}
+	numClasses := len(logits) / numTokens
+	if numClasses > len(labels) {
+		return nil, errors.Errorf("the model returned %v classes, but only %v labels are available", numClasses, len(labels))
+	}
+
+	features := make([]dlframework.Features, len(encodings))
+	for b, encoding := range encodings {
+		res := make(dlframework.Features, 0, len(encoding.words))
+		for position, wordIndex := range encoding.wordIndexes {
+			if wordIndex < 0 || encoding.wordIndexes[position-1] == wordIndex {
+				continue
+			}
+			offset := (b*maxSeqLength + position) * numClasses
+			probabilities, err := applyProbabilitiesTransform(logits[offset:offset+numClasses], opts.transform, opts.temperature)
+			if err != nil {
+				return nil, err
+			}
+			best := 0
+			for ii, probability := range probabilities {
+				if probability > probabilities[best] {
+					best = ii
+				}
+			}
+			res = append(res, feature.New(
+				feature.ClassificationIndex(int32(best)),
+				feature.ClassificationLabel(labels[best]),
+				feature.Probability(probabilities[best]),
+				feature.AppendMetadata("word", encoding.words[wordIndex]),
+				feature.AppendMetadata("word_index", wordIndex),
+			))
+		}
+		features[b] = res
+	}
+
+	return features, nil
+}
+
+// Modality ...
+func (p *TokenClassificationPredictor) Modality() (dlframework.Modality, error) {
+	return TokenClassificationModality, nil
+}
+
+func init() {
+	config.AfterInit(func() {
+		framework := pytorch.FrameworkManifest
+		agent.AddPredictor(framework, &TokenClassificationPredictor{
+			Base: common.Base{
+				Framework: framework,
+			},
+		})
+	})
+}
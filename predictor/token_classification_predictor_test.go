@@ -0,0 +1,43 @@
+package predictor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTokenClassificationFeatures(t *testing.T) {
+	tokenizer := testWordPieceTokenizer(t, true)
+	encodings := []textEncoding{encodeText(tokenizer, "John went running", 6)}
+	assert.Equal(t, []int{-1, 0, 1, 2, 2, -1}, encodings[0].wordIndexes)
+
+	labels := []string{"O", "B-PER"}
+	logits := []float32{
+		0, 0, // [CLS]
+		0, 5, // john
+		5, 0, // went
+		5, 0, // runn
+		0, 5, // ##ing is not labeled
+		0, 0, // [SEP]
+	}
+	opts := classificationPostprocessOptions{
+		transform:   "softmax",
+		temperature: 1,
+	}
+
+	features, err := createTokenClassificationFeatures(logits, encodings, 6, labels, opts)
+	assert.NoError(t, err)
+	assert.Len(t, features, 1)
+	assert.Len(t, features[0], 3)
+	for ii, label := range []string{"B-PER", "O", "O"} {
+		f := features[0][ii]
+		assert.Equal(t, label, f.GetClassification().GetLabel())
+		assert.Equal(t, encodings[0].words[ii], f.GetMetadata()["word"])
+		assert.True(t, f.GetProbability() > 0.5)
+	}
+
+	_, err = createTokenClassificationFeatures(logits[:5], encodings, 6, labels, opts)
+	assert.Error(t, err)
+	_, err = createTokenClassificationFeatures(logits, encodings, 6, labels[:1], opts)
+	assert.Error(t, err)
+}
@@ -0,0 +1,491 @@
+package predictor
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/c3sr/dlframework"
+	"github.com/c3sr/dlframework/framework/options"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/c3sr/downloadmanager"
+	"github.com/c3sr/tracer"
+	opentracing "github.com/opentracing/opentracing-go"
+	olog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	gotensor "gorgonia.org/tensor"
+)
+
+// modelFile is a file downloaded along the graph of the model, such as its labels or its vocabulary.
+type modelFile struct {
+	// name identifies the file in the traces and the errors
+	name     string
+	url      string
+	path     string
+	checksum string
+	// labels marks the labels file, which is read into the labels of the torchPredictor
+	labels bool
+}
+
+// featuresFile returns the labels file given by the features_url and features_checksum output parameters.
+func featuresFile(base common.Base) modelFile {
+	return modelFile{
+		name:     "features",
+		url:      base.GetFeaturesUrl(),
+		path:     getFeaturesPath(base),
+		checksum: getOutputParameter(base, "features_checksum"),
+		labels:   true,
+	}
+}
+
+// vocabFile returns the WordPiece vocabulary given by the vocab_url and vocab_checksum input parameters.
+func vocabFile(base common.Base) modelFile {
+	return modelFile{
+		name:     "vocab",
+		url:      getVocabUrl(base),
+		path:     getVocabPath(base),
+		checksum: getInputParameter(base, "vocab_checksum"),
+	}
+}
+
+// getFeaturesPath returns the path the labels given by the features_url output parameter are downloaded to.
+func getFeaturesPath(p common.Base) string {
+	return filepath.Join(p.WorkDir, p.Model.GetName()+".features")
+}
+
+// downloadTextFile downloads the vocabulary or labels file, checking its md5 checksum when one is given.
+func downloadTextFile(url, path, checksum string) error {
+	if url == "" {
+		return errors.New("the url is not set")
+	}
+	if checksum != "" {
+		_, _, err := downloadmanager.DownloadFile(url, path, downloadmanager.MD5Sum(checksum))
+		return err
+	}
+	_, _, err := downloadmanager.DownloadFile(url, path)
+	return err
+}
+
+// readLabels reads the labels file, one label per line.
+func readLabels(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read %s", path)
+	}
+	defer f.Close()
+	var labels []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		labels = append(labels, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "cannot read %s", path)
+	}
+	return labels, nil
+}
+
+// torchPredictorHooks are implemented by the predictors embedding a torchPredictor.
+// The torchPredictor provides the defaults of modelFiles and loadModelFiles.
+type torchPredictorHooks interface {
+	// modelFiles returns the files downloaded along the graph.
+	modelFiles() []modelFile
+	// loadModelFiles reads the downloaded files and the manifest parameters, once the labels are read.
+	loadModelFiles(ctx context.Context) error
+	// prepare converts the data of a request into the input tensors of the model, and returns the decoder
+	// of their outputs. The decoder holds the state of the request, such as its postprocessing options.
+	prepare(ctx context.Context, data interface{}, opts ...options.Option) ([]gotensor.Tensor, outputDecoder, error)
+	// decodeOutputs builds the features from the outputs of the model, with the options of the manifest and of the Load.
+	decodeOutputs(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error)
+}
+
+// tilingHooks are implemented by the predictors able to run the model on tiles of large images.
+type tilingHooks interface {
+	// tileOptions returns the tiling of a request.
+	tileOptions(opts ...options.Option) (tileOptions, error)
+}
+
+// batchDecoderHooks are implemented by the predictors that cannot tell the batch size from the outputs of the model.
+type batchDecoderHooks interface {
+	// decodeBatch builds the features of the batchSize rows of the inputs, with the options of the manifest and of the Load.
+	decodeBatch(ctx context.Context, outputs []gotensor.Tensor, batchSize int) ([]dlframework.Features, error)
+}
+
+// outputDecoder builds the features of a request from the outputs of the model.
+type outputDecoder func(ctx context.Context, outputs []gotensor.Tensor) ([]dlframework.Features, error)
+
+// torchPredictor is the TorchScript core embedded by the predictors of this package.
+// It downloads the graph and the model files, loads the TorchScript module, runs it
+// and hands the outputs to the decodeOutputs hook of the embedding predictor.
+//
+// The torchPredictor goes from unloaded to loaded once its module is created, and to closed once Close
+// releases the module. The operations on a predictor that is not loaded return a *PredictorStateError,
+// and Close holds the lock until the running operations return, so that the module is never used once freed.
+//
+// The module is loaded as a pool of replicas, whose size is set through the Replicas option.
+// Infer and InferAndDecode are safe for concurrent use. The Predict and ReadPredictedFeatures pair of the
+// predictor interface shares the outputs of the last prediction, and is kept for the callers running one
+// request at a time.
+type torchPredictor struct {
+	mu      sync.RWMutex
+	state   PredictorState
+	model   string
+	modules *torchModulePool
+	labels  []string
+	hooks   torchPredictorHooks
+
+	outputsMu sync.Mutex
+	outputs   []gotensor.Tensor
+	decoder   outputDecoder
+}
+
+// newTorchBase resolves the framework and the work directory of the model.
+func newTorchBase(model dlframework.ModelManifest, opts ...options.Option) (common.Base, error) {
+	framework, err := model.ResolveFramework()
+	if err != nil {
+		return common.Base{}, err
+	}
+
+	workDir, err := model.WorkDir()
+	if err != nil {
+		return common.Base{}, err
+	}
+
+	return common.Base{
+		Framework: framework,
+		Model:     model,
+		WorkDir:   workDir,
+		Options:   options.New(opts...),
+	}, nil
+}
+
+// downloadModel downloads the graph, or the model archive, and the model files.
+func downloadModel(ctx context.Context, base common.Base, files []modelFile) error {
+	tags := opentracing.Tags{
+		"graph_url":         base.GetGraphUrl(),
+		"target_graph_file": base.GetGraphPath(),
+	}
+	for _, file := range files {
+		tags[file.name+"_url"] = file.url
+		tags["target_"+file.name+"_file"] = file.path
+	}
+	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "download", tags)
+	defer span.Finish()
+
+	model := base.Model
+	if model.Model.IsArchive {
+		baseURL := model.Model.BaseUrl
+		span.LogFields(
+			olog.String("event", "download model archive"),
+		)
+		_, err := downloadmanager.DownloadInto(baseURL, base.WorkDir, downloadmanager.Context(ctx))
+		if err != nil {
+			return errors.Wrapf(err, "failed to download model archive from %v", model.Model.BaseUrl)
+		}
+	} else {
+		span.LogFields(
+			olog.String("event", "download graph"),
+		)
+		checksum := base.GetGraphChecksum()
+		if checksum != "" {
+			if _, _, err := downloadmanager.DownloadFile(base.GetGraphUrl(), base.GetGraphPath(), downloadmanager.MD5Sum(checksum)); err != nil {
+				return err
+			}
+		} else {
+			if _, _, err := downloadmanager.DownloadFile(base.GetGraphUrl(), base.GetGraphPath()); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, file := range files {
+		span.LogFields(
+			olog.String("event", "download "+file.name),
+		)
+		if err := downloadTextFile(file.url, file.path, file.checksum); err != nil {
+			return errors.Wrapf(err, "cannot download the %v file", file.name)
+		}
+	}
+
+	return nil
+}
+
+// load downloads the model and opens it.
+func (p *torchPredictor) load(ctx context.Context, base common.Base, hooks torchPredictorHooks) error {
+	if err := downloadModel(ctx, base, hooks.modelFiles()); err != nil {
+		return err
+	}
+
+	return p.open(ctx, base, hooks)
+}
+
+// open reads the labels of the downloaded model, runs the loadModelFiles hook and loads the TorchScript module.
+func (p *torchPredictor) open(ctx context.Context, base common.Base, hooks torchPredictorHooks) error {
+	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "load_predictor")
+	defer span.Finish()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.state != PredictorUnloaded {
+		return &PredictorStateError{Op: "load", State: p.state}
+	}
+
+	p.hooks = hooks
+	p.model = base.Model.GetName()
+
+	for _, file := range hooks.modelFiles() {
+		if !file.labels {
+			continue
+		}
+		span.LogFields(
+			olog.String("event", "read "+file.name),
+		)
+		labels, err := readLabels(file.path)
+		if err != nil {
+			return err
+		}
+		p.labels = labels
+	}
+
+	if err := hooks.loadModelFiles(ctx); err != nil {
+		return err
+	}
+
+	span.LogFields(
+		olog.String("event", "creating predictor"),
+	)
+
+	opts, err := base.GetPredictionOptions()
+	if err != nil {
+		return err
+	}
+
+	modules, err := newTorchModulePool(
+		ctx,
+		replicasFromOptions(opts),
+		options.WithOptions(opts),
+		options.Graph([]byte(base.GetGraphPath())),
+	)
+	if err != nil {
+		return err
+	}
+
+	p.modules = modules
+	p.state = PredictorLoaded
+
+	return nil
+}
+
+func (p *torchPredictor) modelFiles() []modelFile {
+	return nil
+}
+
+func (p *torchPredictor) loadModelFiles(ctx context.Context) error {
+	return nil
+}
+
+// State returns the lifecycle state of the predictor.
+func (p *torchPredictor) State() PredictorState {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.state
+}
+
+// Infer runs the model on the input tensors, on one of its replicas, and returns the outputs of this run.
+// It is safe for concurrent use.
+func (p *torchPredictor) Infer(ctx context.Context, inputs []gotensor.Tensor) ([]gotensor.Tensor, error) {
+	return p.infer(ctx, "infer", inputs)
+}
+
+func (p *torchPredictor) infer(ctx context.Context, op string, inputs []gotensor.Tensor) ([]gotensor.Tensor, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.state != PredictorLoaded {
+		return nil, &PredictorStateError{Op: op, State: p.state}
+	}
+
+	outputs, err := p.modules.infer(ctx, inputs)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOutputCount(p.model, outputs, 1); err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
+// prepareInputs converts the data of a request with the prepare hook of the embedding predictor,
+// and resolves the tiling of the request when the embedding predictor supports it.
+func (p *torchPredictor) prepareInputs(ctx context.Context, op string, data interface{}, opts ...options.Option) ([]gotensor.Tensor, outputDecoder, tileOptions, error) {
+	if state := p.State(); state != PredictorLoaded {
+		return nil, nil, tileOptions{}, &PredictorStateError{Op: op, State: state}
+	}
+	if data == nil {
+		return nil, nil, tileOptions{}, errors.New("input data nil")
+	}
+
+	var tiles tileOptions
+	if hooks, ok := p.hooks.(tilingHooks); ok {
+		var err error
+		if tiles, err = hooks.tileOptions(opts...); err != nil {
+			return nil, nil, tileOptions{}, err
+		}
+	}
+
+	inputs, decoder, err := p.hooks.prepare(ctx, data, opts...)
+	if err != nil {
+		return nil, nil, tileOptions{}, err
+	}
+	return inputs, decoder, tiles, nil
+}
+
+// run runs the model on the inputs of a request, tile by tile when the tiling is enabled.
+func (p *torchPredictor) run(ctx context.Context, op string, inputs []gotensor.Tensor, tiles tileOptions) ([]gotensor.Tensor, error) {
+	if !tiles.enabled() {
+		return p.infer(ctx, op, inputs)
+	}
+	return inferTiled(ctx, func(ctx context.Context, inputs []gotensor.Tensor) ([]gotensor.Tensor, error) {
+		return p.infer(ctx, op, inputs)
+	}, inputs, tiles)
+}
+
+// InferAndDecode runs the model on the data and returns the features and the outputs of this run,
+// in a single call. The outputs are returned for the callers that need them, and may be ignored.
+// It is safe for concurrent use: nothing is kept on the predictor between the inference and the decoding.
+func (p *torchPredictor) InferAndDecode(ctx context.Context, data interface{}, opts ...options.Option) ([]dlframework.Features, []gotensor.Tensor, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "infer_and_decode")
+	defer span.Finish()
+
+	inputs, decoder, tiles, err := p.prepareInputs(ctx, "infer_and_decode", data, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outputs, err := p.run(ctx, "infer_and_decode", inputs, tiles)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	features, err := decoder(ctx, outputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return features, outputs, nil
+}
+
+// Predict runs the model on the data and keeps its outputs for ReadPredictedFeatures.
+func (p *torchPredictor) Predict(ctx context.Context, data interface{}, opts ...options.Option) error {
+	inputs, decoder, tiles, err := p.prepareInputs(ctx, "predict", data, opts...)
+	if err != nil {
+		return err
+	}
+
+	outputs, err := p.run(ctx, "predict", inputs, tiles)
+	if err != nil {
+		return err
+	}
+
+	p.outputsMu.Lock()
+	p.outputs = outputs
+	p.decoder = decoder
+	p.outputsMu.Unlock()
+
+	return nil
+}
+
+// readOutputs returns the outputs of the last prediction and their decoder.
+func (p *torchPredictor) readOutputs(ctx context.Context, op string) ([]gotensor.Tensor, outputDecoder, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.state != PredictorLoaded {
+		return nil, nil, &PredictorStateError{Op: op, State: p.state}
+	}
+
+	p.outputsMu.Lock()
+	defer p.outputsMu.Unlock()
+
+	if p.outputs == nil {
+		return nil, nil, errors.New("no prediction was run")
+	}
+
+	return p.outputs, p.decoder, nil
+}
+
+// ReadPredictedFeatures ...
+func (p *torchPredictor) ReadPredictedFeatures(ctx context.Context) ([]dlframework.Features, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features")
+	defer span.Finish()
+
+	outputs, decoder, err := p.readOutputs(ctx, "read_predicted_features")
+	if err != nil {
+		return nil, err
+	}
+
+	return decoder(ctx, outputs)
+}
+
+// decode builds the features of the rows of the inputs from the outputs with the decodeOutputs hook of the embedding
+// predictor, or with its decodeBatch hook when it implements one.
+func (p *torchPredictor) decode(ctx context.Context, outputs []gotensor.Tensor, rows int) ([]dlframework.Features, error) {
+	if hooks, ok := p.hooks.(batchDecoderHooks); ok {
+		return hooks.decodeBatch(ctx, outputs, rows)
+	}
+	return p.hooks.decodeOutputs(ctx, outputs)
+}
+
+// ReadPredictedFeaturesAsMap ...
+func (p *torchPredictor) ReadPredictedFeaturesAsMap(ctx context.Context) (map[string]interface{}, error) {
+	span, ctx := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "read_predicted_features_as_map")
+	defer span.Finish()
+
+	outputs, _, err := p.readOutputs(ctx, "read_predicted_features_as_map")
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]interface{})
+	res["outputs"] = outputs
+	if p.labels != nil {
+		res["labels"] = p.labels
+	}
+
+	return res, nil
+}
+
+// Reset ...
+func (p *torchPredictor) Reset(ctx context.Context) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.state != PredictorLoaded {
+		return &PredictorStateError{Op: "reset", State: p.state}
+	}
+
+	return nil
+}
+
+// Close releases the replicas of the TorchScript module, once the running operations return.
+// Closing a closed predictor is a no-op.
+func (p *torchPredictor) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.modules != nil {
+		p.modules.close()
+		p.modules = nil
+	}
+	p.state = PredictorClosed
+
+	p.outputsMu.Lock()
+	p.outputs = nil
+	p.decoder = nil
+	p.outputsMu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package predictor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/c3sr/dlframework"
+	common "github.com/c3sr/dlframework/framework/predictor"
+	"github.com/stretchr/testify/assert"
+	gotensor "gorgonia.org/tensor"
+)
+
+func TestModelFiles(t *testing.T) {
+	base := common.Base{
+		Model: dlframework.ModelManifest{
+			Name: "BERT",
+			Inputs: []*dlframework.ModelManifest_Type{
+				{
+					Type: "general",
+					Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+						"vocab_url":      {Value: "http://example.com/vocab.txt"},
+						"vocab_checksum": {Value: "abc"},
+					},
+				},
+			},
+			Output: &dlframework.ModelManifest_Type{
+				Type: "text_classification",
+				Parameters: map[string]*dlframework.ModelManifest_Type_Parameter{
+					"features_url": {Value: "http://example.com/labels.txt"},
+				},
+			},
+		},
+		WorkDir: "/tmp/work",
+	}
+
+	assert.Equal(t, modelFile{
+		name:     "features",
+		url:      "http://example.com/labels.txt",
+		path:     filepath.Join("/tmp/work", "BERT.features"),
+		checksum: "",
+		labels:   true,
+	}, featuresFile(base))
+
+	assert.Equal(t, modelFile{
+		name:     "vocab",
+		url:      "http://example.com/vocab.txt",
+		path:     filepath.Join("/tmp/work", "BERT.vocab"),
+		checksum: "abc",
+	}, vocabFile(base))
+}
+
+func TestReadLabels(t *testing.T) {
+	dir, err := ioutil.TempDir("", "labels")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "model.features")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("cat\ndog\n"), 0644))
+
+	labels, err := readLabels(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cat", "dog"}, labels)
+
+	_, err = readLabels(filepath.Join(dir, "missing.features"))
+	assert.Error(t, err)
+
+	assert.Error(t, downloadTextFile("", path, ""))
+}
+
+func TestInferAndDecode(t *testing.T) {
+	withFakeTorchModules(t)
+	ctx := context.Background()
+	p := openFakeTorchPredictor(t, Replicas(2))
+
+	var wg sync.WaitGroup
+	for ii := 0; ii < 32; ii++ {
+		wg.Add(1)
+		go func(ii int) {
+			defer wg.Done()
+			input := gotensor.New(gotensor.WithShape(1, 1), gotensor.WithBacking([]float32{float32(ii)}))
+			features, outputs, err := p.InferAndDecode(ctx, []gotensor.Tensor{input})
+			assert.NoError(t, err)
+			assert.Len(t, features, 1)
+			if assert.Len(t, outputs, 1) {
+				assert.Equal(t, []float32{float32(ii)}, outputs[0].Data())
+			}
+		}(ii)
+	}
+	wg.Wait()
+
+	// nothing was kept for ReadPredictedFeatures
+	_, err := p.ReadPredictedFeatures(ctx)
+	assert.Error(t, err)
+	_, _, err = p.InferAndDecode(ctx, nil)
+	assert.Error(t, err)
+
+	assert.NoError(t, p.Close())
+	_, _, err = p.InferAndDecode(ctx, []gotensor.Tensor{})
+	assert.True(t, IsPredictorClosed(err))
+}
+
+func TestInferAndDecodeRequestOptions(t *testing.T) {
+	labels := []string{"cat", "dog", "fish", "bird"}
+	p, _ := openFakeImageClassificationPredictor(t, labels, Replicas(2))
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for k := 1; k <= len(labels); k++ {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			features, _, err := p.InferAndDecode(ctx, []gotensor.Tensor{oneHot([]int{k - 1}, len(labels))}, TopK(k))
+			assert.NoError(t, err)
+			if assert.Len(t, features, 1) && assert.Len(t, features[0], k) {
+				assert.Equal(t, labels[k-1], features[0][0].GetClassification().GetLabel())
+			}
+		}(k)
+	}
+	wg.Wait()
+}
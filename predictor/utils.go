@@ -4,10 +4,14 @@ import (
 	"image"
 	"image/png"
 	"os"
-	"runtime"
-	"runtime/debug"
+	"reflect"
+	"strconv"
 
+	"github.com/c3sr/dlframework"
+	common "github.com/c3sr/dlframework/framework/predictor"
 	imagetypes "github.com/c3sr/image/types"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
 	gotensor "gorgonia.org/tensor"
 )
 
@@ -15,6 +19,241 @@ func makeFloatSliceFromGoTensors(in0 []*gotensor.Dense) ([]float32, error) {
 	return nil, nil
 }
 
+// tensorData returns the backing data of the tensor as a slice. gorgonia returns the value of the 0-d
+// and single element tensors, such as the labels of a single detection, which is wrapped in a slice.
+func tensorData(t gotensor.Tensor) interface{} {
+	data := t.Data()
+	value := reflect.ValueOf(data)
+	if data == nil || value.Kind() == reflect.Slice {
+		return data
+	}
+	res := reflect.MakeSlice(reflect.SliceOf(value.Type()), 1, 1)
+	res.Index(0).Set(value)
+	return res.Interface()
+}
+
+// tensorToFloat32s returns the backing data of the tensor as a float32 slice,
+// converting the other dtypes, such as integer class indices or boolean masks, on the way.
+// The float32 data is returned as is, and the converted data keeps the layout of the backing array.
+func tensorToFloat32s(t gotensor.Tensor) ([]float32, error) {
+	switch data := tensorData(t).(type) {
+	case []float32:
+		return data, nil
+	case []float64:
+		res := make([]float32, len(data))
+		for ii, v := range data {
+			res[ii] = float32(v)
+		}
+		return res, nil
+	case []int64:
+		res := make([]float32, len(data))
+		for ii, v := range data {
+			res[ii] = float32(v)
+		}
+		return res, nil
+	case []int32:
+		res := make([]float32, len(data))
+		for ii, v := range data {
+			res[ii] = float32(v)
+		}
+		return res, nil
+	case []int16:
+		res := make([]float32, len(data))
+		for ii, v := range data {
+			res[ii] = float32(v)
+		}
+		return res, nil
+	case []int8:
+		res := make([]float32, len(data))
+		for ii, v := range data {
+			res[ii] = float32(v)
+		}
+		return res, nil
+	case []uint8:
+		res := make([]float32, len(data))
+		for ii, v := range data {
+			res[ii] = float32(v)
+		}
+		return res, nil
+	case []bool:
+		res := make([]float32, len(data))
+		for ii, v := range data {
+			if v {
+				res[ii] = 1
+			}
+		}
+		return res, nil
+	}
+	return nil, errors.Errorf("unsupported tensor data type %v", t.Dtype())
+}
+
+// isIntegerDtype reports whether the tensor holds integers, such as class indices.
+func isIntegerDtype(dtype gotensor.Dtype) bool {
+	switch dtype {
+	case gotensor.Int64, gotensor.Int32, gotensor.Int16, gotensor.Int8, gotensor.Uint8:
+		return true
+	}
+	return false
+}
+
+// tensorToInt32s returns the backing data of an integer tensor as an int32 slice.
+// The int32 data is returned as is.
+func tensorToInt32s(t gotensor.Tensor) ([]int32, error) {
+	switch data := tensorData(t).(type) {
+	case []int32:
+		return data, nil
+	case []int64:
+		res := make([]int32, len(data))
+		for ii, v := range data {
+			res[ii] = int32(v)
+		}
+		return res, nil
+	case []int16:
+		res := make([]int32, len(data))
+		for ii, v := range data {
+			res[ii] = int32(v)
+		}
+		return res, nil
+	case []int8:
+		res := make([]int32, len(data))
+		for ii, v := range data {
+			res[ii] = int32(v)
+		}
+		return res, nil
+	case []uint8:
+		res := make([]int32, len(data))
+		for ii, v := range data {
+			res[ii] = int32(v)
+		}
+		return res, nil
+	}
+	return nil, errors.Errorf("expecting an integer tensor, but got %v", t.Dtype())
+}
+
+// getOutputParameter returns the output parameter of the model manifest, or an empty string when it is not set.
+func getOutputParameter(p common.Base, name string) string {
+	str, err := p.GetTypeParameter(p.Model.GetOutput().GetParameters(), name)
+	if err != nil {
+		return ""
+	}
+	return str
+}
+
+// getOutputFloat32Parameter returns the float output parameter of the model manifest,
+// or defaultValue when the parameter is not set.
+func getOutputFloat32Parameter(p common.Base, name string, defaultValue float32) float32 {
+	str, err := p.GetTypeParameter(p.Model.GetOutput().GetParameters(), name)
+	if err != nil || str == "" {
+		return defaultValue
+	}
+	val, err := strconv.ParseFloat(str, 32)
+	if err != nil {
+		log.WithError(err).Errorf("unable to get %v %v as a float32", name, str)
+		return defaultValue
+	}
+	return float32(val)
+}
+
+// getOutputIntParameter returns the integer output parameter of the model manifest,
+// or defaultValue when the parameter is not set.
+func getOutputIntParameter(p common.Base, name string, defaultValue int) int {
+	str, err := p.GetTypeParameter(p.Model.GetOutput().GetParameters(), name)
+	if err != nil || str == "" {
+		return defaultValue
+	}
+	val, err := strconv.Atoi(str)
+	if err != nil {
+		log.WithError(err).Errorf("unable to get %v %v as an integer", name, str)
+		return defaultValue
+	}
+	return val
+}
+
+// getOutputBoolParameter returns the boolean output parameter of the model manifest,
+// or defaultValue when the parameter is not set.
+func getOutputBoolParameter(p common.Base, name string, defaultValue bool) bool {
+	str := getOutputParameter(p, name)
+	if str == "" {
+		return defaultValue
+	}
+	val, err := strconv.ParseBool(str)
+	if err != nil {
+		log.WithError(err).Errorf("unable to get %v %v as a boolean", name, str)
+		return defaultValue
+	}
+	return val
+}
+
+// getInputParameter returns the parameter of the first model input, or an empty string when it is not set.
+func getInputParameter(p common.Base, name string) string {
+	inputs := p.Model.GetInputs()
+	if len(inputs) == 0 {
+		return ""
+	}
+	str, err := p.GetTypeParameter(inputs[0].GetParameters(), name)
+	if err != nil {
+		return ""
+	}
+	return str
+}
+
+// getInputIntParameter returns the integer parameter of the first model input,
+// or defaultValue when the parameter is not set.
+func getInputIntParameter(p common.Base, name string, defaultValue int) int {
+	str := getInputParameter(p, name)
+	if str == "" {
+		return defaultValue
+	}
+	val, err := strconv.Atoi(str)
+	if err != nil {
+		log.WithError(err).Errorf("unable to get %v %v as an integer", name, str)
+		return defaultValue
+	}
+	return val
+}
+
+// getInputBoolParameter returns the boolean parameter of the first model input,
+// or defaultValue when the parameter is not set.
+func getInputBoolParameter(p common.Base, name string, defaultValue bool) bool {
+	str := getInputParameter(p, name)
+	if str == "" {
+		return defaultValue
+	}
+	val, err := strconv.ParseBool(str)
+	if err != nil {
+		log.WithError(err).Errorf("unable to get %v %v as a boolean", name, str)
+		return defaultValue
+	}
+	return val
+}
+
+// getOutputListParameter unmarshals the yaml list output parameter of the model manifest into out.
+// It returns false when the parameter is not set.
+func getOutputListParameter(p common.Base, name string, out interface{}) (bool, error) {
+	return unmarshalListParameter(p.Model.GetOutput().GetParameters(), name, out)
+}
+
+// getInputListParameter unmarshals the yaml list parameter of the first model input into out.
+// It returns false when the parameter is not set.
+func getInputListParameter(p common.Base, name string, out interface{}) (bool, error) {
+	inputs := p.Model.GetInputs()
+	if len(inputs) == 0 {
+		return false, nil
+	}
+	return unmarshalListParameter(inputs[0].GetParameters(), name, out)
+}
+
+func unmarshalListParameter(params map[string]*dlframework.ModelManifest_Type_Parameter, name string, out interface{}) (bool, error) {
+	param, ok := params[name]
+	if !ok || param == nil || param.GetValue() == "" {
+		return false, nil
+	}
+	if err := yaml.Unmarshal([]byte(param.GetValue()), out); err != nil {
+		return false, errors.Errorf("unable to get %v %v as a list", name, param.GetValue())
+	}
+	return true, nil
+}
+
 func toPng(filePath string, imgByte []byte, bounds image.Rectangle) {
 
 	img := imagetypes.NewRGBImage(bounds)
@@ -40,8 +279,3 @@ func zeros(height, width, channels int) [][][]float32 {
 	}
 	return rows
 }
-
-func forceGC() {
-	runtime.GC()
-	debug.FreeOSMemory()
-}
@@ -0,0 +1,227 @@
+package predictor
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	bertUnknownToken   = "[UNK]"
+	bertClassifyToken  = "[CLS]"
+	bertSeparatorToken = "[SEP]"
+
+	wordPiecePrefix          = "##"
+	wordPieceMaxInputRunes   = 100
+	wordPieceDefaultCapacity = 30522
+)
+
+// WordPieceTokenizer splits text into the WordPiece tokens of a BERT vocabulary.
+// It runs the basic tokenization of BERT (cleanup, optional lower casing and accent stripping,
+// punctuation and CJK splitting) followed by a greedy longest-match-first WordPiece split.
+type WordPieceTokenizer struct {
+	vocab       map[string]int64
+	doLowerCase bool
+}
+
+// NewWordPieceTokenizer creates a tokenizer from the vocabulary, one token per line,
+// where the line number is the token id.
+func NewWordPieceTokenizer(r io.Reader, doLowerCase bool) (*WordPieceTokenizer, error) {
+	vocab := make(map[string]int64, wordPieceDefaultCapacity)
+	scanner := bufio.NewScanner(r)
+	var id int64
+	for scanner.Scan() {
+		token := strings.TrimSpace(scanner.Text())
+		if _, ok := vocab[token]; !ok {
+			vocab[token] = id
+		}
+		id++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "cannot read the vocabulary")
+	}
+	if _, ok := vocab[bertUnknownToken]; !ok {
+		return nil, errors.Errorf("the vocabulary does not contain the %v token", bertUnknownToken)
+	}
+	return &WordPieceTokenizer{
+		vocab:       vocab,
+		doLowerCase: doLowerCase,
+	}, nil
+}
+
+// NewWordPieceTokenizerFromFile creates a tokenizer from the vocabulary file at path.
+func NewWordPieceTokenizerFromFile(path string, doLowerCase bool) (*WordPieceTokenizer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read %s", path)
+	}
+	defer f.Close()
+	return NewWordPieceTokenizer(f, doLowerCase)
+}
+
+// Tokenize returns the WordPiece tokens of the text.
+func (t *WordPieceTokenizer) Tokenize(text string) []string {
+	var res []string
+	for _, token := range basicTokenize(text, t.doLowerCase) {
+		res = append(res, t.wordPieceTokenize(token)...)
+	}
+	return res
+}
+
+// ConvertTokensToIDs returns the vocabulary ids of the tokens.
+// Tokens missing from the vocabulary are mapped to the id of [UNK].
+func (t *WordPieceTokenizer) ConvertTokensToIDs(tokens []string) []int64 {
+	res := make([]int64, len(tokens))
+	for ii, token := range tokens {
+		id, ok := t.vocab[token]
+		if !ok {
+			id = t.vocab[bertUnknownToken]
+		}
+		res[ii] = id
+	}
+	return res
+}
+
+// basicTokenize cleans the text and splits it on whitespace, punctuation and CJK characters.
+func basicTokenize(text string, doLowerCase bool) []string {
+	text = cleanText(text)
+	text = padCJKCharacters(text)
+	var res []string
+	for _, token := range strings.Fields(text) {
+		if doLowerCase {
+			token = stripAccents(strings.ToLower(token))
+		}
+		res = append(res, splitOnPunctuation(token)...)
+	}
+	return res
+}
+
+// wordPieceTokenize splits a single word into the longest pieces found in the vocabulary.
+// A word that cannot be split is replaced by [UNK].
+func (t *WordPieceTokenizer) wordPieceTokenize(word string) []string {
+	runes := []rune(word)
+	if len(runes) > wordPieceMaxInputRunes {
+		return []string{bertUnknownToken}
+	}
+	var res []string
+	for start := 0; start < len(runes); {
+		end := len(runes)
+		piece := ""
+		for ; start < end; end-- {
+			candidate := string(runes[start:end])
+			if start > 0 {
+				candidate = wordPiecePrefix + candidate
+			}
+			if _, ok := t.vocab[candidate]; ok {
+				piece = candidate
+				break
+			}
+		}
+		if piece == "" {
+			return []string{bertUnknownToken}
+		}
+		res = append(res, piece)
+		start = end
+	}
+	return res
+}
+
+// cleanText removes the invalid and control characters and normalizes the whitespace.
+func cleanText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if r == 0 || r == unicode.ReplacementChar || isControl(r) {
+			continue
+		}
+		if isWhitespace(r) {
+			b.WriteRune(' ')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func padCJKCharacters(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if isCJKCharacter(r) {
+			b.WriteRune(' ')
+			b.WriteRune(r)
+			b.WriteRune(' ')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func stripAccents(text string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(text) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func splitOnPunctuation(text string) []string {
+	var res []string
+	var current []rune
+	for _, r := range text {
+		if isPunctuation(r) {
+			if len(current) > 0 {
+				res = append(res, string(current))
+				current = nil
+			}
+			res = append(res, string(r))
+			continue
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		res = append(res, string(current))
+	}
+	return res
+}
+
+func isWhitespace(r rune) bool {
+	if r == ' ' || r == '\t' || r == '\n' || r == '\r' {
+		return true
+	}
+	return unicode.Is(unicode.Zs, r)
+}
+
+func isControl(r rune) bool {
+	if r == '\t' || r == '\n' || r == '\r' {
+		return false
+	}
+	return unicode.In(r, unicode.Cc, unicode.Cf, unicode.Co, unicode.Cs)
+}
+
+// isPunctuation treats all the non-letter, non-number ASCII characters as punctuation,
+// as BERT does, along with the unicode punctuation categories.
+func isPunctuation(r rune) bool {
+	if (r >= 33 && r <= 47) || (r >= 58 && r <= 64) || (r >= 91 && r <= 96) || (r >= 123 && r <= 126) {
+		return true
+	}
+	return unicode.IsPunct(r)
+}
+
+func isCJKCharacter(r rune) bool {
+	return (r >= 0x4E00 && r <= 0x9FFF) ||
+		(r >= 0x3400 && r <= 0x4DBF) ||
+		(r >= 0x20000 && r <= 0x2A6DF) ||
+		(r >= 0x2A700 && r <= 0x2B73F) ||
+		(r >= 0x2B740 && r <= 0x2B81F) ||
+		(r >= 0x2B820 && r <= 0x2CEAF) ||
+		(r >= 0xF900 && r <= 0xFAFF) ||
+		(r >= 0x2F800 && r <= 0x2FA1F)
+}
@@ -0,0 +1,40 @@
+package predictor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testWordPieceTokenizer(t *testing.T, doLowerCase bool) *WordPieceTokenizer {
+	vocab := []string{
+		"[PAD]", "[UNK]", "[CLS]", "[SEP]",
+		"the", "man", "went", "to", "store", "un", "##want", "##ed", ",", ".", "?",
+		"runn", "##ing", "cafe", "who", "john", "smith", "born", "(", ")", "-", "1895", "1943",
+		"was", "leader", "when", "year", "中", "国",
+	}
+	tokenizer, err := NewWordPieceTokenizer(strings.NewReader(strings.Join(vocab, "\n")), doLowerCase)
+	assert.NoError(t, err)
+	return tokenizer
+}
+
+func TestWordPieceTokenizer(t *testing.T) {
+	tokenizer := testWordPieceTokenizer(t, true)
+
+	assert.Equal(t, []string{"un", "##want", "##ed", ",", "runn", "##ing"}, tokenizer.Tokenize("UNwantéd,running"))
+	assert.Equal(t, []string{"the", "man", "[UNK]", "cafe", "."}, tokenizer.Tokenize(" The\tman jumped\n CAFÉ. "))
+	assert.Equal(t, []string{"(", "1895", "-", "1943", ")", "."}, tokenizer.Tokenize("(1895-1943)."))
+	assert.Equal(t, []string{"中", "国"}, tokenizer.Tokenize("中国"))
+	assert.Equal(t, []string{"[UNK]"}, tokenizer.Tokenize(strings.Repeat("a", wordPieceMaxInputRunes+1)))
+
+	assert.Equal(t, []int64{2, 4, 1, 3}, tokenizer.ConvertTokensToIDs([]string{"[CLS]", "the", "missing", "[SEP]"}))
+
+	// cased tokenizers keep the case and the accents
+	cased := testWordPieceTokenizer(t, false)
+	assert.Equal(t, []string{"[UNK]", "man"}, cased.Tokenize("The man"))
+	assert.Equal(t, []string{"[UNK]"}, cased.Tokenize("café"))
+
+	_, err := NewWordPieceTokenizer(strings.NewReader("the\nman"), true)
+	assert.Error(t, err)
+}